@@ -0,0 +1,26 @@
+// Command eventsink records the CloudEvents posted to it and serves them as
+// JSON on /events. Its image, built with the Dockerfile of this directory, is
+// the sink deployed in the cluster by pkg/eventsink. Run locally, register it
+// as the cloudEventURI of an EventListener or as the
+// default-cloud-events-sink of the pipelines when the cluster can reach it.
+//
+//	go run ./cmd/eventsink [-addr <address>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/openshift-pipelines/release-tests/pkg/eventsink"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address the events are received on")
+	flag.Parse()
+
+	if err := eventsink.Serve(*addr); err != nil {
+		fmt.Fprintf(os.Stderr, "eventsink: %v\n", err)
+		os.Exit(1)
+	}
+}
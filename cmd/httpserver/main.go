@@ -0,0 +1,26 @@
+// Command httpserver serves the files of a directory and the routes set on
+// /_routes, making it respond with basic auth, redirects, delays or error
+// statuses. Its image, built with the Dockerfile of this directory, is the
+// server deployed in the cluster by pkg/httpserver.
+//
+//	go run ./cmd/httpserver [-addr <address>] [-root <directory>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/openshift-pipelines/release-tests/pkg/httpserver"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address the files are served on")
+	root := flag.String("root", ".", "directory of the served files")
+	flag.Parse()
+
+	if err := httpserver.Serve(*addr, *root); err != nil {
+		fmt.Fprintf(os.Stderr, "httpserver: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,38 @@
+// Command speclint checks offline that the steps used by the specs and
+// concepts are implemented, that every step implementation is used, that the
+// testdata files referenced by the specs exist and that tc_spec_map.json is in
+// sync with the test case IDs of the scenarios. It exits with status 1 when it
+// finds a problem other than a warning.
+//
+//	go run ./cmd/speclint [-root <repository>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/openshift-pipelines/release-tests/pkg/spec"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the repository")
+	flag.Parse()
+
+	findings, err := spec.Lint(*root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "speclint: %v\n", err)
+		os.Exit(2)
+	}
+	problems := 0
+	for _, f := range findings {
+		fmt.Println(f)
+		if !f.Warning() {
+			problems++
+		}
+	}
+	if problems > 0 {
+		fmt.Fprintf(os.Stderr, "speclint: %d problem(s) found\n", problems)
+		os.Exit(1)
+	}
+}
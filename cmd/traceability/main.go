@@ -0,0 +1,72 @@
+// Command traceability catalogues the scenarios of the specs from the ID and
+// metadata of their headers. It regenerates tc_spec_map.json and writes the
+// catalogue (catalogue.json, catalogue.csv) and the coverage matrix per
+// component (coverage.csv, coverage.md) to the output directory.
+//
+//	go run ./cmd/traceability [-root <repository>] [-out <directory>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift-pipelines/release-tests/pkg/spec"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory of the repository")
+	out := flag.String("out", filepath.Join("reports", "traceability"), "directory where the catalogue and the coverage matrix are written")
+	flag.Parse()
+
+	if err := run(*root, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "traceability: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(root, out string) error {
+	catalogue, err := spec.NewCatalogue(root)
+	if err != nil {
+		return err
+	}
+
+	mapPath := filepath.Join(root, spec.TCSpecMapFile)
+	if err := spec.WriteTCSpecMap(mapPath, catalogue.TCSpecMap()); err != nil {
+		return fmt.Errorf("failed to write %s: %v", mapPath, err)
+	}
+	fmt.Println(mapPath)
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	for name, write := range map[string]func(io.Writer) error{
+		"catalogue.json": catalogue.WriteJSON,
+		"catalogue.csv":  catalogue.WriteCSV,
+		"coverage.csv":   catalogue.WriteCoverageCSV,
+		"coverage.md":    catalogue.WriteCoverageMarkdown,
+	} {
+		if err := writeFile(filepath.Join(out, name), write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
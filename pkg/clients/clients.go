@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -26,7 +27,7 @@ import (
 
 // KubeClient holds instances of interfaces for making requests to kubernetes client.
 type KubeClient struct {
-	Kube *kubernetes.Clientset
+	Kube kubernetes.Interface
 }
 
 // Clients holds instances of interfaces for making requests to Tekton Pipelines.
@@ -50,6 +51,8 @@ type Clients struct {
 	PipelineRunClient  v1.PipelineRunInterface
 	TriggersClient     triggersclientset.Interface
 	ApprovalTask       apclient.ApprovalTaskInterface
+	// ApprovalTaskClientset is used to scope ApprovalTask to a namespace in NewClientSet
+	ApprovalTaskClientset apclient.OpenshiftpipelinesV1alpha1Interface
 }
 
 // NewClients instantiates and returns several clientsets required for making request to the
@@ -105,6 +108,28 @@ func NewClients(configPath string, clusterName, namespace string) (*Clients, err
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pac clientset from config file at %s: %s", configPath, err)
 	}
+
+	clients.Route, err = routev1.NewForConfig(clients.KubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create route clientset from config file at %s: %s", configPath, err)
+	}
+
+	clients.ProxyConfig, err = configV1.NewForConfig(clients.KubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config clientset from config file at %s: %s", configPath, err)
+	}
+	clients.ClusterVersion = clients.ProxyConfig.ClusterVersions()
+
+	consoleClient, err := consolev1.NewForConfig(clients.KubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create console clientset from config file at %s: %s", configPath, err)
+	}
+	clients.ConsoleCLIDownload = consoleClient.ConsoleCLIDownloads()
+
+	clients.ApprovalTaskClientset, err = apclient.NewForConfig(clients.KubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval task clientset from config file at %s: %s", configPath, err)
+	}
 	clients.NewClientSet(namespace)
 	return clients, nil
 }
@@ -180,15 +205,21 @@ func (c *Clients) PipelinesAsCode() operatorv1alpha1.OpenShiftPipelinesAsCodeInt
 	return c.Operator.OpenShiftPipelinesAsCodes()
 }
 
+// NewClientSet scopes the namespaced clients to namespace. Cluster-scoped
+// clients are created once in NewClients and are left untouched.
 func (c *Clients) NewClientSet(namespace string) {
 	c.PipelineClient = c.Tekton.TektonV1().Pipelines(namespace)
 	c.TaskClient = c.Tekton.TektonV1().Tasks(namespace)
 	c.TaskRunClient = c.Tekton.TektonV1().TaskRuns(namespace)
 	c.PipelineRunClient = c.Tekton.TektonV1().PipelineRuns(namespace)
-	c.Route = routev1.NewForConfigOrDie(c.KubeConfig)
-	c.ProxyConfig = configV1.NewForConfigOrDie(c.KubeConfig)
-	c.ClusterVersion = configV1.NewForConfigOrDie(c.KubeConfig).ClusterVersions()
-	c.ConsoleCLIDownload = consolev1.NewForConfigOrDie(c.KubeConfig).ConsoleCLIDownloads()
-	c.ApprovalTask = apclient.NewForConfigOrDie(c.KubeConfig).ApprovalTasks(namespace)
-	c.PacClientset = pacclientset.NewForConfigOrDie(c.KubeConfig)
+	c.ApprovalTask = c.ApprovalTaskClientset.ApprovalTasks(namespace)
+}
+
+// Reporter returns the reporter.Reporter attached to c.Ctx, or the default one.
+// Helpers report their assertion failures through it.
+func (c *Clients) Reporter() reporter.Reporter {
+	if c == nil {
+		return reporter.Default()
+	}
+	return reporter.FromContext(c.Ctx)
 }
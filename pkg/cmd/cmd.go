@@ -1,49 +1,77 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	"github.com/openshift-pipelines/release-tests/pkg/runreport"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/icmd"
 )
 
-// testsuitAdaptor bridges the gap between testsuit.T and assert.TestingT as
-// testsuit.T does not implement assert.TestingT interface
-type testsuitAdaptor struct{}
+// reporterAdaptor bridges the gap between reporter.Reporter and assert.TestingT.
+// assert logs the failure message before calling FailNow, so Log only keeps the
+// message and the failure is reported once with it.
+type reporterAdaptor struct {
+	r   reporter.Reporter
+	msg []string
+}
+
+// ensure reporterAdaptor satisfies assert.TestingT interface
+var _ assert.TestingT = (*reporterAdaptor)(nil)
+
+func newReporterAdaptor() *reporterAdaptor {
+	return &reporterAdaptor{r: reporter.Default()}
+}
 
-// ensure testsuitAdaptor satisfies assert.TestingT interface
-var _ assert.TestingT = (*testsuitAdaptor)(nil)
+// Fail is fatal like FailNow, the callers of the assertions not expecting to
+// continue after a failed command
+func (ra *reporterAdaptor) Fail() {
+	ra.r.Fail(errors.New(ra.message("step failed execute")))
+}
+
+func (ra *reporterAdaptor) FailNow() {
+	ra.r.Fail(errors.New(ra.message("step failed to execute")))
+}
 
-func (ta testsuitAdaptor) Fail() {
-	testsuit.T.Fail(fmt.Errorf("step failed execute"))
+func (ra *reporterAdaptor) Log(args ...interface{}) {
+	ra.msg = append(ra.msg, fmt.Sprint(args...))
 }
 
-func (ta testsuitAdaptor) FailNow() {
-	testsuit.T.Fail(fmt.Errorf("step failed to execute"))
+func (ra *reporterAdaptor) message(fallback string) string {
+	if len(ra.msg) == 0 {
+		return fallback
+	}
+	return strings.Join(ra.msg, "\n")
 }
 
-func (ta testsuitAdaptor) Log(args ...interface{}) {
-	testsuit.T.Fail(fmt.Errorf("%v", args))
+// run executes c and records the invocation in the run report
+func run(c icmd.Cmd) *icmd.Result {
+	started := time.Now()
+	res := icmd.RunCmd(c)
+	runreport.RecordCommand(c.Command, res.ExitCode, started, res.Error)
+	return res
 }
 
 func Run(cmd ...string) *icmd.Result {
-	return icmd.RunCmd(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout})
+	return run(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout})
 }
 
 func RunWithStdin(stdin io.Reader, cmd ...string) *icmd.Result {
-	return icmd.RunCmd(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout, Stdin: stdin})
+	return run(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout, Stdin: stdin})
 }
 
 // RunWithEnv runs a command inheriting the current process environment, with additional env entries appended.
 // Use this to safely override values like KUBECONFIG while preserving PATH and other required env vars.
 func RunWithEnv(env []string, cmd ...string) *icmd.Result {
 	fullEnv := append(os.Environ(), env...)
-	return icmd.RunCmd(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout, Env: fullEnv})
+	return run(icmd.Cmd{Command: cmd, Timeout: config.CLITimeout, Env: fullEnv})
 }
 
 // MustSucceed asserts that the command ran with 0 exit code
@@ -62,21 +90,21 @@ func MustSucceedWithStdin(stdin io.Reader, args ...string) *icmd.Result {
 // Assert runs a command and verifies exit code (0)
 func Assert(exp icmd.Expected, args ...string) *icmd.Result {
 	res := Run(args...)
-	t := &testsuitAdaptor{}
+	t := newReporterAdaptor()
 	res.Assert(t, exp)
 	return res
 }
 
 func AssertWithEnv(exp icmd.Expected, env []string, args ...string) *icmd.Result {
 	res := RunWithEnv(env, args...)
-	t := &testsuitAdaptor{}
+	t := newReporterAdaptor()
 	res.Assert(t, exp)
 	return res
 }
 
 func AssertWithStdin(exp icmd.Expected, stdin io.Reader, args ...string) *icmd.Result {
 	res := RunWithStdin(stdin, args...)
-	t := &testsuitAdaptor{}
+	t := newReporterAdaptor()
 	res.Assert(t, exp)
 	return res
 }
@@ -87,11 +115,11 @@ func MustSuccedIncreasedTimeout(timeout time.Duration, args ...string) *icmd.Res
 
 func AssertIncreasedTimeout(exp icmd.Expected, timeout time.Duration, args ...string) *icmd.Result {
 	res := RunIncreasedTimeout(timeout, args...)
-	t := &testsuitAdaptor{}
+	t := newReporterAdaptor()
 	res.Assert(t, exp)
 	return res
 }
 
 func RunIncreasedTimeout(timeout time.Duration, cmd ...string) *icmd.Result {
-	return icmd.RunCmd(icmd.Cmd{Command: cmd, Timeout: timeout})
+	return run(icmd.Cmd{Command: cmd, Timeout: timeout})
 }
@@ -11,28 +11,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 )
 
 const (
-	// APIRetry defines the frequency at which we check for updates against the
-	// k8s api when waiting for a specific condition to be true.
-	APIRetry = time.Second * 5
-
-	// APITimeout defines the amount of time we should spend querying the k8s api
-	// when waiting for a specific condition to be true.
-	APITimeout = time.Minute * 10
-	// CLITimeout defines the amount of maximum execution time for CLI commands
-	CLITimeout = time.Second * 90
-
 	// ConsistentlyDuration sets  the default duration for Consistently. Consistently will verify that your condition is satisfied for this long.
 	ConsistentlyDuration = 30 * time.Second
 
-	ResourceTimeout = 60 * time.Second
-
-	// TargetNamespace specify the name of Target namespace
-	TargetNamespace = "openshift-pipelines"
-
 	// Name of the pipeline controller deployment
 	PipelineControllerName = "tekton-pipelines-controller"
 	PipelineControllerSA   = "tekton-pipelines-controller"
@@ -80,6 +65,23 @@ const (
 	TriggersSecretToken = "1234567"
 )
 
+// TargetNamespace returns the namespace where the operator installs the Tekton
+// components, it depends on the target platform (see EnvironmentFlags.Target)
+// and so is only known once the flags are parsed
+func TargetNamespace() string {
+	if Flags.Target == TargetKubernetes {
+		return "tekton-pipelines"
+	}
+	return "openshift-pipelines"
+}
+
+const (
+	// TargetOpenShift is the target platform of OpenShift clusters
+	TargetOpenShift = "openshift"
+	// TargetKubernetes is the target platform of plain Kubernetes clusters, e.g. kind
+	TargetKubernetes = "kubernetes"
+)
+
 // Name prefixes of installerset
 var TektonInstallersetNamePrefixes [34]string = [34]string{
 	"addon-custom-consolecli",
@@ -139,6 +141,18 @@ type EnvironmentFlags struct {
 	TknVersion       string
 	ClusterArch      string // Architecture of the cluster
 	IsDisconnected   bool
+	OcBackend        string // Implementation of pkg/oc, "cli" (oc binary) or "api" (client-go)
+	Target           string // Platform of the cluster, "openshift" or "kubernetes" (see pkg/platform)
+	IngressDomain    string // Domain of the hosts of the Ingresses exposing services on Kubernetes
+	ExposeMode       string // How services are reached from the tests, "route", "nodeport" or "portforward" (see pkg/expose)
+	EventSinkImage   string // image of cmd/eventsink running the in-cluster CloudEvents sink (see pkg/eventsink)
+	GitServerImage   string // Gitea image of the in-cluster git server (see pkg/gitserver)
+	RegistryImage    string // Distribution image of the in-cluster OCI registry (see pkg/registry)
+	HTTPServerImage  string // image of cmd/httpserver running the in-cluster HTTP server (see pkg/httpserver)
+	ReportDir        string // Directory of the JUnit and JSON run reports (defaults to reports/run-report)
+	ArtifactsDir     string // Directory of the artifacts collected when a scenario fails (defaults to $ARTIFACT_DIR or reports/artifacts)
+	Timeouts         Timeouts
+	TimeoutOverrides string // Path to the per spec and per tag timeout overrides (defaults to timeouts.json)
 }
 
 func initializeFlags() *EnvironmentFlags {
@@ -202,6 +216,97 @@ func initializeFlags() *EnvironmentFlags {
 	}
 	flag.BoolVar(&f.IsDisconnected, "isdisconnected", defaultIsDiconnected,
 		"Provide the info if the testing cluster is disconnected. By default `false` will be used.")
+
+	defaultOcBackend := os.Getenv("OC_BACKEND")
+	if defaultOcBackend == "" {
+		defaultOcBackend = "cli"
+	}
+	flag.StringVar(&f.OcBackend, "ocbackend", defaultOcBackend,
+		"Provide the implementation of oc operations, `cli` runs the oc binary and `api` uses client-go. By default `cli` will be used.")
+
+	defaultTarget := os.Getenv("TARGET")
+	if defaultTarget == "" {
+		defaultTarget = TargetOpenShift
+	}
+	flag.StringVar(&f.Target, "target", defaultTarget,
+		"Provide the platform of the cluster, `openshift` or `kubernetes` (e.g. kind). By default `openshift` will be used.")
+
+	defaultIngressDomain := os.Getenv("INGRESS_DOMAIN")
+	if defaultIngressDomain == "" {
+		defaultIngressDomain = "127.0.0.1.nip.io"
+	}
+	flag.StringVar(&f.IngressDomain, "ingressdomain", defaultIngressDomain,
+		"Provide the domain of the Ingresses exposing services on Kubernetes. By default `127.0.0.1.nip.io` will be used.")
+
+	defaultExposeMode := os.Getenv("EXPOSE_MODE")
+	if defaultExposeMode == "" {
+		defaultExposeMode = "route"
+	}
+	flag.StringVar(&f.ExposeMode, "exposemode", defaultExposeMode,
+		"Provide how services are reached from the tests, `route` (Route or Ingress), `nodeport` or `portforward`. By default `route` will be used.")
+
+	defaultEventSinkImage := os.Getenv("EVENT_SINK_IMAGE")
+	if defaultEventSinkImage == "" {
+		defaultEventSinkImage = "quay.io/openshift-pipeline/release-tests-eventsink:latest"
+	}
+	flag.StringVar(&f.EventSinkImage, "eventsinkimage", defaultEventSinkImage,
+		"Provide the image of cmd/eventsink running the CloudEvents sink in the cluster. By default `quay.io/openshift-pipeline/release-tests-eventsink:latest` will be used.")
+
+	defaultGitServerImage := os.Getenv("GIT_SERVER_IMAGE")
+	if defaultGitServerImage == "" {
+		defaultGitServerImage = "docker.io/gitea/gitea:1.24-rootless"
+	}
+	flag.StringVar(&f.GitServerImage, "gitserverimage", defaultGitServerImage,
+		"Provide the rootless Gitea image of the git server deployed in the cluster. By default `docker.io/gitea/gitea:1.24-rootless` will be used.")
+
+	defaultRegistryImage := os.Getenv("REGISTRY_IMAGE")
+	if defaultRegistryImage == "" {
+		defaultRegistryImage = "docker.io/library/registry:2"
+	}
+	flag.StringVar(&f.RegistryImage, "registryimage", defaultRegistryImage,
+		"Provide the distribution image of the OCI registry deployed in the cluster. By default `docker.io/library/registry:2` will be used.")
+
+	defaultHTTPServerImage := os.Getenv("HTTP_SERVER_IMAGE")
+	if defaultHTTPServerImage == "" {
+		defaultHTTPServerImage = "quay.io/openshift-pipeline/release-tests-httpserver:latest"
+	}
+	flag.StringVar(&f.HTTPServerImage, "httpserverimage", defaultHTTPServerImage,
+		"Provide the image of cmd/httpserver running the HTTP server in the cluster. By default `quay.io/openshift-pipeline/release-tests-httpserver:latest` will be used.")
+
+	defaultReportDir := os.Getenv("REPORT_DIR")
+	if defaultReportDir == "" {
+		defaultReportDir = filepath.Join("reports", "run-report")
+	}
+	flag.StringVar(&f.ReportDir, "reportdir", defaultReportDir,
+		"Provide the directory to write the JUnit and JSON run reports to. By default `reports/run-report` will be used.")
+
+	defaultArtifactsDir := os.Getenv("ARTIFACT_DIR")
+	if defaultArtifactsDir == "" {
+		defaultArtifactsDir = filepath.Join("reports", "artifacts")
+	}
+	flag.StringVar(&f.ArtifactsDir, "artifactsdir", defaultArtifactsDir,
+		"Provide the directory to collect the artifacts of failed scenarios in. By default `$ARTIFACT_DIR` or `reports/artifacts` will be used.")
+
+	flag.DurationVar(&f.Timeouts.APIRetry, "apiretry", durationFromEnv("API_RETRY", DefaultAPIRetry),
+		"Provide the interval between two polls of the k8s api. By default `5s` will be used.")
+	flag.DurationVar(&f.Timeouts.APITimeout, "apitimeout", durationFromEnv("API_TIMEOUT", DefaultAPITimeout),
+		"Provide the time to wait for a condition polled from the k8s api. By default `10m` will be used.")
+	flag.DurationVar(&f.Timeouts.CLITimeout, "clitimeout", durationFromEnv("CLI_TIMEOUT", DefaultCLITimeout),
+		"Provide the maximum execution time of CLI commands. By default `90s` will be used.")
+	flag.DurationVar(&f.Timeouts.ResourceTimeout, "resourcetimeout", durationFromEnv("RESOURCE_TIMEOUT", DefaultResourceTimeout),
+		"Provide the time to wait for a resource to be created or deleted. By default `60s` will be used.")
+	flag.DurationVar(&f.Timeouts.OLMInterval, "olminterval", durationFromEnv("OLM_INTERVAL", DefaultOLMInterval),
+		"Provide the interval between two polls of OLM resources. By default `10s` will be used.")
+	flag.DurationVar(&f.Timeouts.OLMTimeout, "olmtimeout", durationFromEnv("OLM_TIMEOUT", DefaultOLMTimeout),
+		"Provide the time to wait for OLM resources to reach a state. By default `8m` will be used.")
+	SetTimeouts(f.Timeouts)
+
+	defaultTimeoutOverrides := os.Getenv("TIMEOUT_OVERRIDES")
+	if defaultTimeoutOverrides == "" {
+		defaultTimeoutOverrides = Path("timeouts.json")
+	}
+	flag.StringVar(&f.TimeoutOverrides, "timeoutoverrides", defaultTimeoutOverrides,
+		"Provide the path to the per spec and per tag timeout overrides. By default `timeouts.json` will be used.")
 	return &f
 }
 
@@ -243,14 +348,14 @@ func RemoveTempDir() {
 	tmp, _ := TempDir()
 	err = os.RemoveAll(tmp)
 	if err != nil {
-		testsuit.T.Errorf("Error: In deleting directory %s: %+v ", tmp, err)
+		reporter.Default().Errorf("Error: In deleting directory %s: %+v ", tmp, err)
 	}
 }
 
 func Path(elem ...string) string {
 	td := filepath.Join(Dir(), "..")
 	if _, err := os.Stat(td); os.IsNotExist(err) {
-		testsuit.T.Errorf("Error: in identifying test data path %s: %+v", td, err)
+		reporter.Default().Errorf("Error: in identifying test data path %s: %+v", td, err)
 	}
 	return filepath.Join(append([]string{td}, elem...)...)
 }
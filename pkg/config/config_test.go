@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestTargetNamespace(t *testing.T) {
+	target := Flags.Target
+	defer func() { Flags.Target = target }()
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: TargetOpenShift, want: "openshift-pipelines"},
+		{target: TargetKubernetes, want: "tekton-pipelines"},
+		{target: "", want: "openshift-pipelines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			// the target flag is read when the namespace is needed, not at init
+			Flags.Target = tt.target
+			if got := TargetNamespace(); got != tt.want {
+				t.Errorf("TargetNamespace() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Default poll intervals and timeouts, used when neither a flag nor an environment variable sets them
+const (
+	DefaultAPIRetry        = time.Second * 5
+	DefaultAPITimeout      = time.Minute * 10
+	DefaultCLITimeout      = time.Second * 90
+	DefaultResourceTimeout = 60 * time.Second
+	DefaultOLMInterval     = 10 * time.Second
+	DefaultOLMTimeout      = 8 * time.Minute
+)
+
+// The poll intervals and timeouts honoured by the helpers. They are resolved
+// from EnvironmentFlags and overridden per spec with ApplyTimeoutOverrides.
+var (
+	// APIRetry defines the frequency at which we check for updates against the
+	// k8s api when waiting for a specific condition to be true.
+	APIRetry = DefaultAPIRetry
+
+	// APITimeout defines the amount of time we should spend querying the k8s api
+	// when waiting for a specific condition to be true.
+	APITimeout = DefaultAPITimeout
+	// CLITimeout defines the amount of maximum execution time for CLI commands
+	CLITimeout = DefaultCLITimeout
+
+	ResourceTimeout = DefaultResourceTimeout
+
+	// OLMInterval specifies the time between two polls of OLM resources.
+	OLMInterval = DefaultOLMInterval
+	// OLMTimeout specifies the timeout for OLM resources to reach a certain status.
+	OLMTimeout = DefaultOLMTimeout
+)
+
+// Timeouts holds a set of poll intervals and timeouts
+type Timeouts struct {
+	APIRetry        time.Duration
+	APITimeout      time.Duration
+	CLITimeout      time.Duration
+	ResourceTimeout time.Duration
+	OLMInterval     time.Duration
+	OLMTimeout      time.Duration
+}
+
+// CurrentTimeouts returns the poll intervals and timeouts in effect
+func CurrentTimeouts() Timeouts {
+	return Timeouts{
+		APIRetry:        APIRetry,
+		APITimeout:      APITimeout,
+		CLITimeout:      CLITimeout,
+		ResourceTimeout: ResourceTimeout,
+		OLMInterval:     OLMInterval,
+		OLMTimeout:      OLMTimeout,
+	}
+}
+
+// SetTimeouts puts t in effect
+func SetTimeouts(t Timeouts) {
+	APIRetry = t.APIRetry
+	APITimeout = t.APITimeout
+	CLITimeout = t.CLITimeout
+	ResourceTimeout = t.ResourceTimeout
+	OLMInterval = t.OLMInterval
+	OLMTimeout = t.OLMTimeout
+}
+
+// durationFromEnv returns the duration set in the environment variable key, or def
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: ignoring %s=%q, it is not a duration: %v", key, value, err)
+		return def
+	}
+	return d
+}
+
+// TimeoutOverride holds the durations (e.g. "20m") overriding the ones
+// resolved from the flags, empty ones are left unchanged
+type TimeoutOverride struct {
+	APIRetry        string `json:"apiRetry,omitempty"`
+	APITimeout      string `json:"apiTimeout,omitempty"`
+	CLITimeout      string `json:"cliTimeout,omitempty"`
+	ResourceTimeout string `json:"resourceTimeout,omitempty"`
+	OLMInterval     string `json:"olmInterval,omitempty"`
+	OLMTimeout      string `json:"olmTimeout,omitempty"`
+}
+
+// TimeoutOverrides is the content of the timeout overrides file: overrides
+// keyed by spec tag and by spec file path relative to the project root
+type TimeoutOverrides struct {
+	Tags  map[string]TimeoutOverride `json:"tags,omitempty"`
+	Specs map[string]TimeoutOverride `json:"specs,omitempty"`
+}
+
+func (o TimeoutOverride) applyTo(t *Timeouts) error {
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"apiRetry", o.APIRetry, &t.APIRetry},
+		{"apiTimeout", o.APITimeout, &t.APITimeout},
+		{"cliTimeout", o.CLITimeout, &t.CLITimeout},
+		{"resourceTimeout", o.ResourceTimeout, &t.ResourceTimeout},
+		{"olmInterval", o.OLMInterval, &t.OLMInterval},
+		{"olmTimeout", o.OLMTimeout, &t.OLMTimeout},
+	} {
+		if field.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", field.name, field.value, err)
+		}
+		*field.dst = d
+	}
+	return nil
+}
+
+// Resolve returns base with the overrides of the tags applied in order, then
+// the one of specFile which takes precedence
+func (o TimeoutOverrides) Resolve(base Timeouts, specFile string, tags []string) (Timeouts, error) {
+	resolved := base
+	for _, tag := range tags {
+		if override, ok := o.Tags[tag]; ok {
+			if err := override.applyTo(&resolved); err != nil {
+				return base, fmt.Errorf("timeout override of tag %s: %v", tag, err)
+			}
+		}
+	}
+	specFile = filepath.ToSlash(specFile)
+	keys := make([]string, 0, len(o.Specs))
+	for key := range o.Specs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if specFile == key || strings.HasSuffix(specFile, "/"+key) {
+			if err := o.Specs[key].applyTo(&resolved); err != nil {
+				return base, fmt.Errorf("timeout override of spec %s: %v", key, err)
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// ReadTimeoutOverrides reads the timeout overrides file, a missing file means no overrides
+func ReadTimeoutOverrides(path string) (TimeoutOverrides, error) {
+	var overrides TimeoutOverrides
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return overrides, nil
+	}
+	if err != nil {
+		return overrides, err
+	}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return overrides, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return overrides, nil
+}
+
+// ApplyTimeoutOverrides puts in effect the timeouts resolved from the flags
+// with the overrides of Flags.TimeoutOverrides for the spec applied
+func ApplyTimeoutOverrides(specFile string, tags []string) error {
+	overrides, err := ReadTimeoutOverrides(Flags.TimeoutOverrides)
+	if err != nil {
+		return err
+	}
+	resolved, err := overrides.Resolve(Flags.Timeouts, specFile, tags)
+	if err != nil {
+		return err
+	}
+	if resolved != Flags.Timeouts {
+		log.Printf("Using timeouts overridden for spec %s: %+v", specFile, resolved)
+	}
+	SetTimeouts(resolved)
+	return nil
+}
@@ -0,0 +1,198 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Filter selects events, its empty fields match any event
+type Filter struct {
+	Type string
+	// Source matches the sources ending with it, e.g. "/eventlisteners/<name>"
+	// or "/<name>"
+	Source string
+	// Subject matches the subject or its first word, e.g. the name of the
+	// EventListener in "<name> processing <event id>"
+	Subject string
+	// Fields are values of the payload by path, see Event.Field
+	Fields map[string]string
+}
+
+// Matches tells whether the event is selected by the filter
+func (f Filter) Matches(e Event) bool {
+	if f.Type != "" && e.Type != f.Type {
+		return false
+	}
+	if f.Source != "" && !strings.HasSuffix(e.Source, f.Source) {
+		return false
+	}
+	if f.Subject != "" && e.Subject != f.Subject && !strings.HasPrefix(e.Subject, f.Subject+" ") {
+		return false
+	}
+	for path, want := range f.Fields {
+		if got, ok := e.Field(path); !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Filter) String() string {
+	var s []string
+	for _, attribute := range [][2]string{{"type", f.Type}, {"source", f.Source}, {"subject", f.Subject}} {
+		if attribute[1] != "" {
+			s = append(s, fmt.Sprintf("%s %q", attribute[0], attribute[1]))
+		}
+	}
+	paths := make([]string, 0, len(f.Fields))
+	for path := range f.Fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		s = append(s, fmt.Sprintf("%s=%q", path, f.Fields[path]))
+	}
+	if len(s) == 0 {
+		return "any event"
+	}
+	return strings.Join(s, ", ")
+}
+
+// Field returns the value of the payload at the dot separated path, e.g.
+// "pipelineRun.metadata.name" or "X-Github-Event.0" in the headers sent by
+// EventListeners. Values which are not strings are returned as JSON.
+func (e Event) Field(path string) (string, bool) {
+	var value interface{}
+	if err := json.Unmarshal(e.Data, &value); err != nil {
+		return "", false
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return "", false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			value = v[i]
+		default:
+			return "", false
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(raw), true
+}
+
+// Select returns the events matching the filter
+func Select(events []Event, f Filter) []Event {
+	var selected []Event
+	for _, e := range events {
+		if f.Matches(e) {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// WaitForEvents waits for the sink to receive count events matching the
+// filter and returns the events matching it
+func (s *Sink) WaitForEvents(c *clients.Clients, f Filter, count int) ([]Event, error) {
+	var selected []Event
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		events, err := s.Events(c)
+		if err != nil {
+			log.Printf("Failed to get the events of the sink: %v", err)
+			return false, nil
+		}
+		selected = Select(events, f)
+		if len(selected) >= count {
+			return true, nil
+		}
+		log.Printf("Waiting for %d events matching %s, received %d of %d events", count, f, len(selected), len(events))
+		return false, nil
+	})
+	if err != nil {
+		return selected, fmt.Errorf("sink received %d events matching %s, expected %d: %v", len(selected), f, count, err)
+	}
+	return selected, nil
+}
+
+// AssertEvents checks that the sink receives at least count events matching
+// the filter
+func AssertEvents(c *clients.Clients, s *Sink, f Filter, count int) {
+	if _, err := s.WaitForEvents(c, f, count); err != nil {
+		c.Reporter().Errorf("%v", err)
+		logEvents(c, s)
+	}
+}
+
+// AssertEventOrder checks that the sink receives events of each of the types
+// matching the filter, its Type being ignored, and that the first event of
+// each type is received in the order of the types, e.g. started before running
+// before successful
+func AssertEventOrder(c *clients.Clients, s *Sink, f Filter, types []string) {
+	for _, t := range types {
+		typed := f
+		typed.Type = t
+		if _, err := s.WaitForEvents(c, typed, 1); err != nil {
+			c.Reporter().Errorf("%v", err)
+			logEvents(c, s)
+			return
+		}
+	}
+	events, err := s.Events(c)
+	if err != nil {
+		c.Reporter().Errorf("failed to get the events of the sink: %v", err)
+		return
+	}
+	f.Type = ""
+	var order []string
+	seen := map[string]bool{}
+	for _, e := range Select(events, f) {
+		if !seen[e.Type] {
+			seen[e.Type] = true
+			order = append(order, e.Type)
+		}
+	}
+	next := 0
+	for _, t := range order {
+		if next < len(types) && t == types[next] {
+			next++
+		}
+	}
+	if next != len(types) {
+		c.Reporter().Errorf("events matching %s were received in the order %v, expected %v", f, order, types)
+	}
+}
+
+// logEvents logs the events received by the sink, to diagnose assertions
+func logEvents(c *clients.Clients, s *Sink) {
+	events, err := s.Events(c)
+	if err != nil {
+		log.Printf("Failed to get the events of the sink: %v", err)
+		return
+	}
+	log.Printf("Event sink received %d events:", len(events))
+	for _, e := range events {
+		log.Printf("  %s %s source %s subject %q", e.Received.Format("15:04:05.000"), e.Type, e.Source, e.Subject)
+	}
+}
@@ -0,0 +1,230 @@
+package eventsink
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EventsPath is the path where the receiver serves the recorded events
+	// (GET) and forgets them (DELETE). Events are received on any other path.
+	EventsPath = "/events"
+
+	structuredContentType = "application/cloudevents+json"
+	batchContentType      = "application/cloudevents-batch+json"
+	headerPrefix          = "Ce-"
+)
+
+// Event is a received CloudEvent
+type Event struct {
+	ID              string            `json:"id"`
+	Type            string            `json:"type"`
+	Source          string            `json:"source"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            string            `json:"time,omitempty"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Extensions      map[string]string `json:"extensions,omitempty"`
+	// Data is the payload, a JSON string if it is not JSON
+	Data json.RawMessage `json:"data,omitempty"`
+	// Received is when the receiver got the event, events are recorded in
+	// the order they were received
+	Received time.Time `json:"received"`
+}
+
+// Receiver is an http.Handler recording the CloudEvents posted to it, in the
+// binary, structured or batched content modes of the HTTP binding
+type Receiver struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewReceiver returns a receiver which did not record any event yet
+func NewReceiver() *Receiver {
+	return &Receiver{}
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == EventsPath {
+		switch req.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(r.Events()); err != nil {
+				log.Printf("failed to write the events: %v", err)
+			}
+		case http.MethodDelete:
+			r.Reset()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "only GET and DELETE are allowed on "+EventsPath, http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(w, "events must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+	events, err := decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	for _, e := range events {
+		e.Received = time.Now()
+		r.events = append(r.events, e)
+		log.Printf("received event %s of type %s from %s, subject %q", e.ID, e.Type, e.Source, e.Subject)
+	}
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Events returns the events received so far
+func (r *Receiver) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event{}, r.events...)
+}
+
+// Reset forgets the events received so far
+func (r *Receiver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Serve records the events posted to addr until the server fails
+func Serve(addr string) error {
+	log.Printf("Receiving CloudEvents on %s, recorded events are served on %s", addr, EventsPath)
+	return http.ListenAndServe(addr, NewReceiver())
+}
+
+func decode(req *http.Request) ([]Event, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the request: %v", err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case structuredContentType:
+		e, err := decodeStructured(body)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{e}, nil
+	case batchContentType:
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, fmt.Errorf("invalid batch of events: %v", err)
+		}
+		events := make([]Event, 0, len(batch))
+		for _, raw := range batch {
+			e, err := decodeStructured(raw)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, e)
+		}
+		return events, nil
+	default:
+		e, err := decodeBinary(req.Header, body)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{e}, nil
+	}
+}
+
+// decodeBinary reads the attributes of the event from the ce- headers and
+// its data from the body
+func decodeBinary(header http.Header, body []byte) (Event, error) {
+	e := Event{DataContentType: header.Get("Content-Type"), Extensions: map[string]string{}}
+	for key, values := range header {
+		if !strings.HasPrefix(key, headerPrefix) || len(values) == 0 {
+			continue
+		}
+		setAttribute(&e, strings.ToLower(strings.TrimPrefix(key, headerPrefix)), values[0])
+	}
+	e.Data = data(body)
+	return e, validate(e)
+}
+
+// decodeStructured reads the event from a JSON object holding its attributes
+// and data
+func decodeStructured(body []byte) (Event, error) {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(body, &attributes); err != nil {
+		return Event{}, fmt.Errorf("invalid structured event: %v", err)
+	}
+	e := Event{Extensions: map[string]string{}}
+	for name, raw := range attributes {
+		switch name {
+		case "data":
+			e.Data = raw
+		case "data_base64":
+			var encoded string
+			if err := json.Unmarshal(raw, &encoded); err != nil {
+				return Event{}, fmt.Errorf("invalid data_base64: %v", err)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return Event{}, fmt.Errorf("invalid data_base64: %v", err)
+			}
+			e.Data = data(decoded)
+		default:
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				// extensions may be numbers or booleans
+				value = string(raw)
+			}
+			setAttribute(&e, name, value)
+		}
+	}
+	return e, validate(e)
+}
+
+func setAttribute(e *Event, name, value string) {
+	switch name {
+	case "specversion":
+	case "id":
+		e.ID = value
+	case "type":
+		e.Type = value
+	case "source":
+		e.Source = value
+	case "subject":
+		e.Subject = value
+	case "time":
+		e.Time = value
+	case "datacontenttype":
+		e.DataContentType = value
+	default:
+		e.Extensions[name] = value
+	}
+}
+
+// data returns the payload as JSON, quoting it if it is not JSON
+func data(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+func validate(e Event) error {
+	if e.ID == "" || e.Type == "" || e.Source == "" {
+		return fmt.Errorf("not a CloudEvent: id, type and source are required")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	releasewait "github.com/openshift-pipelines/release-tests/pkg/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// pipelinesSinkKey is the key of the sink of the pipelines controller in its
+// config-defaults config map
+const pipelinesSinkKey = "default-cloud-events-sink"
+
+// RegisterEventListener sets the sink as the cloudEventURI of the
+// EventListener, and waits for its deployment to be rolled out with it
+// (the --cloudevent-uri argument of its container)
+func (s *Sink) RegisterEventListener(c *clients.Clients, namespace, name string) error {
+	patch := fmt.Sprintf(`{"spec":{"cloudEventURI":%q}}`, s.URL)
+	_, err := c.TriggersClient.TriggersV1beta1().EventListeners(namespace).Patch(c.Ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set the cloudEventURI of eventlistener %s in namespace %s: %v", name, namespace, err)
+	}
+	deployment := "el-" + name
+	arg := "--cloudevent-uri=" + s.URL
+	return releasewait.WaitForDeploymentState(c, deployment, namespace, func(d *appsv1.Deployment) (bool, error) {
+		configured := false
+		for _, container := range d.Spec.Template.Spec.Containers {
+			configured = configured || slices.Contains(container.Args, arg)
+		}
+		rolledOut := d.Status.ObservedGeneration >= d.Generation &&
+			d.Status.UpdatedReplicas == d.Status.Replicas &&
+			d.Status.AvailableReplicas == d.Status.Replicas && d.Status.Replicas > 0
+		return configured && rolledOut, nil
+	}, "CloudEventURI")
+}
+
+// RegisterPipelines sets the sink as the default-cloud-events-sink of the
+// pipelines in TektonConfig, and waits for the pipelines controller to be
+// configured with it. Scenarios registering a sink for the pipelines must be
+// tagged mutating, TektonConfig is then restored after them.
+func (s *Sink) RegisterPipelines(c *clients.Clients) error {
+	oc.UpdateTektonConfig(fmt.Sprintf(`{"spec":{"pipeline":{%q:%q}}}`, pipelinesSinkKey, s.URL))
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		cm, err := c.KubeClient.Kube.CoreV1().ConfigMaps(config.TargetNamespace()).Get(c.Ctx, "config-defaults", metav1.GetOptions{})
+		if err != nil {
+			log.Printf("Failed to get config map config-defaults: %v", err)
+			return false, nil
+		}
+		if cm.Data[pipelinesSinkKey] == s.URL {
+			return true, nil
+		}
+		log.Printf("Waiting for %s %q in config map config-defaults", pipelinesSinkKey, s.URL)
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("pipelines are not configured with the event sink %s: %v", s.URL, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package eventsink records the CloudEvents sent by EventListeners (their
+// cloudEventURI) and by the pipelines controller (default-cloud-events-sink)
+// so steps can assert on their type, source, subject, order and payload.
+//
+// The Receiver runs in process (StartLocal), when the cluster can reach the
+// tests, or in the cluster (Deploy): a small deployment of the image of
+// cmd/eventsink set with the eventsinkimage flag (EVENT_SINK_IMAGE
+// environment variable). The events it recorded are read through pkg/expose,
+// e.g. behind a port-forward.
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Name is the name of the deployment and service of the sink deployed in
+	// the cluster
+	Name = "event-sink"
+	port = 8080
+)
+
+// Sink is a receiver of CloudEvents, deployed in the cluster or running in process
+type Sink struct {
+	// URL is where the sink receives events, as seen from the cluster
+	URL string
+
+	// svc is the service of the sink deployed in the cluster
+	svc *expose.Service
+	// receiver and server of the sink running in process
+	receiver *Receiver
+	server   *http.Server
+}
+
+// Deploy runs the sink in the namespace, unless it already runs there, and
+// waits for it to be ready. Its URL is the address of its service.
+func Deploy(c *clients.Clients, namespace string) (*Sink, error) {
+	labels := map[string]string{"app": Name}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  Name,
+						Image: config.Flags.EventSinkImage,
+						Args:  []string{"-addr", fmt.Sprintf(":%d", port)},
+						Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: port}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: EventsPath, Port: intstr.FromInt32(port)},
+							},
+							PeriodSeconds: 2,
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsNonRoot:             ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
+					}},
+				},
+			},
+		},
+	}
+	if _, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Create(c.Ctx, deployment, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create deployment %s in namespace %s: %v", Name, namespace, err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Name: "http", Port: port, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Services(namespace).Create(c.Ctx, service, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create service %s in namespace %s: %v", Name, namespace, err)
+	}
+
+	if err := k8s.WaitForDeployment(c.Ctx, c.KubeClient.Kube, namespace, Name, 1, config.APIRetry, config.APITimeout); err != nil {
+		return nil, fmt.Errorf("event sink in namespace %s is not ready: %v", namespace, err)
+	}
+	s := &Sink{
+		URL: fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", Name, namespace, port),
+		svc: &expose.Service{Namespace: namespace, Name: Name, Port: "http"},
+	}
+	log.Printf("Event sink deployed at %s", s.URL)
+	return s, nil
+}
+
+// StartLocal runs the sink in process on addr, e.g. ":8080". The cluster
+// reaches it at url, the address it listens on if url is empty.
+func StartLocal(addr, url string) (*Sink, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+	if url == "" {
+		url = "http://" + listener.Addr().String()
+	}
+	s := &Sink{URL: url, receiver: NewReceiver()}
+	s.server = &http.Server{Handler: s.receiver, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("event sink on %s stopped: %v", addr, err)
+		}
+	}()
+	log.Printf("Event sink listening on %s, reached at %s", listener.Addr(), url)
+	return s, nil
+}
+
+// Events returns the events received by the sink so far, in the order they
+// were received
+func (s *Sink) Events(c *clients.Clients) ([]Event, error) {
+	if s.receiver != nil {
+		return s.receiver.Events(), nil
+	}
+	resp, err := s.request(c, http.MethodGet)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var events []Event
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("invalid events of the event sink: %v", err)
+	}
+	return events, nil
+}
+
+// Reset forgets the events received by the sink so far
+func (s *Sink) Reset(c *clients.Clients) error {
+	if s.receiver != nil {
+		s.receiver.Reset()
+		return nil
+	}
+	resp, err := s.request(c, http.MethodDelete)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Close stops the sink running in process, or stops exposing the sink
+// deployed in the cluster. The deployment is deleted with its namespace.
+func (s *Sink) Close(c *clients.Clients) error {
+	if s.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), config.APIRetry)
+		defer cancel()
+		return s.server.Shutdown(ctx)
+	}
+	return expose.Current().Unexpose(c, *s.svc)
+}
+
+// request sends a request to the events of the sink deployed in the cluster
+func (s *Sink) request(c *clients.Clients, method string) (*http.Response, error) {
+	base, err := expose.Current().Expose(c, *s.svc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expose the event sink: %v", err)
+	}
+	req, err := http.NewRequestWithContext(c.Ctx, method, base+EventsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Timeout: config.CLITimeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the event sink: %v", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("event sink returned %s: %s", resp.Status, body)
+	}
+	return resp, nil
+}
@@ -0,0 +1,144 @@
+// Package expose makes services running in the cluster reachable from the
+// tests: through a Route (an Ingress on Kubernetes), a NodePort service or a
+// port-forward to one of the pods of the service. The strategy is selected
+// with the exposemode flag (EXPOSE_MODE environment variable), or set with Set.
+//
+// Port-forwards only need access to the Kubernetes API, so specs exposing
+// services this way also run from CI networks which cannot reach the
+// ingress of the cluster.
+package expose
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RouteMode exposes services with the platform, a Route on OpenShift and
+	// an Ingress on Kubernetes
+	RouteMode = "route"
+	// NodePortMode exposes services on a port of the nodes of the cluster
+	NodePortMode = "nodeport"
+	// PortForwardMode forwards a local port to a pod of the service through
+	// the Kubernetes API
+	PortForwardMode = "portforward"
+)
+
+// Service is the port of a service to expose
+type Service struct {
+	Namespace string
+	Name      string
+	// Port is the name or the number of the port of the service, the first
+	// port of the service is exposed when it is empty
+	Port string
+	// TLS tells whether the service serves HTTPS on the port, the TLS
+	// connection is passed through to the service
+	TLS bool
+}
+
+func (s Service) String() string {
+	return fmt.Sprintf("%s/%s:%s", s.Namespace, s.Name, s.Port)
+}
+
+func (s Service) scheme() string {
+	if s.TLS {
+		return "https"
+	}
+	return "http"
+}
+
+// Strategy makes services reachable from the tests
+type Strategy interface {
+	// Name is the name of the strategy, as given to the exposemode flag
+	Name() string
+	// Expose returns the base URL where the service is reachable. Exposing a
+	// service again returns the same URL while it is still reachable.
+	Expose(c *clients.Clients, svc Service) (string, error)
+	// Unexpose deletes what Expose created for the service
+	Unexpose(c *clients.Clients, svc Service) error
+}
+
+var (
+	strategyMu      sync.Mutex
+	currentStrategy Strategy
+)
+
+// Set replaces the strategy returned by Current and returns the previous one
+// (nil if none was selected yet)
+func Set(s Strategy) Strategy {
+	strategyMu.Lock()
+	defer strategyMu.Unlock()
+	prev := currentStrategy
+	currentStrategy = s
+	return prev
+}
+
+// Current returns the strategy selected with the exposemode flag
+func Current() Strategy {
+	strategyMu.Lock()
+	defer strategyMu.Unlock()
+	if currentStrategy == nil {
+		s, err := New(config.Flags.ExposeMode)
+		if err != nil {
+			reporter.Default().Fail(err)
+			s = Route()
+		}
+		currentStrategy = s
+	}
+	return currentStrategy
+}
+
+// New returns the strategy called name
+func New(name string) (Strategy, error) {
+	switch name {
+	case "", RouteMode:
+		return Route(), nil
+	case NodePortMode:
+		return NodePort(), nil
+	case PortForwardMode:
+		return PortForward(), nil
+	default:
+		return nil, fmt.Errorf("unknown expose mode %q, expected %q, %q or %q", name, RouteMode, NodePortMode, PortForwardMode)
+	}
+}
+
+// HostPort returns the host:port of the URL returned by Expose, with the
+// default port of its scheme if it has none
+func HostPort(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// servicePort returns the port of the service named or numbered port, or its
+// first port if port is empty
+func servicePort(svc *corev1.Service, port string) (corev1.ServicePort, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return corev1.ServicePort{}, fmt.Errorf("service %s in namespace %s has no port", svc.Name, svc.Namespace)
+	}
+	if port == "" {
+		return svc.Spec.Ports[0], nil
+	}
+	for _, p := range svc.Spec.Ports {
+		if p.Name == port || fmt.Sprint(p.Port) == port {
+			return p, nil
+		}
+	}
+	return corev1.ServicePort{}, fmt.Errorf("service %s in namespace %s has no port %s", svc.Name, svc.Namespace, port)
+}
@@ -0,0 +1,107 @@
+package expose
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    string
+		wantErr string
+	}{
+		{mode: "", want: RouteMode},
+		{mode: RouteMode, want: RouteMode},
+		{mode: NodePortMode, want: NodePortMode},
+		{mode: PortForwardMode, want: PortForwardMode},
+		{mode: "loadbalancer", wantErr: `unknown expose mode "loadbalancer"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			s, err := New(tt.mode)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New(%q) error = %v, want %q", tt.mode, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(%q) failed: %v", tt.mode, err)
+			}
+			if s.Name() != tt.want {
+				t.Errorf("Name() = %s, want %s", s.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "http://10.0.0.1:31080", want: "10.0.0.1:31080"},
+		{url: "https://127.0.0.1:40123", want: "127.0.0.1:40123"},
+		{url: "http://sink-test-ns.apps.example.com", want: "sink-test-ns.apps.example.com:80"},
+		{url: "https://sink-test-ns.apps.example.com", want: "sink-test-ns.apps.example.com:443"},
+		{url: "https://[fd00::1]:30443", want: "[fd00::1]:30443"},
+		{url: "http://[fd00::1]", want: "[fd00::1]:80"},
+		{url: "http://%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := HostPort(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HostPort(%q) error = %v, want error %v", tt.url, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HostPort(%q) = %s, want %s", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServicePort(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http", Port: 8080},
+			{Name: "https", Port: 8443},
+		}},
+	}
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		port    string
+		want    int32
+		wantErr string
+	}{
+		{name: "first port", svc: svc, want: 8080},
+		{name: "by name", svc: svc, port: "https", want: 8443},
+		{name: "by number", svc: svc, port: "8443", want: 8443},
+		{name: "unknown port", svc: svc, port: "9090", wantErr: "has no port 9090"},
+		{name: "no port", svc: &corev1.Service{ObjectMeta: svc.ObjectMeta}, wantErr: "has no port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := servicePort(tt.svc, tt.port)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("servicePort() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("servicePort() failed: %v", err)
+			}
+			if got.Port != tt.want {
+				t.Errorf("servicePort() = %d, want %d", got.Port, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package expose
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodePortSuffix is appended to the name of the service to name the NodePort
+// service created for it
+const nodePortSuffix = "-nodeport"
+
+// nodePort exposes services on a port of the nodes
+type nodePort struct{}
+
+// NodePort returns the strategy exposing services on a port of the nodes. A
+// NodePort service selecting the same pods is created, unless the service
+// already has a node port.
+func NodePort() Strategy {
+	return nodePort{}
+}
+
+func (nodePort) Name() string {
+	return NodePortMode
+}
+
+func (nodePort) Expose(c *clients.Clients, svc Service) (string, error) {
+	services := c.KubeClient.Kube.CoreV1().Services(svc.Namespace)
+	s, err := services.Get(c.Ctx, svc.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %s in namespace %s: %v", svc.Name, svc.Namespace, err)
+	}
+	port, err := servicePort(s, svc.Port)
+	if err != nil {
+		return "", err
+	}
+
+	if port.NodePort == 0 {
+		np := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: svc.Name + nodePortSuffix, Namespace: svc.Namespace},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeNodePort,
+				Selector: s.Spec.Selector,
+				Ports: []corev1.ServicePort{{
+					Name:       port.Name,
+					Protocol:   port.Protocol,
+					Port:       port.Port,
+					TargetPort: port.TargetPort,
+				}},
+			},
+		}
+		created, err := services.Create(c.Ctx, np, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			created, err = services.Get(c.Ctx, np.Name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create service %s in namespace %s: %v", np.Name, svc.Namespace, err)
+		}
+		port = created.Spec.Ports[0]
+	}
+
+	host, err := nodeAddress(c)
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s://%s", svc.scheme(), net.JoinHostPort(host, strconv.Itoa(int(port.NodePort))))
+	log.Printf("Node port url: %s", url)
+	return url, nil
+}
+
+func (nodePort) Unexpose(c *clients.Clients, svc Service) error {
+	name := svc.Name + nodePortSuffix
+	err := c.KubeClient.Kube.CoreV1().Services(svc.Namespace).Delete(c.Ctx, name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete service %s in namespace %s: %v", name, svc.Namespace, err)
+	}
+	return nil
+}
+
+// nodeAddress returns the first external IP of the nodes, or their first
+// internal IP (e.g. on kind) if none has an external IP
+func nodeAddress(c *clients.Clients) (string, error) {
+	nodes, err := c.KubeClient.Kube.CoreV1().Nodes().List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %v", err)
+	}
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes.Items {
+			for _, address := range node.Status.Addresses {
+				if address.Type == addressType {
+					return address.Address, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no node has an external or internal IP")
+}
@@ -0,0 +1,168 @@
+package expose
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func node(name string, addresses ...corev1.NodeAddress) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Addresses: addresses},
+	}
+}
+
+// allocateNodePorts makes the API server allocate nodePort to the created
+// services which have none
+func allocateNodePorts(fcs *testutil.FakeClientsets, nodePort int32) {
+	fcs.Kube.PrependReactor("create", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		svc := action.(k8stesting.CreateAction).GetObject().(*corev1.Service)
+		for i := range svc.Spec.Ports {
+			if svc.Spec.Ports[i].NodePort == 0 {
+				svc.Spec.Ports[i].NodePort = nodePort
+			}
+		}
+		return false, nil, nil
+	})
+}
+
+func TestNodePortExpose(t *testing.T) {
+	internal := node("worker", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "172.18.0.2"})
+	external := node("edge",
+		corev1.NodeAddress{Type: corev1.NodeHostName, Address: "edge"},
+		corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+	)
+	service := func(ports ...corev1.ServicePort) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "sink"},
+				Ports:    ports,
+			},
+		}
+	}
+	clusterIP := service(
+		corev1.ServicePort{Name: "http", Port: 8080, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP},
+		corev1.ServicePort{Name: "https", Port: 8443, TargetPort: intstr.FromInt32(9443), Protocol: corev1.ProtocolTCP},
+	)
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		svc     Service
+		want    string
+		// wantService is the port of the NodePort service created, if any
+		wantService *corev1.ServicePort
+		// reused is set when the service has a node port of its own
+		reused  bool
+		wantErr string
+	}{{
+		name:        "first port on the internal IP",
+		objects:     []runtime.Object{clusterIP, internal},
+		svc:         Service{Namespace: "test-ns", Name: "sink"},
+		want:        "http://172.18.0.2:31000",
+		wantService: &corev1.ServicePort{Name: "http", Port: 8080, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP, NodePort: 31000},
+	}, {
+		name:        "named port on the external IP",
+		objects:     []runtime.Object{clusterIP, internal, external},
+		svc:         Service{Namespace: "test-ns", Name: "sink", Port: "https", TLS: true},
+		want:        "https://203.0.113.10:31000",
+		wantService: &corev1.ServicePort{Name: "https", Port: 8443, TargetPort: intstr.FromInt32(9443), Protocol: corev1.ProtocolTCP, NodePort: 31000},
+	}, {
+		name:    "node port of the service",
+		objects: []runtime.Object{service(corev1.ServicePort{Port: 8080, NodePort: 30080}), internal},
+		svc:     Service{Namespace: "test-ns", Name: "sink", Port: "8080"},
+		want:    "http://172.18.0.2:30080",
+		reused:  true,
+	}, {
+		name: "existing NodePort service",
+		objects: []runtime.Object{clusterIP, internal, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "sink" + nodePortSuffix, Namespace: "test-ns"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, Ports: []corev1.ServicePort{{Port: 8080, NodePort: 32123}}},
+		}},
+		svc:  Service{Namespace: "test-ns", Name: "sink", Port: "http"},
+		want: "http://172.18.0.2:32123",
+	}, {
+		name:    "unknown port",
+		objects: []runtime.Object{clusterIP, internal},
+		svc:     Service{Namespace: "test-ns", Name: "sink", Port: "grpc"},
+		wantErr: "has no port grpc",
+	}, {
+		name:    "missing service",
+		objects: []runtime.Object{internal},
+		svc:     Service{Namespace: "test-ns", Name: "sink"},
+		wantErr: "failed to get service sink",
+	}, {
+		name:    "no node address",
+		objects: []runtime.Object{clusterIP, node("bare", corev1.NodeAddress{Type: corev1.NodeHostName, Address: "bare"})},
+		svc:     Service{Namespace: "test-ns", Name: "sink"},
+		wantErr: "no node has an external or internal IP",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fcs := testutil.NewFakeClients("test-ns", tt.objects...)
+			allocateNodePorts(fcs, 31000)
+
+			url, err := NodePort().Expose(c, tt.svc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expose() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expose() failed: %v", err)
+			}
+			if url != tt.want {
+				t.Errorf("Expose() = %s, want %s", url, tt.want)
+			}
+
+			for _, action := range fcs.Kube.Actions() {
+				if action.Matches("create", "services") && tt.reused {
+					t.Errorf("NodePort service created while a node port exists")
+				}
+			}
+			if tt.wantService == nil {
+				return
+			}
+			np, err := fcs.Kube.CoreV1().Services("test-ns").Get(context.Background(), "sink"+nodePortSuffix, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the NodePort service: %v", err)
+			}
+			if np.Spec.Type != corev1.ServiceTypeNodePort {
+				t.Errorf("type = %s, want NodePort", np.Spec.Type)
+			}
+			if np.Spec.Selector["app"] != "sink" {
+				t.Errorf("selector = %v, want the one of the service", np.Spec.Selector)
+			}
+			if len(np.Spec.Ports) != 1 || np.Spec.Ports[0] != *tt.wantService {
+				t.Errorf("ports = %+v, want %+v", np.Spec.Ports, *tt.wantService)
+			}
+		})
+	}
+}
+
+func TestNodePortUnexpose(t *testing.T) {
+	np := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "sink" + nodePortSuffix, Namespace: "test-ns"}}
+	c, fcs := testutil.NewFakeClients("test-ns", np)
+	svc := Service{Namespace: "test-ns", Name: "sink"}
+
+	if err := NodePort().Unexpose(c, svc); err != nil {
+		t.Fatalf("Unexpose() failed: %v", err)
+	}
+	if _, err := fcs.Kube.CoreV1().Services("test-ns").Get(context.Background(), np.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("NodePort service still exists after Unexpose(): %v", err)
+	}
+	if err := NodePort().Unexpose(c, svc); err != nil {
+		t.Errorf("Unexpose() of a service not exposed failed: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// forward is a port-forward to a pod, running until stop is closed or the
+// connection to the pod is lost
+type forward struct {
+	url  string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// portForward forwards local ports to the pods of the services, like
+// `oc port-forward service/<name>`
+type portForward struct {
+	mu       sync.Mutex
+	forwards map[string]*forward
+}
+
+// PortForward returns the strategy forwarding a local port of 127.0.0.1 to a
+// ready pod of the service. The forward follows the pod it was opened to, it
+// is opened again on the next Expose when that pod is gone.
+func PortForward() Strategy {
+	return &portForward{forwards: map[string]*forward{}}
+}
+
+func (*portForward) Name() string {
+	return PortForwardMode
+}
+
+func (p *portForward) Expose(c *clients.Clients, svc Service) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if f, ok := p.forwards[svc.String()]; ok {
+		select {
+		case <-f.done:
+			delete(p.forwards, svc.String())
+		default:
+			return f.url, nil
+		}
+	}
+
+	s, err := c.KubeClient.Kube.CoreV1().Services(svc.Namespace).Get(c.Ctx, svc.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %s in namespace %s: %v", svc.Name, svc.Namespace, err)
+	}
+	port, err := servicePort(s, svc.Port)
+	if err != nil {
+		return "", err
+	}
+	pod, err := readyPod(c, s)
+	if err != nil {
+		return "", err
+	}
+	target, err := containerPort(pod, port)
+	if err != nil {
+		return "", err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.KubeConfig)
+	if err != nil {
+		return "", err
+	}
+	req := c.KubeClient.Kube.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	f := &forward{stop: make(chan struct{}), done: make(chan struct{})}
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", target)}, f.stop, ready, io.Discard, log.Writer())
+	if err != nil {
+		return "", fmt.Errorf("failed to forward port %d of pod %s: %v", target, pod.Name, err)
+	}
+	errs := make(chan error, 1)
+	go func() {
+		defer close(f.done)
+		errs <- fw.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err := <-errs:
+		return "", fmt.Errorf("failed to forward port %d of pod %s: %v", target, pod.Name, err)
+	case <-time.After(config.APITimeout):
+		close(f.stop)
+		return "", fmt.Errorf("timed out forwarding port %d of pod %s", target, pod.Name)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		close(f.stop)
+		return "", err
+	}
+	f.url = fmt.Sprintf("%s://127.0.0.1:%d", svc.scheme(), ports[0].Local)
+	p.forwards[svc.String()] = f
+	log.Printf("Forwarding %s to port %d of pod %s", f.url, target, pod.Name)
+	return f.url, nil
+}
+
+// Unexpose stops the forwards to every port of the service
+func (p *portForward) Unexpose(c *clients.Clients, svc Service) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	prefix := fmt.Sprintf("%s/%s:", svc.Namespace, svc.Name)
+	for key, f := range p.forwards {
+		if strings.HasPrefix(key, prefix) {
+			close(f.stop)
+			delete(p.forwards, key)
+		}
+	}
+	return nil
+}
+
+// readyPod waits for a running and ready pod selected by the service
+func readyPod(c *clients.Clients, svc *corev1.Service) (*corev1.Pod, error) {
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s in namespace %s has no selector", svc.Name, svc.Namespace)
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector).String()
+	var ready *corev1.Pod
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		pods, err := c.KubeClient.Kube.CoreV1().Pods(svc.Namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, err
+		}
+		for i := range pods.Items {
+			if isReady(&pods.Items[i]) {
+				ready = &pods.Items[i]
+				return true, nil
+			}
+		}
+		log.Printf("Waiting for a ready pod of service %s", svc.Name)
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no ready pod of service %s in namespace %s: %v", svc.Name, svc.Namespace, err)
+	}
+	return ready, nil
+}
+
+func isReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containerPort returns the port of the pod the service port targets
+func containerPort(pod *corev1.Pod, port corev1.ServicePort) (int32, error) {
+	switch {
+	case port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal, nil
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, p := range container.Ports {
+				if p.Name == port.TargetPort.StrVal {
+					return p.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no port %s", pod.Name, port.TargetPort.StrVal)
+	default:
+		return port.Port, nil
+	}
+}
@@ -0,0 +1,195 @@
+package expose
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func pod(name string, phase corev1.PodPhase, ready corev1.ConditionStatus, ports ...corev1.ContainerPort) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", Labels: map[string]string{"app": "sink"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "sink", Ports: ports}}},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	if ready != "" {
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+	}
+	return p
+}
+
+func TestIsReady(t *testing.T) {
+	deleted := pod("deleted", corev1.PodRunning, corev1.ConditionTrue)
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{name: "running and ready", pod: pod("a", corev1.PodRunning, corev1.ConditionTrue), want: true},
+		{name: "running not ready", pod: pod("a", corev1.PodRunning, corev1.ConditionFalse)},
+		{name: "running without condition", pod: pod("a", corev1.PodRunning, "")},
+		{name: "pending", pod: pod("a", corev1.PodPending, corev1.ConditionTrue)},
+		{name: "terminating", pod: deleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReady(tt.pod); got != tt.want {
+				t.Errorf("isReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyPod(t *testing.T) {
+	timeouts := config.CurrentTimeouts()
+	defer config.SetTimeouts(timeouts)
+	short := timeouts
+	short.APIRetry, short.APITimeout = 10*time.Millisecond, 100*time.Millisecond
+	config.SetTimeouts(short)
+
+	other := pod("other", corev1.PodRunning, corev1.ConditionTrue)
+	other.Labels = map[string]string{"app": "other"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "sink"}},
+	}
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		objects []runtime.Object
+		want    string
+		wantErr string
+	}{{
+		name:    "ready pod of the service",
+		svc:     svc,
+		objects: []runtime.Object{other, pod("starting", corev1.PodRunning, corev1.ConditionFalse), pod("ready", corev1.PodRunning, corev1.ConditionTrue)},
+		want:    "ready",
+	}, {
+		name:    "no ready pod",
+		svc:     svc,
+		objects: []runtime.Object{other, pod("starting", corev1.PodRunning, corev1.ConditionFalse)},
+		wantErr: "no ready pod of service sink",
+	}, {
+		name:    "no selector",
+		svc:     &corev1.Service{ObjectMeta: svc.ObjectMeta},
+		wantErr: "has no selector",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tt.objects...)
+			got, err := readyPod(c, tt.svc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readyPod() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readyPod() failed: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("readyPod() = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerPort(t *testing.T) {
+	p := pod("sink", corev1.PodRunning, corev1.ConditionTrue,
+		corev1.ContainerPort{Name: "metrics", ContainerPort: 9090},
+		corev1.ContainerPort{Name: "http", ContainerPort: 8081},
+	)
+	tests := []struct {
+		name    string
+		port    corev1.ServicePort
+		want    int32
+		wantErr string
+	}{
+		{name: "target port number", port: corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt32(8080)}, want: 8080},
+		{name: "target port name", port: corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")}, want: 8081},
+		{name: "no target port", port: corev1.ServicePort{Port: 80}, want: 80},
+		{name: "unknown target port name", port: corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("grpc")}, wantErr: "pod sink has no port grpc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := containerPort(p, tt.port)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("containerPort() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("containerPort() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("containerPort() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortForwardReusesForward(t *testing.T) {
+	c, _ := testutil.NewFakeClients("test-ns")
+	p := PortForward().(*portForward)
+	svc := Service{Namespace: "test-ns", Name: "sink", Port: "http"}
+	running := &forward{url: "http://127.0.0.1:40123", stop: make(chan struct{}), done: make(chan struct{})}
+	p.forwards[svc.String()] = running
+
+	// a running forward is returned without looking the service up
+	url, err := p.Expose(c, svc)
+	if err != nil || url != running.url {
+		t.Errorf("Expose() = %s, %v, want %s", url, err, running.url)
+	}
+
+	// a forward whose pod is gone is opened again
+	close(running.done)
+	if _, err := p.Expose(c, svc); err == nil || !strings.Contains(err.Error(), "failed to get service sink") {
+		t.Errorf("Expose() error = %v, want the service to be looked up again", err)
+	}
+	if _, ok := p.forwards[svc.String()]; ok {
+		t.Error("the ended forward is still recorded")
+	}
+}
+
+func TestPortForwardUnexpose(t *testing.T) {
+	c, _ := testutil.NewFakeClients("test-ns")
+	p := PortForward().(*portForward)
+	forwards := map[Service]*forward{}
+	for _, svc := range []Service{
+		{Namespace: "test-ns", Name: "sink", Port: "http"},
+		{Namespace: "test-ns", Name: "sink", Port: "8443"},
+		{Namespace: "test-ns", Name: "sink-other", Port: "http"},
+	} {
+		f := &forward{stop: make(chan struct{}), done: make(chan struct{})}
+		forwards[svc] = f
+		p.forwards[svc.String()] = f
+	}
+
+	if err := p.Unexpose(c, Service{Namespace: "test-ns", Name: "sink"}); err != nil {
+		t.Fatalf("Unexpose() failed: %v", err)
+	}
+	for svc, f := range forwards {
+		select {
+		case <-f.stop:
+			if svc.Name != "sink" {
+				t.Errorf("forward of %s stopped", svc)
+			}
+		default:
+			if svc.Name == "sink" {
+				t.Errorf("forward of %s not stopped", svc)
+			}
+		}
+	}
+	if len(p.forwards) != 1 {
+		t.Errorf("forwards = %v, want the one of sink-other", p.forwards)
+	}
+}
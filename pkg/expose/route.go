@@ -0,0 +1,27 @@
+package expose
+
+import (
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
+)
+
+// route exposes services with the platform
+type route struct{}
+
+// Route returns the strategy exposing services with a Route on OpenShift and
+// an Ingress on Kubernetes (see platform.Platform.ExposeService)
+func Route() Strategy {
+	return route{}
+}
+
+func (route) Name() string {
+	return RouteMode
+}
+
+func (route) Expose(c *clients.Clients, svc Service) (string, error) {
+	return platform.Current().ExposeService(c, svc.Namespace, svc.Name, svc.Port, svc.TLS)
+}
+
+func (route) Unexpose(c *clients.Clients, svc Service) error {
+	return platform.Current().UnexposeService(c, svc.Namespace, svc.Name)
+}
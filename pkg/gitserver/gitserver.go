@@ -0,0 +1,295 @@
+// Package gitserver deploys a git server in the namespace of a scenario, so
+// the git resolver and the git-clone and git-cli tasks fetch repositories
+// seeded from testdata instead of github.com, with no external network nor
+// GitHub token.
+//
+// The server is Gitea, from the rootless image of the gitserverimage flag
+// (GIT_SERVER_IMAGE environment variable). It serves the repositories over
+// HTTP, anonymously or with the token of its admin, over SSH with a key
+// generated for the scenario, and through the Gitea API used by the git
+// resolver with scmType gitea. The tests reach its API through pkg/expose.
+package gitserver
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	"github.com/openshift-pipelines/release-tests/pkg/pki"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Name is the name of the deployment, service and secret of the server
+	Name     = "git-server"
+	httpPort = 3000
+	sshPort  = 2222
+
+	// AdminUser is the user owning the token and SSH key of the server
+	AdminUser = "release-tests"
+	// sshUser is the user of the SSH URLs of the repositories
+	sshUser = "git"
+
+	// TokenSecret is the secret holding the token of the admin, under
+	// TokenKey, in the namespace of the server
+	TokenSecret = "git-server-token"
+	TokenKey    = "token"
+	// SSHSecret is the kubernetes.io/ssh-auth secret holding the SSH key of
+	// the admin and the known host of the server, annotated for the
+	// credentials initialization of Tekton. Link it to the service account of
+	// the runs cloning over SSH.
+	SSHSecret = "git-server-ssh"
+
+	hostKeyPath = "/etc/gitea-ssh/host_key"
+)
+
+// Server is a git server deployed in a namespace
+type Server struct {
+	Namespace string
+	// Host is the address of the server in the cluster
+	Host string
+	// Token authenticates the admin over HTTP and on the API
+	Token string
+
+	password string
+	svc      expose.Service
+	// repos are the repositories created on the server, by <org>/<repo>
+	repos map[string]bool
+}
+
+// URL returns the HTTP URL of the repository in the cluster
+func (s *Server) URL(repo string) string {
+	return fmt.Sprintf("%s/%s.git", s.ServerURL(), repo)
+}
+
+// SSHURL returns the SSH URL of the repository in the cluster
+func (s *Server) SSHURL(repo string) string {
+	return fmt.Sprintf("ssh://%s@%s:%d/%s.git", sshUser, s.Host, sshPort, repo)
+}
+
+// ServerURL returns the base URL of the server in the cluster, e.g. the
+// serverURL of the git resolver
+func (s *Server) ServerURL() string {
+	return fmt.Sprintf("http://%s:%d", s.Host, httpPort)
+}
+
+// Deploy runs the git server in the namespace and waits for it to be ready,
+// then creates the token and SSH key of its admin and their secrets
+func Deploy(c *clients.Clients, namespace string) (*Server, error) {
+	s := &Server{
+		Namespace: namespace,
+		Host:      fmt.Sprintf("%s.%s.svc.cluster.local", Name, namespace),
+		password:  rand.Text(),
+		svc:       expose.Service{Namespace: namespace, Name: Name, Port: "http"},
+		repos:     map[string]bool{},
+	}
+	hostKey, err := pki.NewSSHKey()
+	if err != nil {
+		return nil, err
+	}
+	hostKeyPEM, err := hostKey.PrivateKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{"app": Name}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		StringData: map[string]string{
+			"admin-password": s.password,
+			"host_key":       string(hostKeyPEM),
+		},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("a git server is already deployed in namespace %s", namespace)
+		}
+		return nil, fmt.Errorf("failed to create secret %s in namespace %s: %v", Name, namespace, err)
+	}
+
+	if _, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Create(c.Ctx, s.deployment(labels), metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create deployment %s in namespace %s: %v", Name, namespace, err)
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: httpPort, TargetPort: intstr.FromString("http")},
+				{Name: "ssh", Port: sshPort, TargetPort: intstr.FromString("ssh")},
+			},
+		},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Services(namespace).Create(c.Ctx, service, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create service %s in namespace %s: %v", Name, namespace, err)
+	}
+	if err := k8s.WaitForDeployment(c.Ctx, c.KubeClient.Kube, namespace, Name, 1, config.APIRetry, config.APITimeout); err != nil {
+		return nil, fmt.Errorf("git server in namespace %s is not ready: %v", namespace, err)
+	}
+
+	if err := s.createToken(c); err != nil {
+		return nil, err
+	}
+	if err := s.createSSHKey(c, hostKey); err != nil {
+		return nil, err
+	}
+	log.Printf("Git server deployed at %s", s.ServerURL())
+	return s, nil
+}
+
+// deployment returns the deployment of Gitea. Its admin is created by an init
+// container sharing its configuration and data.
+func (s *Server) deployment(labels map[string]string) *appsv1.Deployment {
+	env := []corev1.EnvVar{
+		// the random UID of the pod on OpenShift has no user name, Gitea
+		// then compares the user it runs as with $USER
+		{Name: "USER", Value: sshUser},
+		{Name: "GITEA__security__INSTALL_LOCK", Value: "true"},
+		{Name: "GITEA__database__DB_TYPE", Value: "sqlite3"},
+		{Name: "GITEA__server__DOMAIN", Value: s.Host},
+		{Name: "GITEA__server__ROOT_URL", Value: s.ServerURL() + "/"},
+		{Name: "GITEA__server__HTTP_PORT", Value: strconv.Itoa(httpPort)},
+		{Name: "GITEA__server__START_SSH_SERVER", Value: "true"},
+		{Name: "GITEA__server__SSH_DOMAIN", Value: s.Host},
+		{Name: "GITEA__server__SSH_PORT", Value: strconv.Itoa(sshPort)},
+		{Name: "GITEA__server__SSH_LISTEN_PORT", Value: strconv.Itoa(sshPort)},
+		{Name: "GITEA__server__SSH_SERVER_HOST_KEYS", Value: hostKeyPath},
+		{Name: "GITEA__service__DISABLE_REGISTRATION", Value: "true"},
+		{Name: "GITEA__repository__DEFAULT_BRANCH", Value: "main"},
+		{Name: "GITEA__log__LEVEL", Value: "Warn"},
+		{Name: "ADMIN_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: Name}, Key: "admin-password"},
+		}},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: "data", MountPath: "/var/lib/gitea"},
+		{Name: "config", MountPath: "/etc/gitea"},
+		{Name: "host-key", MountPath: "/etc/gitea-ssh", ReadOnly: true},
+	}
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	createAdmin := fmt.Sprintf(`gitea --config "$GITEA_APP_INI" migrate && `+
+		`gitea --config "$GITEA_APP_INI" admin user create --admin --username %s --password "$ADMIN_PASSWORD" `+
+		`--email %s@example.com --must-change-password=false`, AdminUser, AdminUser)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: s.Namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{
+						Name:  "create-admin",
+						Image: config.Flags.GitServerImage,
+						// the entrypoint writes the configuration before running the command
+						Command:         []string{"/usr/local/bin/docker-entrypoint.sh", "sh", "-c", createAdmin},
+						Env:             env,
+						VolumeMounts:    mounts,
+						SecurityContext: securityContext,
+					}},
+					Containers: []corev1.Container{{
+						Name:  "gitea",
+						Image: config.Flags.GitServerImage,
+						Env:   env,
+						Ports: []corev1.ContainerPort{
+							{Name: "http", ContainerPort: httpPort},
+							{Name: "ssh", ContainerPort: sshPort},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/api/healthz", Port: intstr.FromString("http")},
+							},
+							PeriodSeconds: 2,
+						},
+						VolumeMounts:    mounts,
+						SecurityContext: securityContext,
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						{Name: "config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						{Name: "host-key", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+							SecretName: Name,
+							Items:      []corev1.KeyToPath{{Key: "host_key", Path: "host_key", Mode: ptr.To[int32](0400)}},
+						}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// createToken creates the token of the admin and its secret
+func (s *Server) createToken(c *clients.Clients) error {
+	var token struct {
+		SHA1 string `json:"sha1"`
+	}
+	body := map[string]interface{}{
+		"name":   "release-tests-" + rand.Text(),
+		"scopes": []string{"write:repository", "write:organization", "write:user"},
+	}
+	if err := s.api(c, "POST", "/users/"+AdminUser+"/tokens", body, &token); err != nil {
+		return fmt.Errorf("failed to create the token of the git server: %v", err)
+	}
+	s.Token = token.SHA1
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: TokenSecret, Namespace: s.Namespace},
+		StringData: map[string]string{TokenKey: s.Token},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(s.Namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create secret %s in namespace %s: %v", TokenSecret, s.Namespace, err)
+	}
+	return nil
+}
+
+// createSSHKey creates an SSH key for the admin and its secret, trusting the
+// host key of the server
+func (s *Server) createSSHKey(c *clients.Clients, hostKey *pki.SSHKey) error {
+	key, err := pki.NewSSHKey()
+	if err != nil {
+		return err
+	}
+	privateKey, err := key.PrivateKeyPEM()
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{"title": "release-tests", "key": key.AuthorizedKey()}
+	if err := s.api(c, "POST", "/user/keys", body, nil); err != nil {
+		return fmt.Errorf("failed to add the SSH key of the git server: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        SSHSecret,
+			Namespace:   s.Namespace,
+			Annotations: map[string]string{"tekton.dev/git-0": fmt.Sprintf("%s:%d", s.Host, sshPort)},
+		},
+		Type: corev1.SecretTypeSSHAuth,
+		StringData: map[string]string{
+			corev1.SSHAuthPrivateKey: string(privateKey),
+			"known_hosts":            hostKey.KnownHost(s.Host, sshPort),
+		},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(s.Namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create secret %s in namespace %s: %v", SSHSecret, s.Namespace, err)
+	}
+	return nil
+}
+
+// Close stops exposing the server, which is deleted with its namespace
+func (s *Server) Close(c *clients.Clients) error {
+	return expose.Current().Unexpose(c, s.svc)
+}
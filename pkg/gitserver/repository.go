@@ -0,0 +1,159 @@
+package gitserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+)
+
+// Source is a file or a directory of this repository seeded into a
+// repository of the server
+type Source struct {
+	// Path is relative to this repository, e.g. testdata/resolvers/pipelines,
+	// nothing is seeded if empty
+	Path string
+	// Target is the path of the file or directory in the repository of the
+	// server, Path if empty
+	Target string
+}
+
+// ReadSources returns the files of the sources by their path in the
+// repository of the server
+func ReadSources(sources []Source) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, src := range sources {
+		if src.Path == "" {
+			continue
+		}
+		target := src.Target
+		if target == "" {
+			target = src.Path
+		}
+		root := config.Path(src.Path)
+		err := filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			files[path.Clean(path.Join(target, filepath.ToSlash(rel)))] = content
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", src.Path, err)
+		}
+	}
+	return files, nil
+}
+
+// CreateRepository creates the repository <org>/<name> on the server, with a
+// README.md unless the files have one, and commits the files to the branch,
+// its default branch. The YAML files are rewritten for the server like the
+// manifests given to Rewrite, for this repository and the ones created before.
+func (s *Server) CreateRepository(c *clients.Clients, repo, branch string, private bool, files map[string][]byte) error {
+	org, name, ok := strings.Cut(repo, "/")
+	if !ok || org == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid repository %q, expected <org>/<name>", repo)
+	}
+	if err := s.api(c, http.MethodGet, "/orgs/"+org, nil, nil); err != nil {
+		if err := s.api(c, http.MethodPost, "/orgs", map[string]interface{}{"username": org, "visibility": "public"}, nil); err != nil {
+			return fmt.Errorf("failed to create organization %s: %v", org, err)
+		}
+	}
+	body := map[string]interface{}{"name": name, "private": private, "default_branch": branch}
+	if err := s.api(c, http.MethodPost, "/orgs/"+org+"/repos", body, nil); err != nil {
+		return fmt.Errorf("failed to create repository %s: %v", repo, err)
+	}
+	s.repos[repo] = true
+
+	// the files of the caller are left as they are
+	seeded := make(map[string][]byte, len(files)+1)
+	maps.Copy(seeded, files)
+	if _, ok := seeded["README.md"]; !ok {
+		seeded["README.md"] = []byte(fmt.Sprintf("# %s\n\nSeeded by the release tests.\n", name))
+	}
+	paths := make([]string, 0, len(seeded))
+	for p := range seeded {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	changes := make([]map[string]string, 0, len(paths))
+	for _, p := range paths {
+		content := seeded[p]
+		if ext := path.Ext(p); ext == ".yaml" || ext == ".yml" {
+			if rewritten, err := s.Rewrite(content); err == nil {
+				content = rewritten
+			} else {
+				log.Printf("Committing %s of repository %s as is, it is not YAML: %v", p, repo, err)
+			}
+		}
+		changes = append(changes, map[string]string{
+			"operation": "create",
+			"path":      p,
+			"content":   base64.StdEncoding.EncodeToString(content),
+		})
+	}
+	commit := map[string]interface{}{"branch": branch, "message": "Seed " + repo, "files": changes}
+	if err := s.api(c, http.MethodPost, "/repos/"+repo+"/contents", commit, nil); err != nil {
+		return fmt.Errorf("failed to commit %d files to repository %s: %v", len(seeded), repo, err)
+	}
+	log.Printf("Created repository %s (branch %s, private: %t) with %d files", s.URL(repo), branch, private, len(seeded))
+	return nil
+}
+
+// api sends a request to the Gitea API of the server as its admin, and
+// decodes the response into out unless it is nil
+func (s *Server) api(c *clients.Clients, method, apiPath string, body, out interface{}) error {
+	base, err := expose.Current().Expose(c, s.svc)
+	if err != nil {
+		return fmt.Errorf("failed to expose the git server: %v", err)
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(c.Ctx, method, base+"/api/v1"+apiPath, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(AdminUser, s.password)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: config.CLITimeout}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the git server: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned %s: %s", method, apiPath, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,103 @@
+package gitserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+)
+
+// serverURL exposes every service at the URL of a test server
+type serverURL string
+
+func (serverURL) Name() string { return "test" }
+
+func (u serverURL) Expose(*clients.Clients, expose.Service) (string, error) { return string(u), nil }
+
+func (serverURL) Unexpose(*clients.Clients, expose.Service) error { return nil }
+
+func TestCreateRepository(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	committed := map[string]string{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/orgs/openshift-pipelines":
+			http.NotFound(w, r)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/openshift-pipelines/test/contents":
+			var commit struct {
+				Files []struct {
+					Path    string `json:"path"`
+					Content string `json:"content"`
+				} `json:"files"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&commit); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for _, f := range commit.Files {
+				content, _ := base64.StdEncoding.DecodeString(f.Content)
+				committed[f.Path] = string(content)
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer api.Close()
+	defer expose.Set(expose.Set(serverURL(api.URL)))
+
+	c, _ := testutil.NewFakeClients("releasetest-git")
+	s := testServer()
+	files := map[string][]byte{
+		"pipeline.yaml": []byte("url: https://github.com/openshift-pipelines/test.git\n"),
+		"script.sh":     []byte("git clone https://github.com/openshift-pipelines/test.git\n"),
+	}
+	if err := s.CreateRepository(c, "openshift-pipelines/test", "main", false, files); err != nil {
+		t.Fatalf("CreateRepository() failed: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Errorf("CreateRepository() changed the files given: %v", files)
+	}
+	if !strings.HasPrefix(committed["README.md"], "# test\n") {
+		t.Errorf("README.md = %q, want one seeded", committed["README.md"])
+	}
+	if want := "---\nurl: http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git\n"; committed["pipeline.yaml"] != want {
+		t.Errorf("pipeline.yaml = %q, want %q", committed["pipeline.yaml"], want)
+	}
+	if committed["script.sh"] != string(files["script.sh"]) {
+		t.Errorf("script.sh = %q, want it committed as is", committed["script.sh"])
+	}
+	want := []string{
+		"GET /api/v1/orgs/openshift-pipelines",
+		"POST /api/v1/orgs",
+		"POST /api/v1/orgs/openshift-pipelines/repos",
+		"POST /api/v1/repos/openshift-pipelines/test/contents",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+	if !s.repos["openshift-pipelines/test"] {
+		t.Error("the repository is not recorded as created")
+	}
+}
+
+func TestCreateRepositoryInvalid(t *testing.T) {
+	c, _ := testutil.NewFakeClients("releasetest-git")
+	for _, repo := range []string{"test", "/test", "openshift-pipelines/", "openshift-pipelines/test/sub"} {
+		if err := testServer().CreateRepository(c, repo, "main", false, nil); err == nil || !strings.Contains(err.Error(), "invalid repository") {
+			t.Errorf("CreateRepository(%q) error = %v, want invalid repository", repo, err)
+		}
+	}
+}
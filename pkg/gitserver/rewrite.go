@@ -0,0 +1,163 @@
+package gitserver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Rewrite points the manifests, YAML documents, at the repositories of the
+// server:
+//   - the github.com URLs of its repositories, HTTPS or SSH, in any string
+//     become their URLs on the server, e.g. the url param of the git resolver,
+//     the URL param of git-clone or the script of git-cli
+//   - git resolver refs to its repositories through the SCM API (org and repo
+//     params) get the gitea scmType and the serverURL of the server
+//   - the token and gitToken params of git resolver refs, and their keys, are
+//     replaced by the secret of the token of the server (TokenSecret)
+func (s *Server) Rewrite(manifests []byte) ([]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+	var out bytes.Buffer
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var obj interface{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			continue
+		}
+		rewritten, err := yaml.Marshal(s.rewrite(obj))
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(rewritten)
+	}
+}
+
+// RewriteFile rewrites the manifests of the file (path relative to the
+// repository) and writes them to dir, returning the path of the rewritten file
+func (s *Server) RewriteFile(file, dir string) (string, error) {
+	manifests, err := os.ReadFile(config.Path(file))
+	if err != nil {
+		return "", fmt.Errorf("could not load test data from file %s: %v", file, err)
+	}
+	rewritten, err := s.Rewrite(manifests)
+	if err != nil {
+		return "", fmt.Errorf("failed to rewrite %s for the git server: %v", file, err)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "git-server-"+filepath.Base(file))
+	return path, os.WriteFile(path, rewritten, 0600)
+}
+
+func (s *Server) rewrite(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return s.rewriteURLs(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = s.rewrite(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = s.rewrite(v[key])
+		}
+		if v["resolver"] == "git" {
+			if params, ok := v["params"].([]interface{}); ok {
+				v["params"] = s.rewriteResolverParams(params)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// rewriteURLs replaces the github.com URLs of the repositories of the server
+// in the string
+func (s *Server) rewriteURLs(value string) string {
+	repos := make([]string, 0, len(s.repos))
+	for repo := range s.repos {
+		repos = append(repos, repo)
+	}
+	// longest first, so openshift-pipelines/test-private is not taken for
+	// openshift-pipelines/test
+	sort.Slice(repos, func(i, j int) bool { return len(repos[i]) > len(repos[j]) })
+	for _, repo := range repos {
+		quoted := regexp.QuoteMeta(repo)
+		ssh := regexp.MustCompile(`(?:ssh://)?git@github\.com[:/]` + quoted + `(?:\.git)?([^\w.\-/]|$)`)
+		value = ssh.ReplaceAllString(value, s.SSHURL(repo)+"$1")
+		https := regexp.MustCompile(`https?://github\.com/` + quoted + `(?:\.git)?([^\w.\-/]|$)`)
+		value = https.ReplaceAllString(value, s.URL(repo)+"$1")
+	}
+	return value
+}
+
+// rewriteResolverParams points the params of a git resolver ref to a
+// repository of the server at the server
+func (s *Server) rewriteResolverParams(params []interface{}) []interface{} {
+	values := map[string]string{}
+	for _, p := range params {
+		if param, ok := p.(map[string]interface{}); ok {
+			name, _ := param["name"].(string)
+			value, _ := param["value"].(string)
+			values[name] = value
+		}
+	}
+	api := s.repos[values["org"]+"/"+values["repo"]]
+	if !api && !strings.HasPrefix(values["url"], s.ServerURL()+"/") {
+		return params
+	}
+	set := map[string]string{}
+	if api {
+		set["scmType"] = "gitea"
+		set["serverURL"] = s.ServerURL()
+		set["token"], set["tokenKey"] = TokenSecret, TokenKey
+	}
+	if _, ok := values["token"]; ok {
+		set["token"], set["tokenKey"] = TokenSecret, TokenKey
+	}
+	if _, ok := values["gitToken"]; ok {
+		set["gitToken"], set["gitTokenKey"] = TokenSecret, TokenKey
+	}
+	for _, p := range params {
+		if param, ok := p.(map[string]interface{}); ok {
+			name, _ := param["name"].(string)
+			if value, ok := set[name]; ok {
+				param["value"] = value
+				delete(set, name)
+			}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		params = append(params, map[string]interface{}{"name": name, "value": set[name]})
+	}
+	return params
+}
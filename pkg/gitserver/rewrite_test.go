@@ -0,0 +1,242 @@
+package gitserver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testServer returns a server with the repositories, as if created on it
+func testServer(repos ...string) *Server {
+	s := &Server{Namespace: "releasetest-git", Host: "git-server.releasetest-git.svc", repos: map[string]bool{}}
+	for _, repo := range repos {
+		s.repos[repo] = true
+	}
+	return s
+}
+
+func TestRewrite(t *testing.T) {
+	s := testServer("openshift-pipelines/test", "openshift-pipelines/test-private")
+	tests := []struct {
+		name      string
+		manifests string
+		want      string
+		wantErr   bool
+	}{{
+		name: "https URLs, longest repository first",
+		manifests: `
+params:
+- name: URL
+  value: https://github.com/openshift-pipelines/test-private.git
+- name: mirror
+  value: https://github.com/openshift-pipelines/test
+`,
+		want: `---
+params:
+- name: URL
+  value: http://git-server.releasetest-git.svc:3000/openshift-pipelines/test-private.git
+- name: mirror
+  value: http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git
+`,
+	}, {
+		name: "ssh URLs in a script",
+		manifests: `
+script: |
+  git clone git@github.com:openshift-pipelines/test.git src
+  git remote add private ssh://git@github.com/openshift-pipelines/test-private
+`,
+		want: `---
+script: |
+  git clone ssh://git@git-server.releasetest-git.svc:2222/openshift-pipelines/test.git src
+  git remote add private ssh://git@git-server.releasetest-git.svc:2222/openshift-pipelines/test-private.git
+`,
+	}, {
+		name: "repositories not on the server",
+		manifests: `
+urls:
+- https://github.com/openshift-pipelines/test-other
+- https://github.com/openshift-pipelines/test/blob/main/README.md
+- https://gitlab.com/openshift-pipelines/test.git
+`,
+		want: `---
+urls:
+- https://github.com/openshift-pipelines/test-other
+- https://github.com/openshift-pipelines/test/blob/main/README.md
+- https://gitlab.com/openshift-pipelines/test.git
+`,
+	}, {
+		name: "git resolver url with gitToken",
+		manifests: `
+pipelineRef:
+  resolver: git
+  params:
+  - name: url
+    value: https://github.com/openshift-pipelines/test-private.git
+  - name: pathInRepo
+    value: pipeline.yaml
+  - name: gitToken
+    value: github-token
+  - name: gitTokenKey
+    value: password
+`,
+		want: `---
+pipelineRef:
+  params:
+  - name: url
+    value: http://git-server.releasetest-git.svc:3000/openshift-pipelines/test-private.git
+  - name: pathInRepo
+    value: pipeline.yaml
+  - name: gitToken
+    value: git-server-token
+  - name: gitTokenKey
+    value: token
+  resolver: git
+`,
+	}, {
+		name: "git resolver through the SCM API",
+		manifests: `
+taskRef:
+  resolver: git
+  params:
+  - name: org
+    value: openshift-pipelines
+  - name: repo
+    value: test
+  - name: token
+    value: github-token
+`,
+		want: `---
+taskRef:
+  params:
+  - name: org
+    value: openshift-pipelines
+  - name: repo
+    value: test
+  - name: token
+    value: git-server-token
+  - name: scmType
+    value: gitea
+  - name: serverURL
+    value: http://git-server.releasetest-git.svc:3000
+  - name: tokenKey
+    value: token
+  resolver: git
+`,
+	}, {
+		name: "git resolver of another repository",
+		manifests: `
+taskRef:
+  resolver: git
+  params:
+  - name: url
+    value: https://github.com/tektoncd/catalog.git
+  - name: token
+    value: github-token
+`,
+		want: `---
+taskRef:
+  params:
+  - name: url
+    value: https://github.com/tektoncd/catalog.git
+  - name: token
+    value: github-token
+  resolver: git
+`,
+	}, {
+		name: "several documents",
+		manifests: `---
+kind: Pipeline
+---
+---
+kind: PipelineRun
+url: git@github.com:openshift-pipelines/test
+`,
+		want: `---
+kind: Pipeline
+---
+kind: PipelineRun
+url: ssh://git@git-server.releasetest-git.svc:2222/openshift-pipelines/test.git
+`,
+	}, {
+		name:      "not YAML",
+		manifests: "kind: [Pipeline\n",
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Rewrite([]byte(tt.manifests))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rewrite() error = %v, want error %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Rewrite() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteResolverParams(t *testing.T) {
+	s := testServer("openshift-pipelines/test")
+	param := func(name, value string) interface{} {
+		return map[string]interface{}{"name": name, "value": value}
+	}
+	tests := []struct {
+		name   string
+		params []interface{}
+		want   []interface{}
+	}{{
+		name:   "repository through the SCM API",
+		params: []interface{}{param("org", "openshift-pipelines"), param("repo", "test"), param("pathInRepo", "task.yaml")},
+		want: []interface{}{
+			param("org", "openshift-pipelines"), param("repo", "test"), param("pathInRepo", "task.yaml"),
+			param("scmType", "gitea"), param("serverURL", "http://git-server.releasetest-git.svc:3000"),
+			param("token", TokenSecret), param("tokenKey", TokenKey),
+		},
+	}, {
+		name:   "scmType, serverURL and token replaced",
+		params: []interface{}{param("scmType", "github"), param("serverURL", "https://api.github.com"), param("org", "openshift-pipelines"), param("repo", "test"), param("token", "github-token"), param("tokenKey", "password")},
+		want:   []interface{}{param("scmType", "gitea"), param("serverURL", "http://git-server.releasetest-git.svc:3000"), param("org", "openshift-pipelines"), param("repo", "test"), param("token", TokenSecret), param("tokenKey", TokenKey)},
+	}, {
+		name:   "url of the server",
+		params: []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("revision", "main")},
+		want:   []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("revision", "main")},
+	}, {
+		name:   "url of the server with gitToken",
+		params: []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("gitToken", "github-token")},
+		want:   []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("gitToken", TokenSecret), param("gitTokenKey", TokenKey)},
+	}, {
+		name:   "url of the server with token",
+		params: []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("token", "github-token"), param("tokenKey", "password")},
+		want:   []interface{}{param("url", "http://git-server.releasetest-git.svc:3000/openshift-pipelines/test.git"), param("token", TokenSecret), param("tokenKey", TokenKey)},
+	}, {
+		name:   "url of another server",
+		params: []interface{}{param("url", "https://github.com/tektoncd/catalog.git"), param("gitToken", "github-token")},
+		want:   []interface{}{param("url", "https://github.com/tektoncd/catalog.git"), param("gitToken", "github-token")},
+	}, {
+		name:   "repository not on the server",
+		params: []interface{}{param("org", "tektoncd"), param("repo", "catalog"), param("token", "github-token")},
+		want:   []interface{}{param("org", "tektoncd"), param("repo", "catalog"), param("token", "github-token")},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.rewriteResolverParams(tt.params); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rewriteResolverParams() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteFile(t *testing.T) {
+	s := testServer("openshift-pipelines/test")
+	dir := t.TempDir()
+	path, err := s.RewriteFile("testdata/resolvers/pipelineruns/git-resolver-pipelinerun-private-token-auth.yaml", dir)
+	if err != nil {
+		t.Fatalf("RewriteFile() failed: %v", err)
+	}
+	if !strings.HasPrefix(path, dir) || !strings.HasSuffix(path, "git-server-git-resolver-pipelinerun-private-token-auth.yaml") {
+		t.Errorf("RewriteFile() = %s, want git-server-<file> in %s", path, dir)
+	}
+	if _, err := s.RewriteFile("testdata/resolvers/missing.yaml", dir); err == nil || !strings.Contains(err.Error(), "could not load test data") {
+		t.Errorf("RewriteFile() of a missing file error = %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RoutesPath is the path where the handler serves its routes (GET, with their
+// passwords redacted), merges the posted ones (PUT) and forgets them (DELETE).
+// Files are served on any other path.
+const RoutesPath = "/_routes"
+
+// redacted stands in for the passwords of the routes served on RoutesPath
+const redacted = "REDACTED"
+
+// Route is how the handler responds on a path, instead of serving its file
+type Route struct {
+	// Delay is waited before responding, e.g. 90s
+	Delay string `json:"delay,omitempty"`
+	// Username and Password, when set, are required with HTTP basic auth
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Redirect responds 302 Found to the path
+	Redirect string `json:"redirect,omitempty"`
+	// Status responds the status code, e.g. 404 or 500
+	Status int `json:"status,omitempty"`
+}
+
+// Handler is an http.Handler serving the files of a directory, with the
+// routes of their paths
+type Handler struct {
+	root   string
+	mu     sync.Mutex
+	routes map[string]Route
+}
+
+// NewHandler returns a handler serving the files of root with no route
+func NewHandler(root string) *Handler {
+	return &Handler{root: root, routes: map[string]Route{}}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == RoutesPath {
+		h.serveRoutes(w, req)
+		return
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, "only GET and HEAD are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := path.Clean("/" + req.URL.Path)[1:]
+	h.mu.Lock()
+	route := h.routes[name]
+	h.mu.Unlock()
+
+	if route.Delay != "" {
+		delay, err := time.ParseDuration(route.Delay)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid delay %q of %s: %v", route.Delay, name, err), http.StatusInternalServerError)
+			return
+		}
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			log.Printf("request of %s cancelled after %s", name, delay)
+			return
+		}
+	}
+	if route.Username != "" {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != route.Username || password != route.Password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="release-tests"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+	if route.Redirect != "" {
+		http.Redirect(w, req, path.Clean("/"+route.Redirect), http.StatusFound)
+		return
+	}
+	if route.Status != 0 {
+		http.Error(w, http.StatusText(route.Status), route.Status)
+		return
+	}
+
+	content, err := os.ReadFile(filepath.Join(h.root, filepath.FromSlash(name)))
+	if err != nil {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write(content); err != nil {
+		log.Printf("failed to write %s: %v", name, err)
+	}
+}
+
+func (h *Handler) serveRoutes(w http.ResponseWriter, req *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch req.Method {
+	case http.MethodGet:
+		routes := make(map[string]Route, len(h.routes))
+		for name, route := range h.routes {
+			if route.Password != "" {
+				route.Password = redacted
+			}
+			routes[name] = route
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(routes); err != nil {
+			log.Printf("failed to write the routes: %v", err)
+		}
+	case http.MethodPut:
+		var routes map[string]Route
+		if err := json.NewDecoder(req.Body).Decode(&routes); err != nil {
+			http.Error(w, fmt.Sprintf("invalid routes: %v", err), http.StatusBadRequest)
+			return
+		}
+		for name, route := range routes {
+			name = path.Clean("/" + name)[1:]
+			h.routes[name] = route
+			log.Printf("route of %s set: delay %q, basic auth %t, redirect %q, status %d",
+				name, route.Delay, route.Username != "", route.Redirect, route.Status)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		h.routes = map[string]Route{}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "only GET, PUT and DELETE are allowed on "+RoutesPath, http.StatusMethodNotAllowed)
+	}
+}
+
+// Serve serves the files of root on addr until the server fails
+func Serve(addr, root string) error {
+	log.Printf("Serving %s on %s, routes are set on %s", root, addr, RoutesPath)
+	return http.ListenAndServe(addr, NewHandler(root))
+}
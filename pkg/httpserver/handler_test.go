@@ -0,0 +1,262 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the files with a handler having the routes
+func newTestServer(t *testing.T, files map[string]string, routes map[string]Route) *httptest.Server {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := NewHandler(root)
+	for name, route := range routes {
+		h.routes[name] = route
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHandler(t *testing.T) {
+	files := map[string]string{
+		"tasks/task.yaml":  "kind: Task\n",
+		"tasks/other.yaml": "kind: Pipeline\n",
+	}
+	routes := map[string]Route{
+		"private/task.yaml": {Username: "release-tests", Password: "s3cret"},
+		"tasks/old.yaml":    {Redirect: "tasks/task.yaml"},
+		"tasks/gone.yaml":   {Status: http.StatusNotFound},
+		"tasks/broken.yaml": {Status: http.StatusInternalServerError},
+		"tasks/slow.yaml":   {Delay: "100ms"},
+		"tasks/invalid":     {Delay: "soon"},
+	}
+	srv := newTestServer(t, files, routes)
+	// the redirects are checked, not followed
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		username   string
+		password   string
+		wantStatus int
+		wantBody   string
+		wantHeader map[string]string
+		minDelay   time.Duration
+	}{{
+		name:       "file",
+		path:       "/tasks/task.yaml",
+		wantStatus: http.StatusOK,
+		wantBody:   "kind: Task\n",
+		wantHeader: map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+	}, {
+		name:       "cleaned path",
+		path:       "/tasks/../tasks/./other.yaml",
+		wantStatus: http.StatusOK,
+		wantBody:   "kind: Pipeline\n",
+	}, {
+		name:       "missing file",
+		path:       "/tasks/missing.yaml",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "file out of the root",
+		path:       "/../../etc/passwd",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "method not allowed",
+		method:     http.MethodPost,
+		path:       "/tasks/task.yaml",
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:       "auth without credentials",
+		path:       "/private/task.yaml",
+		wantStatus: http.StatusUnauthorized,
+		wantHeader: map[string]string{"WWW-Authenticate": `Basic realm="release-tests"`},
+	}, {
+		name:       "auth with a wrong password",
+		path:       "/private/task.yaml",
+		username:   "release-tests",
+		password:   "guess",
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "auth with another user",
+		path:       "/private/task.yaml",
+		username:   "admin",
+		password:   "s3cret",
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "auth with the credentials",
+		path:       "/private/task.yaml",
+		username:   "release-tests",
+		password:   "s3cret",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "redirect",
+		path:       "/tasks/old.yaml",
+		wantStatus: http.StatusFound,
+		wantHeader: map[string]string{"Location": "/tasks/task.yaml"},
+	}, {
+		name:       "not found status",
+		path:       "/tasks/gone.yaml",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "internal server error status",
+		path:       "/tasks/broken.yaml",
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   "Internal Server Error\n",
+	}, {
+		name:       "delay",
+		path:       "/tasks/slow.yaml",
+		wantStatus: http.StatusNotFound,
+		minDelay:   100 * time.Millisecond,
+	}, {
+		name:       "invalid delay",
+		path:       "/tasks/invalid",
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   `invalid delay "soon" of tasks/invalid`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.username != "" {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+			start := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("%s %s failed: %v", method, tt.path, err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if elapsed := time.Since(start); elapsed < tt.minDelay {
+				t.Errorf("responded after %s, want at least %s", elapsed, tt.minDelay)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			for key, want := range tt.wantHeader {
+				if got := resp.Header.Get(key); got != want {
+					t.Errorf("header %s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerDelayCancelled(t *testing.T) {
+	srv := newTestServer(t, nil, map[string]Route{"slow": {Delay: "1m"}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+		t.Fatalf("GET /slow = %d, want the request cancelled", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("cancelled request took %s", elapsed)
+	}
+}
+
+func TestHandlerRoutes(t *testing.T) {
+	srv := newTestServer(t, map[string]string{"task.yaml": "kind: Task\n"}, nil)
+	do := func(method, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+RoutesPath, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, RoutesPath, err)
+		}
+		defer resp.Body.Close()
+		content, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(content)
+	}
+
+	if status, _ := do(http.MethodPut, `{"/task.yaml": {"username": "release-tests", "password": "s3cret"}, "a/../gone.yaml": {"status": 404}}`); status != http.StatusNoContent {
+		t.Fatalf("PUT %s = %d, want %d", RoutesPath, status, http.StatusNoContent)
+	}
+	status, body := do(http.MethodGet, "")
+	if status != http.StatusOK {
+		t.Fatalf("GET %s = %d, want %d", RoutesPath, status, http.StatusOK)
+	}
+	if strings.Contains(body, "s3cret") {
+		t.Errorf("GET %s returned the password: %s", RoutesPath, body)
+	}
+	var routes map[string]Route
+	if err := json.Unmarshal([]byte(body), &routes); err != nil {
+		t.Fatalf("GET %s returned invalid routes: %v", RoutesPath, err)
+	}
+	want := map[string]Route{
+		"task.yaml": {Username: "release-tests", Password: redacted},
+		"gone.yaml": {Status: http.StatusNotFound},
+	}
+	if len(routes) != len(want) || routes["task.yaml"] != want["task.yaml"] || routes["gone.yaml"] != want["gone.yaml"] {
+		t.Errorf("GET %s = %v, want %v", RoutesPath, routes, want)
+	}
+
+	// the redacted password is not the one required
+	resp, err := http.Get(srv.URL + "/task.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /task.yaml = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	if status, _ := do(http.MethodPut, `{"task.yaml": `); status != http.StatusBadRequest {
+		t.Errorf("PUT of invalid routes = %d, want %d", status, http.StatusBadRequest)
+	}
+	if status, _ := do(http.MethodPost, ""); status != http.StatusMethodNotAllowed {
+		t.Errorf("POST %s = %d, want %d", RoutesPath, status, http.StatusMethodNotAllowed)
+	}
+	if status, _ := do(http.MethodDelete, ""); status != http.StatusNoContent {
+		t.Errorf("DELETE %s = %d, want %d", RoutesPath, status, http.StatusNoContent)
+	}
+	if _, body := do(http.MethodGet, ""); strings.TrimSpace(body) != "{}" {
+		t.Errorf("GET %s after DELETE = %s, want no route", RoutesPath, body)
+	}
+	resp, err = http.Get(srv.URL + "/task.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /task.yaml after DELETE = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
@@ -0,0 +1,101 @@
+package httpserver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// rawURL matches the raw.githubusercontent.com URLs of the files of a branch
+// or tag of any repository, the path of the file being its last group
+var rawURL = regexp.MustCompile(`https://raw\.githubusercontent\.com/[\w.\-]+/[\w.\-]+/(?:refs/(?:heads|tags)/)?[\w.\-]+/([\w.\-/]+)`)
+
+// Rewrite points the manifests, YAML documents, at the server: the
+// raw.githubusercontent.com URLs of the files it serves in any string, e.g.
+// the url param of the http resolver, become their URLs on the server. The
+// served files are matched by their path on the server, whatever the
+// repository, branch or tag of the URL.
+func (s *Server) Rewrite(manifests []byte) ([]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+	var out bytes.Buffer
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var obj interface{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			continue
+		}
+		rewritten, err := yaml.Marshal(s.rewrite(obj))
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(rewritten)
+	}
+}
+
+// RewriteFile rewrites the manifests of the file (path relative to the
+// repository) and writes them to dir, returning the path of the rewritten file
+func (s *Server) RewriteFile(file, dir string) (string, error) {
+	manifests, err := os.ReadFile(config.Path(file))
+	if err != nil {
+		return "", fmt.Errorf("could not load test data from file %s: %v", file, err)
+	}
+	rewritten, err := s.Rewrite(manifests)
+	if err != nil {
+		return "", fmt.Errorf("failed to rewrite %s for the http server: %v", file, err)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "http-server-"+filepath.Base(file))
+	return path, os.WriteFile(path, rewritten, 0600)
+}
+
+func (s *Server) rewrite(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return s.rewriteURLs(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = s.rewrite(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = s.rewrite(v[key])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// rewriteURLs replaces the raw.githubusercontent.com URLs of the served files
+// in the string
+func (s *Server) rewriteURLs(value string) string {
+	return rawURL.ReplaceAllStringFunc(value, func(url string) string {
+		file := rawURL.FindStringSubmatch(url)[1]
+		if !s.files[file] {
+			return url
+		}
+		return s.FileURL(file)
+	})
+}
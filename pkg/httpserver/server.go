@@ -0,0 +1,262 @@
+// Package httpserver serves files of this repository from the namespace of a
+// scenario, so the http resolver fetches them without internet access, and
+// makes it respond with basic auth, redirects, delays or error statuses to
+// cover how the resolver reports them.
+//
+// The server is a small deployment of the image of cmd/httpserver set with the
+// httpserverimage flag (HTTP_SERVER_IMAGE environment variable). The served
+// files are in a config map. Its routes are set at any time through
+// pkg/expose, e.g. behind a port-forward.
+package httpserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Name is the name of the deployment, service and config map of the server
+	Name = "http-server"
+	port = 8080
+
+	// Username is the user of the routes requiring basic auth
+	Username = "release-tests"
+	// AuthSecret is the secret holding the password of Username under
+	// AuthSecretKey, in the namespace of the server, for the
+	// http-password-secret and http-password-secret-key params of the http
+	// resolver
+	AuthSecret    = "http-server-auth"
+	AuthSecretKey = "password"
+
+	// root of the served files in the container
+	dataPath = "/data"
+)
+
+// File is a file of this repository served by the server
+type File struct {
+	// Path is relative to this repository, e.g.
+	// testdata/resolvers/pipelines/http-resolver-pipeline.yaml
+	Path string
+	// Target is the path of the file on the server, Path if empty
+	Target string
+}
+
+// Server is an HTTP server deployed in a namespace
+type Server struct {
+	Namespace string
+	// URL is the base URL of the server in the cluster
+	URL string
+
+	password string
+	svc      expose.Service
+	// files are the paths of the served files
+	files map[string]bool
+}
+
+// Deploy runs the server of the files in the namespace and waits for it to be
+// ready, then creates the secret of the password of its basic auth. The YAML
+// files are rewritten like the manifests given to Rewrite, so their http
+// resolver refs to each other are served too.
+func Deploy(c *clients.Clients, namespace string, files []File) (*Server, error) {
+	s := &Server{
+		Namespace: namespace,
+		URL:       fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", Name, namespace, port),
+		password:  rand.Text(),
+		svc:       expose.Service{Namespace: namespace, Name: Name, Port: "http"},
+		files:     map[string]bool{},
+	}
+	for _, f := range files {
+		s.files[f.target()] = true
+	}
+
+	labels := map[string]string{"app": Name}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Data:       map[string]string{},
+	}
+	// config map keys cannot hold the directories of the paths, the items of
+	// the volume put the files at their paths
+	var items []corev1.KeyToPath
+	for i, f := range files {
+		content, err := os.ReadFile(config.Path(f.Path))
+		if err != nil {
+			return nil, fmt.Errorf("could not load test data from file %s: %v", f.Path, err)
+		}
+		if ext := path.Ext(f.Path); ext == ".yaml" || ext == ".yml" {
+			if content, err = s.Rewrite(content); err != nil {
+				return nil, fmt.Errorf("failed to rewrite %s for the http server: %v", f.Path, err)
+			}
+		}
+		key := "file-" + strconv.Itoa(i)
+		cm.Data[key] = string(content)
+		items = append(items, corev1.KeyToPath{Key: key, Path: f.target()})
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().ConfigMaps(namespace).Create(c.Ctx, cm, metav1.CreateOptions{}); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("an http server is already deployed in namespace %s", namespace)
+		}
+		return nil, fmt.Errorf("failed to create config map %s in namespace %s: %v", Name, namespace, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: AuthSecret, Namespace: namespace, Labels: labels},
+		StringData: map[string]string{AuthSecretKey: s.password},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create secret %s in namespace %s: %v", AuthSecret, namespace, err)
+	}
+
+	if _, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Create(c.Ctx, deployment(labels, items), metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create deployment %s in namespace %s: %v", Name, namespace, err)
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Name: "http", Port: port, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Services(namespace).Create(c.Ctx, service, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create service %s in namespace %s: %v", Name, namespace, err)
+	}
+	if err := k8s.WaitForDeployment(c.Ctx, c.KubeClient.Kube, namespace, Name, 1, config.APIRetry, config.APITimeout); err != nil {
+		return nil, fmt.Errorf("http server in namespace %s is not ready: %v", namespace, err)
+	}
+	log.Printf("HTTP server deployed at %s with %d files", s.URL, len(files))
+	return s, nil
+}
+
+func (f File) target() string {
+	if f.Target == "" {
+		return path.Clean(f.Path)
+	}
+	return path.Clean(strings.TrimPrefix(f.Target, "/"))
+}
+
+// deployment returns the deployment of the server of the files of the items
+// of the config map
+func deployment(labels map[string]string, items []corev1.KeyToPath) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  Name,
+						Image: config.Flags.HTTPServerImage,
+						Args:  []string{"-addr", fmt.Sprintf(":%d", port), "-root", dataPath},
+						Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: port}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: RoutesPath, Port: intstr.FromInt32(port)},
+							},
+							PeriodSeconds: 2,
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: dataPath, ReadOnly: true}},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsNonRoot:             ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: Name},
+							Items:                items,
+						}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// FileURL returns the URL of the served file in the cluster
+func (s *Server) FileURL(file string) string {
+	return s.URL + "/" + strings.TrimPrefix(file, "/")
+}
+
+// AuthRoute returns a route requiring the basic auth of Username, whose
+// password is in AuthSecret
+func (s *Server) AuthRoute() Route {
+	return Route{Username: Username, Password: s.password}
+}
+
+// SetRoutes sets the routes of the paths of the server, the other paths
+// keep theirs
+func (s *Server) SetRoutes(c *clients.Clients, routes map[string]Route) error {
+	body, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(routes))
+	for p := range routes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	if err := s.request(c, http.MethodPut, body); err != nil {
+		return fmt.Errorf("failed to set the routes of %s: %v", strings.Join(paths, ", "), err)
+	}
+	return nil
+}
+
+// ResetRoutes forgets the routes of the server, which serves its files again
+func (s *Server) ResetRoutes(c *clients.Clients) error {
+	if err := s.request(c, http.MethodDelete, nil); err != nil {
+		return fmt.Errorf("failed to reset the routes: %v", err)
+	}
+	return nil
+}
+
+// Close stops exposing the server, which is deleted with its namespace
+func (s *Server) Close(c *clients.Clients) error {
+	return expose.Current().Unexpose(c, s.svc)
+}
+
+// request sends a request to the routes of the server
+func (s *Server) request(c *clients.Clients, method string, body []byte) error {
+	base, err := expose.Current().Expose(c, s.svc)
+	if err != nil {
+		return fmt.Errorf("failed to expose the http server: %v", err)
+	}
+	req, err := http.NewRequestWithContext(c.Ctx, method, base+RoutesPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: config.CLITimeout}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the http server: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("http server returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
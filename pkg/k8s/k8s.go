@@ -9,12 +9,13 @@ import (
 
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
-	"github.com/openshift-pipelines/release-tests/pkg/oc"
 	"github.com/openshift-pipelines/release-tests/pkg/openshift"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
+	releasewait "github.com/openshift-pipelines/release-tests/pkg/wait"
 	secv1 "github.com/openshift/api/security/v1"
 	secclient "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	"github.com/tektoncd/pipeline/pkg/names"
@@ -39,13 +40,15 @@ func NewClientSet() (*clients.Clients, string, func()) {
 	ns := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("releasetest")
 	cs, err := clients.NewClients(config.Flags.Kubeconfig, config.Flags.Cluster, ns)
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
-	oc.CreateNewProject(ns)
+	if err := platform.Current().CreateNamespace(cs, ns); err != nil {
+		reporter.Default().Fail(err)
+	}
 
 	return cs, ns, func() {
-		oc.DeleteProjectIgnoreErors(ns)
+		platform.Current().DeleteNamespace(cs, ns)
 	}
 }
 
@@ -65,7 +68,7 @@ func WaitForDeploymentDeletion(cs *clients.Clients, namespace, name string) erro
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Errorf("failed to delete deployment %s \n %v", name, err)
+		cs.Reporter().Errorf("failed to delete deployment %s \n %v", name, err)
 	}
 	return err
 }
@@ -85,12 +88,16 @@ func WaitForServiceAccount(cs *clients.Clients, ns, targetSA string) *corev1.Ser
 		return false, err
 	})
 	if err != nil {
-		testsuit.T.Errorf("service account %s not found in namespace %s", targetSA, ns)
+		cs.Reporter().Errorf("service account %s not found in namespace %s", targetSA, ns)
 	}
 	return ret
 }
 
 func ValidateSCCAdded(cs *clients.Clients, ns, sa string) {
+	if !platform.Current().Supports(platform.SecurityContextConstraints) {
+		log.Printf("Skipping validation of the privileged SCC of service account %s as the platform has no SCC", sa)
+		return
+	}
 	err := wait.PollUntilContextTimeout(cs.Ctx, config.APIRetry, config.APITimeout, false, func(context.Context) (bool, error) {
 		privileged, err := GetPrivilegedSCC(cs)
 		if err != nil {
@@ -103,11 +110,15 @@ func ValidateSCCAdded(cs *clients.Clients, ns, sa string) {
 		return inList(privileged.Users, ctrlSA), nil
 	})
 	if err != nil {
-		testsuit.T.Errorf("failed to add privileged SCC to the service account %s \n %v", sa, err)
+		cs.Reporter().Errorf("failed to add privileged SCC to the service account %s \n %v", sa, err)
 	}
 }
 
 func ValidateSCCRemoved(cs *clients.Clients, ns, sa string) {
+	if !platform.Current().Supports(platform.SecurityContextConstraints) {
+		log.Printf("Skipping validation of the privileged SCC of service account %s as the platform has no SCC", sa)
+		return
+	}
 	err := wait.PollUntilContextTimeout(cs.Ctx, config.APIRetry, config.APITimeout, false, func(context.Context) (bool, error) {
 		privileged, err := GetPrivilegedSCC(cs)
 		if err != nil {
@@ -118,7 +129,7 @@ func ValidateSCCRemoved(cs *clients.Clients, ns, sa string) {
 		return !inList(privileged.Users, ctrlSA), nil
 	})
 	if err != nil {
-		testsuit.T.Errorf("failed to remove privileged SCC from service account %s \n %v", sa, err)
+		cs.Reporter().Errorf("failed to remove privileged SCC from service account %s \n %v", sa, err)
 	}
 }
 
@@ -141,7 +152,7 @@ func ValidateDeployments(cs *clients.Clients, ns string, deployments ...string)
 			config.APITimeout,
 		)
 		if err != nil {
-			testsuit.T.Errorf("failed to create deployment %+v \n %v", d, err)
+			cs.Reporter().Errorf("failed to create deployment %+v \n %v", d, err)
 		}
 	}
 }
@@ -156,7 +167,7 @@ func GetPrivilegedSCC(cs *clients.Clients) (*secv1.SecurityContextConstraints, e
 
 func DeleteDeployment(cs *clients.Clients, ns string, deploymentName string) error {
 	kc := cs.KubeClient.Kube
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(cs.Ctx, config.ResourceTimeout)
 	defer cancel()
 
 	err := kc.AppsV1().Deployments(ns).Delete(ctx, deploymentName, metav1.DeleteOptions{})
@@ -172,7 +183,7 @@ func ValidateDeploymentDeletion(cs *clients.Clients, ns string, deployments ...s
 	for _, d := range deployments {
 		err := WaitForDeploymentDeletion(cs, ns, d)
 		if err != nil {
-			testsuit.T.Errorf("failed to delete deployment %+v \n %v", d, err)
+			cs.Reporter().Errorf("failed to delete deployment %+v \n %v", d, err)
 		}
 	}
 }
@@ -206,7 +217,7 @@ func VerifyNoServiceAccount(ctx context.Context, kc *clients.KubeClient, sa, ns
 		}
 		return true, nil
 	}); err != nil {
-		testsuit.T.Errorf("Fail: SA %q exists in namespace %q, err: %s", sa, ns, err)
+		reporter.Default().Errorf("Fail: SA %q exists in namespace %q, err: %s", sa, ns, err)
 	}
 }
 
@@ -220,7 +231,7 @@ func VerifyServiceAccountExists(ctx context.Context, kc *clients.KubeClient, sa,
 		}
 		return true, err
 	}); err != nil {
-		testsuit.T.Errorf("failed to get SA %s in namespace %s for tests: %v", sa, ns, err)
+		reporter.Default().Errorf("failed to get SA %s in namespace %s for tests: %v", sa, ns, err)
 	}
 }
 
@@ -233,7 +244,7 @@ func VerifyNamespaceExists(ctx context.Context, kc *clients.KubeClient, ns strin
 		}
 		return true, err
 	}); err != nil {
-		testsuit.T.Errorf("failed to get namespace %s for tests: %v", ns, err)
+		reporter.Default().Errorf("failed to get namespace %s for tests: %v", ns, err)
 	}
 }
 
@@ -268,7 +279,7 @@ func CreateCronJob(c *clients.Clients, args []string, schedule, namespace string
 	}
 	cj, err := c.KubeClient.Kube.BatchV1().CronJobs(namespace).Create(c.Ctx, cronjob, metav1.CreateOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to create cron job %s \n %v", cj.Name, err)
+		c.Reporter().Errorf("failed to create cron job %s \n %v", cj.Name, err)
 	}
 	log.Printf("Cronjob: %s created in namespace: %s", cj.Name, namespace)
 	store.PutScenarioData("cronjob", cj.Name)
@@ -322,10 +333,10 @@ func GetGroupVersionResource(gr schema.GroupVersionResource, discovery discovery
 func AssertIfDefaultCronjobExists(c *clients.Clients, namespace string) {
 	cronJobs, err := c.KubeClient.Kube.BatchV1().CronJobs(namespace).List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get default cron job in namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to get default cron job in namespace %s \n %v", namespace, err)
 	}
 	if len(cronJobs.Items) == 0 {
-		testsuit.T.Errorf("No cronjobs present in the namespace %v", namespace)
+		c.Reporter().Errorf("No cronjobs present in the namespace %v", namespace)
 	}
 	present := false
 	for _, cj := range cronJobs.Items {
@@ -338,7 +349,7 @@ func AssertIfDefaultCronjobExists(c *clients.Clients, namespace string) {
 		}
 	}
 	if !present {
-		testsuit.T.Errorf("No cronjobs with schedule %v and with prefix %v is not present", config.PrunerSchedule, config.PrunerNamePrefix)
+		c.Reporter().Errorf("No cronjobs with schedule %v and with prefix %v is not present", config.PrunerSchedule, config.PrunerNamePrefix)
 	}
 }
 
@@ -346,10 +357,10 @@ func GetCronjobNameWithSchedule(c *clients.Clients, namespace, schedule string)
 	name := ""
 	cronJobs, err := c.KubeClient.Kube.BatchV1().CronJobs(namespace).List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get cron job from namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to get cron job from namespace %s \n %v", namespace, err)
 	}
 	if len(cronJobs.Items) == 0 {
-		testsuit.T.Errorf("No cronjobs present in the namespace %v", namespace)
+		c.Reporter().Errorf("No cronjobs present in the namespace %v", namespace)
 	}
 	for _, cj := range cronJobs.Items {
 		if cj.Spec.Schedule == schedule {
@@ -365,7 +376,7 @@ func AssertPrunerCronjobWithContainer(c *clients.Clients, namespace, num string)
 	log.Printf("Verifying if the cronjob with prefix tekton-resource-pruner in namespace %v contains %v number of containers", namespace, num)
 	cronJobs, err := c.KubeClient.Kube.BatchV1().CronJobs(namespace).List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("Error while getting cronjobs %v", err)
+		c.Reporter().Errorf("Error while getting cronjobs %v", err)
 	}
 	jobFound := false
 	for _, cr := range cronJobs.Items {
@@ -374,14 +385,14 @@ func AssertPrunerCronjobWithContainer(c *clients.Clients, namespace, num string)
 			containers := cr.Spec.JobTemplate.Spec.Template.Spec.Containers
 			numInt, _ := strconv.Atoi(num)
 			if len(containers) != numInt {
-				testsuit.T.Errorf("Expected: %v containers in cronjob spec, Actual: %v containers in cronjob spec", numInt, len(containers))
+				c.Reporter().Errorf("Expected: %v containers in cronjob spec, Actual: %v containers in cronjob spec", numInt, len(containers))
 			}
 			log.Printf("%v containers found in the cronjob spec", numInt)
 			break
 		}
 	}
 	if !jobFound {
-		testsuit.T.Errorf("Cronjob with prefix tekton-resource-pruner not found in %v namespace", namespace)
+		c.Reporter().Errorf("Cronjob with prefix tekton-resource-pruner not found in %v namespace", namespace)
 	}
 }
 
@@ -400,7 +411,7 @@ func AssertCronjobPresent(c *clients.Clients, cronJobName, namespace string) {
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: cronjob with prefix %v present in namespace %v, Actual: cronjob with prefix %v not present in namespace %v", cronJobName, namespace, cronJobName, namespace))
+		c.Reporter().Fail(fmt.Errorf("expected: cronjob with prefix %v present in namespace %v, Actual: cronjob with prefix %v not present in namespace %v", cronJobName, namespace, cronJobName, namespace))
 	}
 	log.Printf("Cronjob with prefix %v is present in namespace %v", cronJobName, namespace)
 }
@@ -420,7 +431,7 @@ func AssertCronjobNotPresent(c *clients.Clients, cronJobName, namespace string)
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: cronjob with prefix %v present in namespace %v, Actual: cronjob with prefix %v not present in namespace %v", cronJobName, namespace, cronJobName, namespace))
+		c.Reporter().Fail(fmt.Errorf("expected: cronjob with prefix %v present in namespace %v, Actual: cronjob with prefix %v not present in namespace %v", cronJobName, namespace, cronJobName, namespace))
 	}
 	log.Printf("Cronjob with prefix %v is present in namespace %v", cronJobName, namespace)
 }
@@ -429,7 +440,7 @@ func ValidateTektonInstallersetStatus(c *clients.Clients) {
 	tis, err := c.Operator.TektonInstallerSets().List(c.Ctx, metav1.ListOptions{})
 	failedInstallersets := make([]string, 0)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("error getting tektoninstallersets: %v", err))
+		c.Reporter().Fail(fmt.Errorf("error getting tektoninstallersets: %v", err))
 	}
 
 	for _, is := range tis.Items {
@@ -440,7 +451,7 @@ func ValidateTektonInstallersetStatus(c *clients.Clients) {
 	}
 
 	if len(failedInstallersets) > 0 {
-		testsuit.T.Fail(fmt.Errorf("the installersets %s is/are not in ready status", strings.Join(failedInstallersets, ",")))
+		c.Reporter().Fail(fmt.Errorf("the installersets %s is/are not in ready status", strings.Join(failedInstallersets, ",")))
 	}
 	log.Print("All the installersets are in ready state")
 }
@@ -448,7 +459,7 @@ func ValidateTektonInstallersetStatus(c *clients.Clients) {
 func ValidateTektonInstallersetNames(c *clients.Clients) {
 	tis, err := c.Operator.TektonInstallerSets().List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("error getting tektoninstallersets: %v", err))
+		c.Reporter().Fail(fmt.Errorf("error getting tektoninstallersets: %v", err))
 	}
 	missingInstallersets := make([]string, 0)
 	for _, isp := range config.TektonInstallersetNamePrefixes {
@@ -479,7 +490,7 @@ func ValidateTektonInstallersetNames(c *clients.Clients) {
 	}
 
 	if len(missingInstallersets) > 0 {
-		testsuit.T.Fail(fmt.Errorf("installersets with prefix %s is not found", strings.Join(missingInstallersets, ",")))
+		c.Reporter().Fail(fmt.Errorf("installersets with prefix %s is not found", strings.Join(missingInstallersets, ",")))
 	}
 }
 
@@ -495,3 +506,60 @@ func GetWarningEvents(c *clients.Clients, namespace string) (string, error) {
 	}
 	return strings.Join(eventSlice, "\n"), nil
 }
+
+// ScaleDeployment sets the replicas of the deployment through its scale
+// subresource, like kubectl scale
+func ScaleDeployment(c *clients.Clients, deployment, namespace string, replicas int32) {
+	deployments := c.KubeClient.Kube.AppsV1().Deployments(namespace)
+	scale, err := deployments.GetScale(c.Ctx, deployment, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get the scale of deployment %s in namespace %s: %v", deployment, namespace, err))
+		return
+	}
+	scale.Spec.Replicas = replicas
+	if _, err := deployments.UpdateScale(c.Ctx, deployment, scale, metav1.UpdateOptions{}); err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to scale deployment %s in namespace %s to %d replicas: %v", deployment, namespace, replicas, err))
+		return
+	}
+	log.Printf("Deployment %s in namespace %s scaled to %d replicas", deployment, namespace, replicas)
+}
+
+// AssertPodsOfDeploymentRunning waits for the deployment to have exactly
+// replicas pods, all of them running
+func AssertPodsOfDeploymentRunning(c *clients.Clients, deployment, namespace string, replicas int) {
+	dep, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Get(c.Ctx, deployment, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get deployment %s in namespace %s: %v", deployment, namespace, err))
+		return
+	}
+	selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("invalid selector of deployment %s: %v", deployment, err))
+		return
+	}
+	running := releasewait.WaitForPodsWithLabels(c, namespace, selector.String())
+	err = releasewait.WaitFor(c.Ctx, func() (bool, error) {
+		if done, err := running(); !done || err != nil {
+			return done, err
+		}
+		pods, err := c.KubeClient.Kube.CoreV1().Pods(namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return false, err
+		}
+		// terminating pods of a scale down are still running
+		count := 0
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp == nil {
+				count++
+			}
+		}
+		if count != replicas {
+			log.Printf("Waiting for %d pods of deployment %s, found %d", replicas, deployment, count)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("expected %d running pods of deployment %s in namespace %s: %v", replicas, deployment, namespace, err))
+	}
+}
@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateTektonInstallersetNames(t *testing.T) {
+	defer platform.Set(platform.Set(platform.OpenShift()))
+
+	// installerSets returns an installer set per prefix, with a generated
+	// suffix, except for the skipped prefixes
+	installerSets := func(skipped ...string) []runtime.Object {
+		var objects []runtime.Object
+		for _, prefix := range config.TektonInstallersetNamePrefixes {
+			if prefix == "" || slices.Contains(skipped, prefix) {
+				continue
+			}
+			objects = append(objects, &operatorv1alpha1.TektonInstallerSet{ObjectMeta: metav1.ObjectMeta{Name: prefix + "-x7k2p"}})
+		}
+		return objects
+	}
+	clusterVersion := func(capabilities ...configv1.ClusterVersionCapability) runtime.Object {
+		cv := &configv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}}
+		cv.Status.Capabilities.EnabledCapabilities = capabilities
+		return cv
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		wantErr string
+	}{{
+		name:    "all installer sets",
+		objects: append(installerSets(), clusterVersion(configv1.ClusterVersionCapabilityConsole)),
+	}, {
+		name:    "missing installer sets",
+		objects: append(installerSets("chain-config", "result-pre"), clusterVersion(configv1.ClusterVersionCapabilityConsole)),
+		wantErr: "installersets with prefix chain-config,result-pre is not found",
+	}, {
+		name:    "console installer sets skipped without the console",
+		objects: append(installerSets("addon-custom-consolecli", "addon-custom-openshiftconsole"), clusterVersion()),
+	}, {
+		name:    "console installer sets required with the console",
+		objects: append(installerSets("addon-custom-consolecli"), clusterVersion(configv1.ClusterVersionCapabilityConsole)),
+		wantErr: "installersets with prefix addon-custom-consolecli is not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tt.objects...)
+			err := testutil.CaptureFailures(func() {
+				ValidateTektonInstallersetNames(c)
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("expected failure %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+// Package lease serializes mutations of cluster-scoped resources (e.g.
+// TektonConfig) between scenarios running in parallel. Gauge runs every
+// parallel stream in its own process, so the lock is a coordination.k8s.io
+// Lease held by the process rather than an in-memory mutex. Scenarios not
+// mutating the cluster hold it shared, so they never observe a mutation in
+// progress.
+package lease
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ClusterLeaseName is the name of the Lease serializing the mutations of
+	// cluster-scoped resources, created in config.TargetNamespace()
+	ClusterLeaseName = "release-tests-cluster"
+
+	// Duration is the time after which a Lease which was not renewed (e.g.
+	// its holder was killed) can be taken over by another process
+	Duration = 2 * time.Minute
+
+	// ReaderLabel is the label of the Leases of the processes holding the
+	// cluster shared, named after ClusterLeaseName and their holder
+	ReaderLabel = "release-tests.openshift-pipelines.org/cluster-reader"
+)
+
+// Lease is a coordination.k8s.io Lease acquired on behalf of this process
+type Lease struct {
+	c         *clients.Clients
+	namespace string
+	name      string
+	holder    string
+	labels    map[string]string
+	// ephemeral Leases are deleted when released
+	ephemeral bool
+
+	mu    sync.Mutex
+	depth int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// New returns the Lease name in namespace, held as Holder()
+func New(c *clients.Clients, namespace, name string) *Lease {
+	return &Lease{c: c, namespace: namespace, name: name, holder: Holder()}
+}
+
+// Holder identifies this process, i.e. the Gauge stream, as a Lease holder
+func Holder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "release-tests"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Acquire blocks until the Lease is held by this process or config.APITimeout
+// expires. It is reentrant: the Lease is released by the last matching Release.
+// The Lease is renewed in the background while it is held.
+func (l *Lease) Acquire(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.depth > 0 {
+		l.depth++
+		return nil
+	}
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, config.APITimeout, true, l.tryAcquire)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lease %s in namespace %s: %v", l.name, l.namespace, err)
+	}
+	log.Printf("Acquired lease %s as %s", l.name, l.holder)
+	l.depth = 1
+	l.stop, l.done = make(chan struct{}), make(chan struct{})
+	go l.renew(l.stop, l.done)
+	return nil
+}
+
+// Release undoes an Acquire, giving the Lease up after the last one
+func (l *Lease) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.depth == 0 {
+		return
+	}
+	l.depth--
+	if l.depth > 0 {
+		return
+	}
+	close(l.stop)
+	<-l.done
+	if l.ephemeral {
+		err := l.c.KubeClient.Kube.CoordinationV1().Leases(l.namespace).Delete(context.Background(), l.name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			log.Printf("Warning: could not delete lease %s, it expires in %s: %v", l.name, Duration, err)
+			return
+		}
+		log.Printf("Released lease %s", l.name)
+		return
+	}
+	if err := l.update(context.Background(), func(spec *coordinationv1.LeaseSpec) {
+		spec.HolderIdentity = nil
+	}); err != nil {
+		log.Printf("Warning: could not release lease %s, it expires in %s: %v", l.name, Duration, err)
+		return
+	}
+	log.Printf("Released lease %s", l.name)
+}
+
+func (l *Lease) tryAcquire(ctx context.Context) (bool, error) {
+	leases := l.c.KubeClient.Kube.CoordinationV1().Leases(l.namespace)
+	now := time.Now()
+	lease, err := leases.Get(ctx, l.name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: l.name, Namespace: l.namespace, Labels: l.labels}}
+		l.hold(&lease.Spec, now)
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+	if l.heldByOther(lease.Spec, now) {
+		log.Printf("Waiting for lease %s held by %s", l.name, *lease.Spec.HolderIdentity)
+		return false, nil
+	}
+	l.hold(&lease.Spec, now)
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if errors.IsConflict(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// renew keeps the Lease from expiring until stop is closed
+func (l *Lease) renew(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(Duration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := l.update(context.Background(), func(spec *coordinationv1.LeaseSpec) {
+				renewTime := metav1.NewMicroTime(time.Now())
+				spec.RenewTime = &renewTime
+			})
+			if err != nil {
+				log.Printf("Warning: could not renew lease %s: %v", l.name, err)
+			}
+		}
+	}
+}
+
+// update applies mutate to the Lease, retrying on conflicts
+func (l *Lease) update(ctx context.Context, mutate func(*coordinationv1.LeaseSpec)) error {
+	leases := l.c.KubeClient.Kube.CoordinationV1().Leases(l.namespace)
+	for {
+		lease, err := leases.Get(ctx, l.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if holder := lease.Spec.HolderIdentity; holder == nil || *holder != l.holder {
+			return fmt.Errorf("lease %s was taken over", l.name)
+		}
+		mutate(&lease.Spec)
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+		if !errors.IsConflict(err) {
+			return err
+		}
+	}
+}
+
+func (l *Lease) hold(spec *coordinationv1.LeaseSpec, now time.Time) {
+	holder := l.holder
+	seconds := int32(Duration.Seconds())
+	acquireTime := metav1.NewMicroTime(now)
+	renewTime := metav1.NewMicroTime(now)
+	spec.HolderIdentity = &holder
+	spec.LeaseDurationSeconds = &seconds
+	spec.AcquireTime = &acquireTime
+	spec.RenewTime = &renewTime
+}
+
+// heldByOther tells whether another process holds the Lease of spec
+func (l *Lease) heldByOther(spec coordinationv1.LeaseSpec, now time.Time) bool {
+	holder := spec.HolderIdentity
+	return holder != nil && *holder != "" && *holder != l.holder && !expired(spec, now)
+}
+
+func expired(spec coordinationv1.LeaseSpec, now time.Time) bool {
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second).Before(now)
+}
+
+// ClusterLock is a readers-writer lock of the cluster-scoped resources: the
+// scenarios mutating them hold it exclusively, through the ClusterLeaseName
+// Lease, the other ones shared, through a Lease of their process labelled
+// ReaderLabel. The exclusive holder waits for the shared ones to release
+// theirs, while new shared holders wait for it.
+type ClusterLock struct {
+	writer *Lease
+	reader *Lease
+}
+
+// NewClusterLock returns the cluster lock in namespace held as holder
+func NewClusterLock(c *clients.Clients, namespace, holder string) *ClusterLock {
+	return &ClusterLock{
+		writer: &Lease{c: c, namespace: namespace, name: ClusterLeaseName, holder: holder},
+		reader: &Lease{
+			c:         c,
+			namespace: namespace,
+			name:      readerLeaseName(holder),
+			holder:    holder,
+			labels:    map[string]string{ReaderLabel: "true"},
+			ephemeral: true,
+		},
+	}
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// readerLeaseName returns the name of the Lease of holder holding the cluster
+// shared
+func readerLeaseName(holder string) string {
+	name := ClusterLeaseName + "-reader-" + invalidNameChars.ReplaceAllString(strings.ToLower(holder), "-")
+	return strings.TrimRight(name[:min(len(name), 63)], "-")
+}
+
+// Lock blocks until the cluster is held exclusively by this process, then
+// returns the function releasing it. A shared hold of this process is given
+// up while it waits, two processes upgrading their shared hold at the same
+// time would wait for each other otherwise, and taken again once released.
+func (cl *ClusterLock) Lock(ctx context.Context) (func(), error) {
+	resume := func() {}
+	if cl.reader.held() {
+		cl.reader.Release()
+		resume = func() {
+			if _, err := cl.RLock(context.Background()); err != nil {
+				log.Printf("Warning: could not hold the cluster shared again: %v", err)
+			}
+		}
+	}
+	if err := cl.writer.Acquire(ctx); err != nil {
+		resume()
+		return nil, err
+	}
+	if err := cl.waitForReaders(ctx); err != nil {
+		cl.writer.Release()
+		resume()
+		return nil, err
+	}
+	return func() {
+		cl.writer.Release()
+		resume()
+	}, nil
+}
+
+// RLock blocks until the cluster is held shared by this process, i.e. no
+// other process holds it exclusively, then returns the function releasing it
+func (cl *ClusterLock) RLock(ctx context.Context) (func(), error) {
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, config.APITimeout, true, func(ctx context.Context) (bool, error) {
+		if held, err := cl.writerHeldByOther(ctx); held || err != nil {
+			return false, err
+		}
+		if err := cl.reader.Acquire(ctx); err != nil {
+			return false, err
+		}
+		// a process may have taken the cluster exclusively meanwhile, it
+		// waits for this one to release its shared hold
+		if held, err := cl.writerHeldByOther(ctx); held || err != nil {
+			cl.reader.Release()
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold the cluster shared with lease %s in namespace %s: %v", cl.reader.name, cl.reader.namespace, err)
+	}
+	return cl.reader.Release, nil
+}
+
+func (cl *ClusterLock) writerHeldByOther(ctx context.Context) (bool, error) {
+	lease, err := cl.writer.c.KubeClient.Kube.CoordinationV1().Leases(cl.writer.namespace).Get(ctx, cl.writer.name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if cl.writer.heldByOther(lease.Spec, time.Now()) {
+		log.Printf("Waiting for lease %s held by %s", cl.writer.name, *lease.Spec.HolderIdentity)
+		return true, nil
+	}
+	return false, nil
+}
+
+// waitForReaders waits until no other process holds the cluster shared
+func (cl *ClusterLock) waitForReaders(ctx context.Context) error {
+	leases := cl.reader.c.KubeClient.Kube.CoordinationV1().Leases(cl.reader.namespace)
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, config.APITimeout, true, func(ctx context.Context) (bool, error) {
+		list, err := leases.List(ctx, metav1.ListOptions{LabelSelector: ReaderLabel})
+		if err != nil {
+			return false, err
+		}
+		now := time.Now()
+		var readers []string
+		for _, lease := range list.Items {
+			if cl.reader.heldByOther(lease.Spec, now) {
+				readers = append(readers, *lease.Spec.HolderIdentity)
+			}
+		}
+		if len(readers) > 0 {
+			log.Printf("Waiting for the scenarios of %s holding the cluster shared", strings.Join(readers, ", "))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the scenarios holding the cluster shared: %v", err)
+	}
+	return nil
+}
+
+// held tells whether this process holds the Lease
+func (l *Lease) held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.depth > 0
+}
+
+var (
+	clusterOnce sync.Once
+	clusterLock *ClusterLock
+	clusterErr  error
+)
+
+// Cluster returns the lock of the cluster-scoped resources held by this process
+func Cluster() (*ClusterLock, error) {
+	clusterOnce.Do(func() {
+		cs, err := clients.NewClients(config.Flags.Kubeconfig, config.Flags.Cluster, config.TargetNamespace())
+		if err != nil {
+			clusterErr = fmt.Errorf("failed to create clients for the cluster lease: %v", err)
+			return
+		}
+		clusterLock = NewClusterLock(cs, config.TargetNamespace(), Holder())
+	})
+	return clusterLock, clusterErr
+}
+
+// LockCluster holds the cluster exclusively and returns the function
+// releasing it, e.g. `defer lease.LockCluster()()` around a TektonConfig update
+func LockCluster() func() {
+	return lockCluster((*ClusterLock).Lock)
+}
+
+// LockClusterShared holds the cluster shared and returns the function
+// releasing it, for scenarios which must not run while another one mutates
+// the cluster
+func LockClusterShared() func() {
+	return lockCluster((*ClusterLock).RLock)
+}
+
+func lockCluster(lock func(*ClusterLock, context.Context) (func(), error)) func() {
+	cl, err := Cluster()
+	var release func()
+	if err == nil {
+		release, err = lock(cl, context.Background())
+	}
+	if err != nil {
+		reporter.Default().Fail(err)
+		return func() {}
+	}
+	return release
+}
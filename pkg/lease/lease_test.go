@@ -0,0 +1,202 @@
+package lease
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const namespace = "openshift-pipelines"
+
+// shortTimeouts makes the locks give up quickly when the cluster is held by
+// another process
+func shortTimeouts(t *testing.T) {
+	t.Helper()
+	timeouts := config.CurrentTimeouts()
+	t.Cleanup(func() { config.SetTimeouts(timeouts) })
+	short := timeouts
+	short.APIRetry = 10 * time.Millisecond
+	short.APITimeout = 100 * time.Millisecond
+	config.SetTimeouts(short)
+}
+
+func leaseHolder(t *testing.T, fcs *testutil.FakeClientsets, name string) string {
+	t.Helper()
+	lease, err := fcs.Kube.CoordinationV1().Leases(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease %s: %v", name, err)
+	}
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func readerLeases(t *testing.T, fcs *testutil.FakeClientsets) []string {
+	t.Helper()
+	list, err := fcs.Kube.CoordinationV1().Leases(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: ReaderLabel})
+	if err != nil {
+		t.Fatalf("failed to list the reader leases: %v", err)
+	}
+	var names []string
+	for _, lease := range list.Items {
+		names = append(names, lease.Name)
+	}
+	return names
+}
+
+func TestReaderLeaseName(t *testing.T) {
+	tests := []struct {
+		name   string
+		holder string
+		want   string
+	}{
+		{name: "hostname and pid", holder: "runner-1234", want: "release-tests-cluster-reader-runner-1234"},
+		{name: "invalid characters", holder: "Runner_A.local-42", want: "release-tests-cluster-reader-runner-a-local-42"},
+		{name: "truncated", holder: strings.Repeat("a", 60), want: "release-tests-cluster-reader-" + strings.Repeat("a", 34)},
+		{name: "no trailing dash", holder: strings.Repeat("a", 33) + "_b", want: "release-tests-cluster-reader-" + strings.Repeat("a", 33)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readerLeaseName(tt.holder); got != tt.want {
+				t.Errorf("readerLeaseName(%q) = %q, want %q", tt.holder, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterLockShared(t *testing.T) {
+	shortTimeouts(t)
+	cs, fcs := testutil.NewFakeClients(namespace)
+	a := NewClusterLock(cs, namespace, "stream-a")
+	b := NewClusterLock(cs, namespace, "stream-b")
+
+	releaseA, err := a.RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() of stream-a failed: %v", err)
+	}
+	releaseB, err := b.RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() of stream-b failed while stream-a holds the cluster shared: %v", err)
+	}
+	if got := readerLeases(t, fcs); len(got) != 2 {
+		t.Errorf("reader leases = %v, want the ones of stream-a and stream-b", got)
+	}
+
+	releaseA()
+	releaseB()
+	if got := readerLeases(t, fcs); len(got) != 0 {
+		t.Errorf("reader leases = %v after release, want none", got)
+	}
+}
+
+func TestClusterLockExclusiveWaitsForReaders(t *testing.T) {
+	shortTimeouts(t)
+	cs, fcs := testutil.NewFakeClients(namespace)
+	reader := NewClusterLock(cs, namespace, "stream-a")
+	writer := NewClusterLock(cs, namespace, "stream-b")
+
+	release, err := reader.RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() failed: %v", err)
+	}
+	if _, err := writer.Lock(context.Background()); err == nil {
+		t.Fatal("Lock() succeeded while stream-a holds the cluster shared")
+	}
+	if got := leaseHolder(t, fcs, ClusterLeaseName); got != "" {
+		t.Errorf("writer lease held by %q after Lock() gave up, want released", got)
+	}
+
+	release()
+	unlock, err := writer.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() failed once the cluster is not held shared: %v", err)
+	}
+	if got := leaseHolder(t, fcs, ClusterLeaseName); got != "stream-b" {
+		t.Errorf("writer lease held by %q, want stream-b", got)
+	}
+	unlock()
+}
+
+func TestClusterLockSharedWaitsForWriter(t *testing.T) {
+	shortTimeouts(t)
+	cs, fcs := testutil.NewFakeClients(namespace)
+	writer := NewClusterLock(cs, namespace, "stream-a")
+	reader := NewClusterLock(cs, namespace, "stream-b")
+
+	unlock, err := writer.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() failed: %v", err)
+	}
+	if _, err := reader.RLock(context.Background()); err == nil {
+		t.Fatal("RLock() succeeded while stream-a holds the cluster exclusively")
+	}
+	if got := readerLeases(t, fcs); len(got) != 0 {
+		t.Errorf("reader leases = %v while the cluster is held exclusively, want none", got)
+	}
+
+	unlock()
+	release, err := reader.RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() failed once the cluster is released: %v", err)
+	}
+	release()
+}
+
+func TestClusterLockExpiredWriter(t *testing.T) {
+	shortTimeouts(t)
+	holder := "killed-stream"
+	duration := int32(Duration.Seconds())
+	renewed := metav1.NewMicroTime(time.Now().Add(-2 * Duration))
+	stale := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterLeaseName, Namespace: namespace},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewed,
+		},
+	}
+	cs, _ := testutil.NewFakeClients(namespace, stale)
+
+	release, err := NewClusterLock(cs, namespace, "stream-a").RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() failed while the writer lease expired: %v", err)
+	}
+	release()
+}
+
+func TestClusterLockUpgrade(t *testing.T) {
+	shortTimeouts(t)
+	cs, fcs := testutil.NewFakeClients(namespace)
+	cl := NewClusterLock(cs, namespace, "stream-a")
+
+	release, err := cl.RLock(context.Background())
+	if err != nil {
+		t.Fatalf("RLock() failed: %v", err)
+	}
+	unlock, err := cl.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() failed while this stream holds the cluster shared: %v", err)
+	}
+	if got := readerLeases(t, fcs); len(got) != 0 {
+		t.Errorf("reader leases = %v while held exclusively, want the shared hold given up", got)
+	}
+
+	unlock()
+	if got := leaseHolder(t, fcs, ClusterLeaseName); got != "" {
+		t.Errorf("writer lease held by %q after unlock, want released", got)
+	}
+	if got := readerLeases(t, fcs); len(got) != 1 {
+		t.Errorf("reader leases = %v after unlock, want the shared hold taken again", got)
+	}
+	release()
+	if got := readerLeases(t, fcs); len(got) != 0 {
+		t.Errorf("reader leases = %v after release, want none", got)
+	}
+}
@@ -28,11 +28,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	atv1alpha1 "github.com/openshift-pipelines/manual-approval-gate/pkg/apis/approvaltask/v1alpha1"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
 	mag "github.com/tektoncd/operator/pkg/client/clientset/versioned/typed/operator/v1alpha1"
@@ -182,7 +182,7 @@ func userPassword(user string) string {
 // This uses the current kubeconfig (admin context) and is intentionally cluster-scoped.
 func EnsureGroupMembers(group string, users []string) {
 	if group == "" {
-		testsuit.T.Fail(fmt.Errorf("group name is empty"))
+		reporter.Default().Fail(fmt.Errorf("group name is empty"))
 	}
 
 	// Capture old membership so we can mark removed users dirty too.
@@ -198,7 +198,7 @@ func EnsureGroupMembers(group string, users []string) {
 
 	usersJSON, err := json.Marshal(users)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to marshal group users: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to marshal group users: %v", err))
 	}
 	patch := fmt.Sprintf("{\"users\":%s}", string(usersJSON))
 
@@ -208,7 +208,7 @@ func EnsureGroupMembers(group string, users []string) {
 	if createRes.ExitCode != 0 {
 		stderr := strings.ToLower(createRes.Stderr())
 		if !strings.Contains(stderr, "already exists") && !strings.Contains(stderr, "alreadyexists") {
-			testsuit.T.Fail(fmt.Errorf("failed to create group %s: %s", group, createRes.Stderr()))
+			reporter.Default().Fail(fmt.Errorf("failed to create group %s: %s", group, createRes.Stderr()))
 		}
 	}
 
@@ -223,7 +223,7 @@ func EnsureGroupMembers(group string, users []string) {
 	sort.Strings(expected)
 	sort.Strings(actual)
 	if strings.Join(expected, ",") != strings.Join(actual, ",") {
-		testsuit.T.Fail(fmt.Errorf("group %s membership mismatch: expected [%s], got [%s]", group, strings.Join(expected, " "), strings.Join(actual, " ")))
+		reporter.Default().Fail(fmt.Errorf("group %s membership mismatch: expected [%s], got [%s]", group, strings.Join(expected, " "), strings.Join(actual, " ")))
 	}
 
 	// OpenShift group membership is reflected in user tokens; refresh user auth after membership changes.
@@ -239,10 +239,10 @@ func EnsureGroupMembers(group string, users []string) {
 
 func CreateApprovalPipelineRun(id, description string, approvers []string, required int, timeout, namespace string) (string, string) {
 	if len(approvers) == 0 {
-		testsuit.T.Fail(fmt.Errorf("approvers list is empty"))
+		reporter.Default().Fail(fmt.Errorf("approvers list is empty"))
 	}
 	if required <= 0 {
-		testsuit.T.Fail(fmt.Errorf("numberOfApprovalsRequired must be > 0; got %d", required))
+		reporter.Default().Fail(fmt.Errorf("numberOfApprovalsRequired must be > 0; got %d", required))
 	}
 
 	approverLines := strings.Builder{}
@@ -257,7 +257,7 @@ func CreateApprovalPipelineRun(id, description string, approvers []string, requi
 	}
 	approversRaw := strings.TrimSuffix(approverLines.String(), "\n")
 	if strings.TrimSpace(approversRaw) == "" {
-		testsuit.T.Fail(fmt.Errorf("approvers list is empty after trimming"))
+		reporter.Default().Fail(fmt.Errorf("approvers list is empty after trimming"))
 	}
 	// Indent subsequent list items (the first line is indented by the YAML template itself).
 	approversYAML := strings.ReplaceAll(approversRaw, "\n", "\n              ")
@@ -291,7 +291,7 @@ spec:
 
 	prName := strings.TrimSpace(cmd.MustSucceedWithStdin(strings.NewReader(prYAML), "oc", "create", "-n", namespace, "-f", "-", "-o", "jsonpath={.metadata.name}").Stdout())
 	if prName == "" {
-		testsuit.T.Fail(fmt.Errorf("failed to create PipelineRun: got empty name"))
+		reporter.Default().Fail(fmt.Errorf("failed to create PipelineRun: got empty name"))
 	}
 
 	taskName := WaitForSingleApprovalTaskName(prName, namespace, 2*time.Minute)
@@ -302,14 +302,14 @@ spec:
 func WaitForSingleApprovalTaskName(prName, namespace string, timeout time.Duration) string {
 	cs := store.Clients()
 	if cs == nil {
-		testsuit.T.Fail(fmt.Errorf("clients not initialized"))
+		reporter.Default().Fail(fmt.Errorf("clients not initialized"))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	var picked *atv1alpha1.ApprovalTask
-	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, timeout, true, func(ctx context.Context) (bool, error) {
 		list, err := cs.ApprovalTask.List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return false, err
@@ -326,7 +326,7 @@ func WaitForSingleApprovalTaskName(prName, namespace string, timeout time.Durati
 		return true, nil
 	})
 	if err != nil || picked == nil {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting for ApprovalTask for pipelinerun %s in namespace %s: %v", prName, namespace, err))
+		reporter.Default().Fail(fmt.Errorf("timed out waiting for ApprovalTask for pipelinerun %s in namespace %s: %v", prName, namespace, err))
 	}
 	return picked.Name
 }
@@ -338,7 +338,7 @@ func WaitForApprovalTaskState(task, expectedState string, timeout time.Duration)
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, timeout, true, func(ctx context.Context) (bool, error) {
 		at, err := cs.ApprovalTask.Get(ctx, task, metav1.GetOptions{})
 		if err != nil {
 			return false, err
@@ -346,7 +346,7 @@ func WaitForApprovalTaskState(task, expectedState string, timeout time.Duration)
 		return strings.ToLower(at.Status.State) == exp, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting for ApprovalTask %s state=%s: %v", task, expectedState, err))
+		reporter.Default().Fail(fmt.Errorf("timed out waiting for ApprovalTask %s state=%s: %v", task, expectedState, err))
 	}
 }
 
@@ -380,13 +380,13 @@ func approvalTaskMessageContains(at *atv1alpha1.ApprovalTask, text string) bool
 func WaitForApprovalTaskMessageContains(task, text string, timeout time.Duration) {
 	cs := store.Clients()
 	if cs == nil {
-		testsuit.T.Fail(fmt.Errorf("clients not initialized"))
+		reporter.Default().Fail(fmt.Errorf("clients not initialized"))
 		return
 	}
 
 	text = strings.TrimSpace(text)
 	if text == "" {
-		testsuit.T.Fail(fmt.Errorf("message text is empty"))
+		reporter.Default().Fail(fmt.Errorf("message text is empty"))
 		return
 	}
 
@@ -394,7 +394,7 @@ func WaitForApprovalTaskMessageContains(task, text string, timeout time.Duration
 	defer cancel()
 
 	var last *atv1alpha1.ApprovalTask
-	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, timeout, true, func(ctx context.Context) (bool, error) {
 		at, err := cs.ApprovalTask.Get(ctx, task, metav1.GetOptions{})
 		if err != nil {
 			return false, err
@@ -404,10 +404,10 @@ func WaitForApprovalTaskMessageContains(task, text string, timeout time.Duration
 	})
 	if err != nil {
 		if last == nil {
-			testsuit.T.Fail(fmt.Errorf("timed out waiting for ApprovalTask %s to contain message %q: %v", task, text, err))
+			reporter.Default().Fail(fmt.Errorf("timed out waiting for ApprovalTask %s to contain message %q: %v", task, text, err))
 			return
 		}
-		testsuit.T.Fail(fmt.Errorf("timed out waiting for ApprovalTask %s to contain message %q; last status=%q", task, text, last.Status.State))
+		reporter.Default().Fail(fmt.Errorf("timed out waiting for ApprovalTask %s to contain message %q; last status=%q", task, text, last.Status.State))
 	}
 }
 
@@ -419,7 +419,7 @@ func WaitForAndAssertApprovalTaskListState(task string, expectedNum, expectedPen
 	defer cancel()
 
 	var last *atv1alpha1.ApprovalTask
-	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+	err := wait.PollUntilContextTimeout(ctx, config.APIRetry, timeout, true, func(ctx context.Context) (bool, error) {
 		at, err := cs.ApprovalTask.Get(ctx, task, metav1.GetOptions{})
 		if err != nil {
 			return false, err
@@ -435,9 +435,9 @@ func WaitForAndAssertApprovalTaskListState(task string, expectedNum, expectedPen
 	})
 	if err != nil {
 		if last == nil {
-			testsuit.T.Fail(fmt.Errorf("failed to read ApprovalTask %s for state assertion: %v", task, err))
+			reporter.Default().Fail(fmt.Errorf("failed to read ApprovalTask %s for state assertion: %v", task, err))
 		}
-		testsuit.T.Fail(fmt.Errorf("approvaltask %s list-state mismatch: expected num=%d pending=%d rejected=%d status=%s; got num=%d pending=%d rejected=%d status=%s",
+		reporter.Default().Fail(fmt.Errorf("approvaltask %s list-state mismatch: expected num=%d pending=%d rejected=%d status=%s; got num=%d pending=%d rejected=%d status=%s",
 			task,
 			expectedNum, expectedPending, expectedRejected, expStatus,
 			last.Spec.NumberOfApprovalsRequired, pendingApprovals(last), rejectedCount(last), stateHuman(last),
@@ -562,7 +562,7 @@ func ensureMAGAPIServer() string {
 		magAPIServer = api
 	})
 	if magAPIServerErr != nil {
-		testsuit.T.Fail(magAPIServerErr)
+		reporter.Default().Fail(magAPIServerErr)
 		return ""
 	}
 	return magAPIServer
@@ -587,7 +587,7 @@ func ensureUserKubeconfig(user string) string {
 
 	tmp, err := os.CreateTemp("", fmt.Sprintf("mag-kubeconfig-%s-", user))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create temp kubeconfig for %s: %v", user, err))
+		reporter.Default().Fail(fmt.Errorf("failed to create temp kubeconfig for %s: %v", user, err))
 	}
 	_ = tmp.Close()
 
@@ -646,7 +646,7 @@ func ApproveApprovalTaskExpectFailAsUser(user, task, namespace, message string)
 	}
 	res := cmd.RunWithEnv([]string{"KUBECONFIG=" + kc}, args...)
 	if res.ExitCode == 0 {
-		testsuit.T.Fail(fmt.Errorf("expected approval by %s on %s to fail, but it succeeded", user, task))
+		reporter.Default().Fail(fmt.Errorf("expected approval by %s on %s to fail, but it succeeded", user, task))
 	}
 }
 
@@ -664,7 +664,7 @@ func ApproveApprovalTaskAllowFinalStateAsUser(user, task, namespace, message str
 	if strings.Contains(out, "already reached") && strings.Contains(out, "final state") {
 		return
 	}
-	testsuit.T.Fail(fmt.Errorf("unexpected approval failure for %s on %s: %s", user, task, res.Stderr()))
+	reporter.Default().Fail(fmt.Errorf("unexpected approval failure for %s on %s: %s", user, task, res.Stderr()))
 }
 
 type approvalTaskActionFn func(user, task, namespace, message string)
@@ -680,13 +680,13 @@ var approvalTaskActionDispatch = map[string]approvalTaskActionFn{
 func PerformApprovalTaskActionAsUser(user, action, task, namespace, message string) {
 	a := strings.ToLower(strings.TrimSpace(action))
 	if a == "" {
-		testsuit.T.Fail(fmt.Errorf("approval task action is empty"))
+		reporter.Default().Fail(fmt.Errorf("approval task action is empty"))
 		return
 	}
 
 	fn, ok := approvalTaskActionDispatch[a]
 	if !ok {
-		testsuit.T.Fail(fmt.Errorf("unsupported approval gate action: %s", action))
+		reporter.Default().Fail(fmt.Errorf("unsupported approval gate action: %s", action))
 		return
 	}
 
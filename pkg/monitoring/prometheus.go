@@ -13,6 +13,8 @@ import (
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
 
 	v1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -34,8 +36,18 @@ func (a *authRoundtripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	return a.inner.RoundTrip(r)
 }
 
+// Prometheus of the monitoring stack installed on Kubernetes (e.g. kube-prometheus)
+const (
+	prometheusNamespace = "monitoring"
+	prometheusService   = "prometheus-k8s"
+	prometheusPort      = "web"
+)
+
 func newPrometheusClient(cs *clients.Clients) (promv1.API, error) {
-	route, err := getPrometheusRoute(cs)
+	if !platform.Current().Supports(platform.ClusterMonitoring) {
+		return newKubernetesPrometheusClient(cs)
+	}
+	address, err := getPrometheusAddress(cs)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +60,7 @@ func newPrometheusClient(cs *clients.Clients) (promv1.API, error) {
 	// nolint reason: InsecureSkipVerify is enabled due to self signed certs
 	rt.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
 	client, err := prom.NewClient(prom.Config{
-		Address: "https://" + route.Spec.Host,
+		Address: address,
 		RoundTripper: &authRoundtripper{
 			authorization: fmt.Sprintf("Bearer %s", bToken),
 			inner:         rt,
@@ -61,6 +73,37 @@ func newPrometheusClient(cs *clients.Clients) (promv1.API, error) {
 	return promv1.NewAPI(client), nil
 }
 
+// newKubernetesPrometheusClient queries the Prometheus service of the
+// monitoring namespace, exposed with the expose mode, without authentication
+func newKubernetesPrometheusClient(cs *clients.Clients) (promv1.API, error) {
+	url, err := expose.Current().Expose(cs, expose.Service{Namespace: prometheusNamespace, Name: prometheusService, Port: prometheusPort})
+	if err != nil {
+		return nil, fmt.Errorf("error exposing Prometheus: %w", err)
+	}
+	client, err := prom.NewClient(prom.Config{Address: url})
+	if err != nil {
+		return nil, err
+	}
+	return promv1.NewAPI(client), nil
+}
+
+// getPrometheusAddress returns the URL of the Prometheus of openshift-monitoring,
+// its Route or the web port of its service exposed with the expose mode
+func getPrometheusAddress(cs *clients.Clients) (string, error) {
+	if expose.Current().Name() == expose.RouteMode {
+		route, err := getPrometheusRoute(cs)
+		if err != nil {
+			return "", err
+		}
+		return "https://" + route.Spec.Host, nil
+	}
+	address, err := expose.Current().Expose(cs, expose.Service{Namespace: "openshift-monitoring", Name: "prometheus-k8s", Port: prometheusPort, TLS: true})
+	if err != nil {
+		return "", fmt.Errorf("error exposing Prometheus: %w", err)
+	}
+	return address, nil
+}
+
 func getPrometheusRoute(cs *clients.Clients) (*v1.Route, error) {
 	r, err := cs.Route.Routes("openshift-monitoring").Get(context.Background(), "prometheus-k8s", meta.GetOptions{})
 	if err != nil {
@@ -0,0 +1,187 @@
+// Package mustgather dumps the state of a scenario namespace and of the
+// OpenShift Pipelines installation to a directory, so the evidence of a
+// failure outlives the cluster it ran on.
+package mustgather
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/olm"
+	operatorapi "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/yaml"
+)
+
+// operatorPodPrefix is the name prefix of the operator pods in olm.OperatorsNamespace
+const operatorPodPrefix = "openshift-pipelines-operator"
+
+// tektonGroups are the API groups of the resources dumped from the scenario namespace
+var tektonGroups = []string{"tekton.dev", "openshift-pipelines.org"}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ScenarioDir returns the artifacts directory of a scenario under root, the
+// namespace keeps the directories of data driven scenarios apart
+func ScenarioDir(root, scenario, namespace string) string {
+	name := strings.Trim(unsafeChars.ReplaceAllString(scenario, "-"), "-")
+	return filepath.Join(root, name+"-"+namespace)
+}
+
+// Collect writes to dir:
+//   - every Tekton resource of namespace (resources/<resource>.<group>.yaml)
+//   - the logs of all the containers of its pods (logs/<pod>/<container>.log)
+//   - its Warning events (events.yaml)
+//   - TektonConfig and TektonInstallerSets (tektonconfig.yaml, tektoninstallersets.yaml)
+//   - the last 1000 lines of the logs of the controllers in openshift-pipelines
+//     and of the operator (<namespace>/logs/<pod>/<container>.log)
+//
+// Collection is best effort: it goes on after an error and returns them all.
+func Collect(c *clients.Clients, namespace, dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create artifacts directory %s: %v", dir, err)
+	}
+	tail := int64(1000)
+	return errors.Join(
+		dumpTektonResources(c, namespace, filepath.Join(dir, "resources")),
+		dumpPodLogs(c, namespace, filepath.Join(dir, "logs"), nil, ""),
+		dumpWarningEvents(c, namespace, filepath.Join(dir, "events.yaml")),
+		dumpOperatorResources(c, dir),
+		dumpPodLogs(c, config.TargetNamespace(), filepath.Join(dir, config.TargetNamespace(), "logs"), &tail, ""),
+		dumpPodLogs(c, olm.OperatorsNamespace, filepath.Join(dir, olm.OperatorsNamespace, "logs"), &tail, operatorPodPrefix),
+	)
+}
+
+func writeYAML(path string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func isTektonGroup(group string) bool {
+	for _, g := range tektonGroups {
+		if group == g || strings.HasSuffix(group, "."+g) {
+			return true
+		}
+	}
+	return false
+}
+
+func dumpTektonResources(c *clients.Clients, namespace, dir string) error {
+	var errs []error
+	// partial discovery failures still return the resources of the healthy groups
+	lists, err := c.KubeClient.Kube.Discovery().ServerPreferredNamespacedResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return fmt.Errorf("failed to discover namespaced resources: %v", err)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil || !isTektonGroup(gv.Group) {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			items, err := c.Dynamic.Resource(gvr).Namespace(namespace).List(c.Ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to list %s in namespace %s: %v", gvr.GroupResource(), namespace, err))
+				continue
+			}
+			if len(items.Items) == 0 {
+				continue
+			}
+			errs = append(errs, writeYAML(filepath.Join(dir, gvr.GroupResource().String()+".yaml"), items))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dumpPodLogs writes the logs of every container of the pods of namespace
+// whose name starts with prefix, the last tailLines lines only if set
+func dumpPodLogs(c *clients.Clients, namespace, dir string, tailLines *int64, prefix string) error {
+	pods, err := c.KubeClient.Kube.CoreV1().Pods(namespace).List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods in namespace %s: %v", namespace, err)
+	}
+	var errs []error
+	for _, pod := range pods.Items {
+		if !strings.HasPrefix(pod.Name, prefix) {
+			continue
+		}
+		var containers []string
+		for _, ctr := range pod.Spec.InitContainers {
+			containers = append(containers, ctr.Name)
+		}
+		for _, ctr := range pod.Spec.Containers {
+			containers = append(containers, ctr.Name)
+		}
+		for _, container := range containers {
+			errs = append(errs, dumpContainerLogs(c, namespace, pod.Name, container, tailLines, filepath.Join(dir, pod.Name, container+".log")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func dumpContainerLogs(c *clients.Clients, namespace, pod, container string, tailLines *int64, path string) error {
+	stream, err := c.KubeClient.Kube.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container, TailLines: tailLines}).Stream(c.Ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of container %s of pod %s in namespace %s: %v", container, pod, namespace, err)
+	}
+	defer stream.Close()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, stream)
+	return err
+}
+
+func dumpWarningEvents(c *clients.Clients, namespace, path string) error {
+	events, err := c.KubeClient.Kube.CoreV1().Events(namespace).List(c.Ctx, metav1.ListOptions{FieldSelector: "type=Warning"})
+	if err != nil {
+		return fmt.Errorf("failed to list Warning events in namespace %s: %v", namespace, err)
+	}
+	return writeYAML(path, events)
+}
+
+func dumpOperatorResources(c *clients.Clients, dir string) error {
+	var errs []error
+	tc, err := c.TektonConfig().Get(c.Ctx, operatorapi.ConfigResourceName, metav1.GetOptions{})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get TektonConfig: %v", err))
+	} else {
+		errs = append(errs, writeYAML(filepath.Join(dir, "tektonconfig.yaml"), tc))
+	}
+	tis, err := c.Operator.TektonInstallerSets().List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list TektonInstallerSets: %v", err))
+	} else {
+		errs = append(errs, writeYAML(filepath.Join(dir, "tektoninstallersets.yaml"), tis))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,635 @@
+package oc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/retry"
+)
+
+// fieldManager identifies the suite as the owner of the fields it applies
+const fieldManager = "release-tests"
+
+var (
+	projectRequestGVR  = schema.GroupVersionResource{Group: "project.openshift.io", Version: "v1", Resource: "projectrequests"}
+	consoleOperatorGVR = schema.GroupVersionResource{Group: "operator.openshift.io", Version: "v1", Resource: "consoles"}
+)
+
+// apiBackend runs every operation through the Kubernetes API: manifests go
+// through the dynamic client and server-side apply, everything else through
+// the typed clients. Failures are reported with the API errors as returned.
+type apiBackend struct {
+	cs *clients.Clients
+
+	mu       sync.Mutex
+	discover *restmapper.DeferredDiscoveryRESTMapper
+	mapper   meta.RESTMapper
+}
+
+// NewAPIBackend returns the Backend that talks to the cluster through cs
+func NewAPIBackend(cs *clients.Clients) Backend {
+	return &apiBackend{cs: cs}
+}
+
+func (a *apiBackend) restMapper() meta.RESTMapper {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.mapper == nil {
+		dc := memory.NewMemCacheClient(a.cs.KubeClient.Kube.Discovery())
+		a.discover = restmapper.NewDeferredDiscoveryRESTMapper(dc)
+		a.mapper = restmapper.NewShortcutExpander(a.discover, dc, nil)
+	}
+	return a.mapper
+}
+
+// withMapper calls fn with the REST mapper, once more after refreshing the
+// discovery cache if the kind or resource is unknown (e.g. a CRD just installed)
+func (a *apiBackend) withMapper(fn func(meta.RESTMapper) error) error {
+	err := fn(a.restMapper())
+	if meta.IsNoMatchError(err) {
+		a.mu.Lock()
+		a.discover.Reset()
+		a.mu.Unlock()
+		err = fn(a.restMapper())
+	}
+	return err
+}
+
+func (a *apiBackend) mappingForKind(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	var mapping *meta.RESTMapping
+	err := a.withMapper(func(m meta.RESTMapper) error {
+		var err error
+		mapping, err = m.RESTMapping(gvk.GroupKind(), gvk.Version)
+		return err
+	})
+	return mapping, err
+}
+
+// mappingForResource resolves resource types the way oc does, e.g. "pr",
+// "pipelinerun" or "pipelineruns.tekton.dev"
+func (a *apiBackend) mappingForResource(resourceType string) (*meta.RESTMapping, error) {
+	var mapping *meta.RESTMapping
+	err := a.withMapper(func(m meta.RESTMapper) error {
+		gvk, err := m.KindFor(schema.ParseGroupResource(resourceType).WithVersion(""))
+		if err != nil {
+			return err
+		}
+		mapping, err = m.RESTMapping(gvk.GroupKind(), gvk.Version)
+		return err
+	})
+	return mapping, err
+}
+
+func (a *apiBackend) resourceInterface(mapping *meta.RESTMapping, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return a.cs.Dynamic.Resource(mapping.Resource).Namespace(namespace)
+	}
+	return a.cs.Dynamic.Resource(mapping.Resource)
+}
+
+// readManifests returns the objects defined in a file, in the yaml and json
+// files of a directory, or at a remote http(s) location
+func readManifests(path string) ([]*unstructured.Unstructured, error) {
+	var files [][]byte
+	switch {
+	case strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://"):
+		data, err := fetchManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, data)
+	default:
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		paths := []string{path}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			paths = nil
+			for _, entry := range entries {
+				switch filepath.Ext(entry.Name()) {
+				case ".yaml", ".yml", ".json":
+					if !entry.IsDir() {
+						paths = append(paths, filepath.Join(path, entry.Name()))
+					}
+				}
+			}
+		}
+		for _, p := range paths {
+			data, err := os.ReadFile(filepath.Clean(p))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, data)
+		}
+	}
+
+	var objs []*unstructured.Unstructured
+	for _, data := range files {
+		decoded, err := decodeManifests(data)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, decoded...)
+	}
+	return objs, nil
+}
+
+func fetchManifest(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q fetching %s", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// decodeManifests splits a multi document yaml (or json) stream into objects,
+// flattening lists
+func decodeManifests(data []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objs, nil
+			}
+			return nil, err
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, err
+		}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				objs = append(objs, &list.Items[i])
+			}
+			continue
+		}
+		objs = append(objs, obj)
+	}
+}
+
+func describe(obj *unstructured.Unstructured) string {
+	return strings.ToLower(obj.GroupVersionKind().GroupKind().String()) + "/" + obj.GetName()
+}
+
+// forEachManifest calls fn for every object read from path, with the
+// resource client for its type scoped to namespace unless the object sets its own
+func (a *apiBackend) forEachManifest(path, namespace, verb, done string, fn func(dynamic.ResourceInterface, *unstructured.Unstructured) error) {
+	objs, err := readManifests(path)
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to read manifests from %s: %v", path, err))
+		return
+	}
+	var output []string
+	for _, obj := range objs {
+		mapping, err := a.mappingForKind(obj.GroupVersionKind())
+		if err != nil {
+			a.cs.Reporter().Fail(fmt.Errorf("failed to map %s: %v", describe(obj), err))
+			return
+		}
+		ns := obj.GetNamespace()
+		if ns == "" {
+			ns = namespace
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			obj.SetNamespace(ns)
+		}
+		if err := fn(a.resourceInterface(mapping, ns), obj); err != nil {
+			a.cs.Reporter().Fail(fmt.Errorf("failed to %s %s: %v", verb, describe(obj), err))
+			return
+		}
+		output = append(output, describe(obj)+" "+done)
+	}
+	log.Printf("output: %s\n", strings.Join(output, "\n"))
+}
+
+func (a *apiBackend) create(path, namespace string) {
+	a.forEachManifest(path, namespace, "create", "created", func(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+		created, err := ri.Create(a.cs.Ctx, obj, metav1.CreateOptions{FieldManager: fieldManager})
+		if err == nil {
+			obj.SetName(created.GetName())
+		}
+		return err
+	})
+}
+
+func (a *apiBackend) Create(path_dir, namespace string) {
+	a.create(config.Path(path_dir), namespace)
+}
+
+func (a *apiBackend) CreateRemote(remote_path, namespace string) {
+	a.create(remote_path, namespace)
+}
+
+func (a *apiBackend) Apply(path_dir, namespace string) {
+	a.forEachManifest(config.Path(path_dir), namespace, "apply", "serverside-applied", func(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+		_, err := ri.Apply(a.cs.Ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+		return err
+	})
+}
+
+// deleteAndWait deletes the named resource and waits until it is gone, like oc delete does
+func (a *apiBackend) deleteAndWait(ri dynamic.ResourceInterface, name string, timeout time.Duration) error {
+	policy := metav1.DeletePropagationBackground
+	if err := ri.Delete(a.cs.Ctx, name, metav1.DeleteOptions{PropagationPolicy: &policy}); err != nil {
+		return err
+	}
+	return wait.PollUntilContextTimeout(a.cs.Ctx, config.APIRetry, timeout, true, func(ctx context.Context) (bool, error) {
+		_, err := ri.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+func (a *apiBackend) Delete(path_dir, namespace string) {
+	// Tekton Results sets a finalizer that prevent resource removal for some time
+	// see parameters "store_deadline" and "forward_buffer"
+	// by default, it waits at least 150 seconds
+	a.forEachManifest(config.Path(path_dir), namespace, "delete", "deleted", func(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+		return a.deleteAndWait(ri, obj.GetName(), time.Second*300)
+	})
+}
+
+func (a *apiBackend) CreateNewProject(ns string) {
+	project := &unstructured.Unstructured{}
+	project.SetAPIVersion(projectRequestGVR.GroupVersion().String())
+	project.SetKind("ProjectRequest")
+	project.SetName(ns)
+	if _, err := a.cs.Dynamic.Resource(projectRequestGVR).Create(a.cs.Ctx, project, metav1.CreateOptions{}); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to create project %s: %v", ns, err))
+		return
+	}
+	log.Printf("output: Now using project %q\n", ns)
+}
+
+func (a *apiBackend) deleteProject(ns string) error {
+	if err := a.cs.KubeClient.Kube.CoreV1().Namespaces().Delete(a.cs.Ctx, ns, metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	log.Printf("output: project.project.openshift.io %q deleted\n", ns)
+	return nil
+}
+
+func (a *apiBackend) DeleteProject(ns string) {
+	if err := a.deleteProject(ns); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to delete project %s: %v", ns, err))
+	}
+}
+
+func (a *apiBackend) DeleteProjectIgnoreErors(ns string) {
+	if err := a.deleteProject(ns); err != nil {
+		log.Printf("output: %v\n", err)
+	}
+}
+
+func (a *apiBackend) LinkSecretToSA(secretname, sa, namespace string) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		serviceAccounts := a.cs.KubeClient.Kube.CoreV1().ServiceAccounts(namespace)
+		account, err := serviceAccounts.Get(a.cs.Ctx, sa, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		for _, s := range account.Secrets {
+			if s.Name == secretname {
+				return nil
+			}
+		}
+		account.Secrets = append(account.Secrets, corev1.ObjectReference{Name: secretname})
+		_, err = serviceAccounts.Update(a.cs.Ctx, account, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to link secret %s to service account %s: %v", secretname, sa, err))
+	}
+}
+
+func (a *apiBackend) createSecret(name, namespace string, secretType corev1.SecretType, data map[string]string) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       secretType,
+		Data:       make(map[string][]byte, len(data)),
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	if _, err := a.cs.KubeClient.Kube.CoreV1().Secrets(namespace).Create(a.cs.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to create secret %s in namespace %s: %v", name, namespace, err))
+		return
+	}
+	log.Printf("output: secret/%s created\n", name)
+}
+
+func (a *apiBackend) CreateSecretWithSecretToken(secretname, namespace string) {
+	a.createSecret(secretname, namespace, corev1.SecretTypeOpaque, map[string]string{"secretToken": config.TriggersSecretToken})
+}
+
+// patchNamespaceMetadata sets ("key=value") or removes ("key-") a label or an
+// annotation, refusing to overwrite an existing value like oc does without --overwrite
+func (a *apiBackend) patchNamespaceMetadata(namespace, field, arg string) error {
+	var value interface{}
+	key, v, found := strings.Cut(arg, "=")
+	switch {
+	case found:
+		value = v
+	case strings.HasSuffix(arg, "-"):
+		key = strings.TrimSuffix(arg, "-")
+	default:
+		return fmt.Errorf("invalid %s %q, expected key=value or key-", field, arg)
+	}
+
+	namespaces := a.cs.KubeClient.Kube.CoreV1().Namespaces()
+	ns, err := namespaces.Get(a.cs.Ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	existing := ns.Labels
+	if field == "annotations" {
+		existing = ns.Annotations
+	}
+	if old, ok := existing[key]; ok && found && old != v {
+		return fmt.Errorf("'%s' already has a value (%s), and --overwrite is false", key, old)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{field: map[string]interface{}{key: value}},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := namespaces.Patch(a.cs.Ctx, namespace, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	done := "labeled"
+	if field == "annotations" {
+		done = "annotated"
+	}
+	log.Printf("output: namespace/%s %s\n", namespace, done)
+	return nil
+}
+
+func (a *apiBackend) EnableTLSConfigForEventlisteners(namespace string) {
+	a.LabelNamespace(namespace, "operator.tekton.dev/enable-annotation=enabled")
+}
+
+func (a *apiBackend) VerifyKubernetesEventsForEventListener(namespace string) {
+	events, err := a.cs.KubeClient.Kube.CoreV1().Events(namespace).List(a.cs.Ctx, metav1.ListOptions{})
+	if err != nil {
+		a.cs.Reporter().Errorf("failed to list events in namespace %s: %v", namespace, err)
+		return
+	}
+	var result strings.Builder
+	for i := range events.Items {
+		fmt.Fprintf(&result, "%s %s\n", events.Items[i].Reason, events.Items[i].Message)
+	}
+	startedEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.started.v1")
+	successfulEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.successful.v1")
+	doneEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.done.v1")
+	if !startedEvent || !successfulEvent || !doneEvent {
+		a.cs.Reporter().Errorf("No events for successful, done and started")
+	}
+}
+
+func (a *apiBackend) patchTektonConfig(patchType types.PatchType, patch string) error {
+	_, err := a.cs.TektonConfig().Patch(a.cs.Ctx, "config", patchType, []byte(patch), metav1.PatchOptions{})
+	return err
+}
+
+func (a *apiBackend) UpdateTektonConfig(patch_data string) {
+	if err := a.patchTektonConfig(types.MergePatchType, patch_data); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to patch tektonconfig config: %v", err))
+		return
+	}
+	log.Printf("output: tektonconfig.operator.tekton.dev/config patched\n")
+}
+
+func (a *apiBackend) UpdateTektonConfigwithInvalidData(patch_data, errorMessage string) {
+	err := a.patchTektonConfig(types.MergePatchType, patch_data)
+	if err == nil {
+		a.cs.Reporter().Errorf("Expected patch %s to be rejected but it was accepted", patch_data)
+		return
+	}
+	log.Printf("Output: %v\n", err)
+	if !strings.Contains(err.Error(), errorMessage) {
+		a.cs.Reporter().Errorf("Expected error message substring %v in %v", errorMessage, err)
+	}
+}
+
+func (a *apiBackend) AnnotateNamespace(namespace, annotation string) {
+	if err := a.patchNamespaceMetadata(namespace, "annotations", annotation); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to annotate namespace %s: %v", namespace, err))
+	}
+}
+
+func (a *apiBackend) AnnotateNamespaceIgnoreErrors(namespace, annotation string) {
+	if err := a.patchNamespaceMetadata(namespace, "annotations", annotation); err != nil {
+		log.Printf("output: %v\n", err)
+	}
+}
+
+func (a *apiBackend) RemovePrunerConfig() {
+	if err := a.patchTektonConfig(types.JSONPatchType, "[{ \"op\": \"remove\", \"path\": \"/spec/pruner\" }]"); err != nil {
+		log.Printf("output: %v\n", err)
+	}
+}
+
+func (a *apiBackend) LabelNamespace(namespace, label string) {
+	if err := a.patchNamespaceMetadata(namespace, "labels", label); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to label namespace %s: %v", namespace, err))
+	}
+}
+
+func (a *apiBackend) deleteResource(resourceType, name, namespace string, timeout time.Duration) {
+	mapping, err := a.mappingForResource(resourceType)
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to map resource type %s: %v", resourceType, err))
+		return
+	}
+	if err := a.deleteAndWait(a.resourceInterface(mapping, namespace), name, timeout); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to delete %s %s in namespace %s: %v", resourceType, name, namespace, err))
+		return
+	}
+	log.Printf("output: %s %q deleted\n", mapping.Resource.GroupResource().String(), name)
+}
+
+func (a *apiBackend) DeleteResource(resourceType, name string) {
+	// Tekton Results sets a finalizer that prevent resource removal for some time
+	// see parameters "store_deadline" and "forward_buffer"
+	// by default, it waits at least 150 seconds
+	a.deleteResource(resourceType, name, store.Namespace(), time.Second*300)
+}
+
+func (a *apiBackend) DeleteResourceInNamespace(resourceType, name, namespace string) {
+	a.deleteResource(resourceType, name, namespace, config.CLITimeout)
+}
+
+func (a *apiBackend) CheckProjectExists(projectName string) bool {
+	_, err := a.cs.KubeClient.Kube.CoreV1().Namespaces().Get(a.cs.Ctx, projectName, metav1.GetOptions{})
+	return err == nil
+}
+
+func (a *apiBackend) SecretExists(secretName string, namespace string) bool {
+	_, err := a.cs.KubeClient.Kube.CoreV1().Secrets(namespace).Get(a.cs.Ctx, secretName, metav1.GetOptions{})
+	return err == nil
+}
+
+func (a *apiBackend) CreateSecretForGitResolver(secretData string) {
+	a.createSecret("github-auth-secret", "openshift-pipelines", corev1.SecretTypeOpaque, map[string]string{"github-auth-key": secretData})
+}
+
+func (a *apiBackend) CreateSecretInNamespace(secretData, secretName, namespace string) {
+	a.createSecret(secretName, namespace, corev1.SecretTypeOpaque, map[string]string{"private-repo-token": secretData})
+}
+
+func (a *apiBackend) CreateSecretForWebhook(tokenSecretData, webhookSecretData, namespace string) {
+	a.createSecret("gitlab-webhook-config", namespace, corev1.SecretTypeOpaque, map[string]string{
+		"provider.token": tokenSecretData,
+		"webhook.secret": webhookSecretData,
+	})
+}
+
+func (a *apiBackend) EnableConsolePlugin() {
+	consoles := a.cs.Dynamic.Resource(consoleOperatorGVR)
+	console, err := consoles.Get(a.cs.Ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to get consoles.operator.openshift.io cluster: %v", err))
+		return
+	}
+	plugins, _, err := unstructured.NestedStringSlice(console.Object, "spec", "plugins")
+	if err != nil {
+		a.cs.Reporter().Errorf("Could not parse consoles.operator.openshift.io CR: %v", err)
+	}
+	log.Printf("Already enabled console plugins: %v", plugins)
+	if slices.Contains(plugins, config.ConsolePluginDeployment) {
+		log.Printf("Pipelines console plugin is already enabled.")
+		return
+	}
+
+	plugins = append(plugins, config.ConsolePluginDeployment)
+	patch, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"plugins": plugins}})
+	if err != nil {
+		a.cs.Reporter().Fail(err)
+		return
+	}
+	if _, err := consoles.Patch(a.cs.Ctx, "cluster", types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to patch consoles.operator.openshift.io cluster: %v", err))
+	}
+}
+
+// GetSecretsData returns the data of the secret in the format of the oc
+// jsonpath "{.data}" query used by the cli backend, i.e. quoted json
+func (a *apiBackend) GetSecretsData(secretName, namespace string) string {
+	secret, err := a.cs.KubeClient.Kube.CoreV1().Secrets(namespace).Get(a.cs.Ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to get secret %s in namespace %s: %v", secretName, namespace, err))
+		return ""
+	}
+	if len(secret.Data) == 0 {
+		return "\"\""
+	}
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		a.cs.Reporter().Fail(err)
+		return ""
+	}
+	return "\"" + string(data) + "\""
+}
+
+// CreateChainsImageRegistrySecret creates the secret in the scenario namespace,
+// the current project of the cli backend
+func (a *apiBackend) CreateChainsImageRegistrySecret(dockerConfig string) {
+	a.createSecret("chains-image-registry-credentials", store.Namespace(), corev1.SecretTypeDockerConfigJson, map[string]string{
+		corev1.DockerConfigJsonKey: dockerConfig,
+		"config.json":              dockerConfig,
+	})
+}
+
+func (a *apiBackend) CopySecret(secretName string, sourceNamespace string, destNamespace string) {
+	secrets := a.cs.KubeClient.Kube.CoreV1().Secrets(destNamespace)
+	source, err := a.cs.KubeClient.Kube.CoreV1().Secrets(sourceNamespace).Get(a.cs.Ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to get secret %s in namespace %s: %v", secretName, sourceNamespace, err))
+		return
+	}
+	data := make(map[string][]byte, len(source.Data))
+	for k, v := range source.Data {
+		if k == "github-auth-key" {
+			k = "token"
+		}
+		data[k] = v
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := secrets.Get(a.cs.Ctx, secretName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			_, err = secrets.Create(a.cs.Ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: destNamespace, Labels: source.Labels},
+				Type:       source.Type,
+				Data:       data,
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		existing.Labels = source.Labels
+		existing.Data = data
+		_, err = secrets.Update(a.cs.Ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		a.cs.Reporter().Fail(fmt.Errorf("failed to copy secret %s to namespace %s: %v", secretName, destNamespace, err))
+		return
+	}
+	log.Printf("Successfully copied secret %s from %s to %s", secretName, sourceNamespace, destNamespace)
+}
@@ -0,0 +1,419 @@
+package oc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newAPIBackend returns the api backend of fake clients whose discovery
+// knows the config maps, secrets and persistent volume claims, like oc
+// resolving their kinds and short names
+func newAPIBackend(t *testing.T, objects ...runtime.Object) (*apiBackend, *testutil.FakeClientsets) {
+	t.Helper()
+	c, fcs := testutil.NewFakeClients("test-ns", objects...)
+	fcs.Kube.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", SingularName: "configmap", Kind: "ConfigMap", Namespaced: true, ShortNames: []string{"cm"}, Verbs: []string{"create", "get", "delete"}},
+			{Name: "secrets", SingularName: "secret", Kind: "Secret", Namespaced: true, Verbs: []string{"create", "get", "delete"}},
+			{Name: "persistentvolumeclaims", SingularName: "persistentvolumeclaim", Kind: "PersistentVolumeClaim", Namespaced: true, ShortNames: []string{"pvc"}, Verbs: []string{"create", "get", "delete"}},
+		},
+	}}
+	return NewAPIBackend(c).(*apiBackend), fcs
+}
+
+func TestAPIBackendCreate(t *testing.T) {
+	manifests := filepath.Join(t.TempDir(), "manifests.yaml")
+	err := os.WriteFile(manifests, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: in-scenario
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: in-other
+  namespace: other-ns
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: listed
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, fcs := newAPIBackend(t)
+	if err := testutil.CaptureFailures(func() { a.CreateRemote(manifests, "test-ns") }); err != nil {
+		t.Fatalf("CreateRemote() failed: %v", err)
+	}
+	// oc create -n keeps the namespace set by a manifest and flattens lists
+	for _, cm := range []struct{ namespace, name string }{
+		{"test-ns", "in-scenario"},
+		{"other-ns", "in-other"},
+		{"test-ns", "listed"},
+	} {
+		if _, err := fcs.Dynamic.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(cm.namespace).Get(t.Context(), cm.name, metav1.GetOptions{}); err != nil {
+			t.Errorf("config map %s not created in namespace %s: %v", cm.name, cm.namespace, err)
+		}
+	}
+
+	// like oc create, creating the same manifests again fails
+	if err := testutil.CaptureFailures(func() { a.CreateRemote(manifests, "test-ns") }); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("CreateRemote() of existing resources failed with %v, want an already exists error", err)
+	}
+}
+
+func TestAPIBackendCreateTestdata(t *testing.T) {
+	a, fcs := newAPIBackend(t)
+	if err := testutil.CaptureFailures(func() { a.Create("testdata/pvc.yaml", "test-ns") }); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if _, err := fcs.Dynamic.Resource(corev1.SchemeGroupVersion.WithResource("persistentvolumeclaims")).Namespace("test-ns").Get(t.Context(), "testdata", metav1.GetOptions{}); err != nil {
+		t.Errorf("persistent volume claim of testdata/pvc.yaml not created: %v", err)
+	}
+	if err := testutil.CaptureFailures(func() { a.Create("testdata/missing.yaml", "test-ns") }); err == nil || !strings.Contains(err.Error(), "failed to read manifests") {
+		t.Errorf("Create() of a missing file failed with %v, want a read error", err)
+	}
+}
+
+func TestAPIBackendDeleteResourceInNamespace(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "test-ns"}}
+	tests := []struct {
+		name         string
+		resourceType string
+		resource     string
+		wantErr      string
+	}{{
+		name:         "short name",
+		resourceType: "cm",
+		resource:     "cm",
+	}, {
+		name:         "plural",
+		resourceType: "configmaps",
+		resource:     "cm",
+	}, {
+		name:         "missing resource",
+		resourceType: "configmap",
+		resource:     "missing",
+		wantErr:      `configmaps "missing" not found`,
+	}, {
+		name:         "unknown type",
+		resourceType: "pipelineruns",
+		resource:     "cm",
+		wantErr:      "failed to map resource type pipelineruns",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fcs := newAPIBackend(t, cm.DeepCopy())
+			err := testutil.CaptureFailures(func() { a.DeleteResourceInNamespace(tt.resourceType, tt.resource, "test-ns") })
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("DeleteResourceInNamespace() failed: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("DeleteResourceInNamespace() failed with %v, want %q", err, tt.wantErr)
+			case tt.wantErr == "":
+				_, err := fcs.Dynamic.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace("test-ns").Get(t.Context(), "cm", metav1.GetOptions{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("config map not deleted: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIBackendNamespaceMetadata(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test-ns",
+		Labels:      map[string]string{"team": "pipelines"},
+		Annotations: map[string]string{"owner": "release-tests"},
+	}}
+	tests := []struct {
+		name            string
+		fn              func(a *apiBackend)
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+		wantErr         string
+	}{{
+		name:            "add label",
+		fn:              func(a *apiBackend) { a.LabelNamespace("test-ns", "env=test") },
+		wantLabels:      map[string]string{"team": "pipelines", "env": "test"},
+		wantAnnotations: map[string]string{"owner": "release-tests"},
+	}, {
+		name:            "same label value",
+		fn:              func(a *apiBackend) { a.LabelNamespace("test-ns", "team=pipelines") },
+		wantLabels:      map[string]string{"team": "pipelines"},
+		wantAnnotations: map[string]string{"owner": "release-tests"},
+	}, {
+		name:    "label overwrite",
+		fn:      func(a *apiBackend) { a.LabelNamespace("test-ns", "team=triggers") },
+		wantErr: "'team' already has a value (pipelines), and --overwrite is false",
+	}, {
+		name:            "remove label",
+		fn:              func(a *apiBackend) { a.LabelNamespace("test-ns", "team-") },
+		wantLabels:      map[string]string{},
+		wantAnnotations: map[string]string{"owner": "release-tests"},
+	}, {
+		name:    "invalid label",
+		fn:      func(a *apiBackend) { a.LabelNamespace("test-ns", "team") },
+		wantErr: `invalid labels "team", expected key=value or key-`,
+	}, {
+		name:            "add annotation",
+		fn:              func(a *apiBackend) { a.AnnotateNamespace("test-ns", "reviewer=maintainers") },
+		wantLabels:      map[string]string{"team": "pipelines"},
+		wantAnnotations: map[string]string{"owner": "release-tests", "reviewer": "maintainers"},
+	}, {
+		name:            "annotation overwrite ignoring errors",
+		fn:              func(a *apiBackend) { a.AnnotateNamespaceIgnoreErrors("test-ns", "owner=someone") },
+		wantLabels:      map[string]string{"team": "pipelines"},
+		wantAnnotations: map[string]string{"owner": "release-tests"},
+	}, {
+		name:            "tls for eventlisteners",
+		fn:              func(a *apiBackend) { a.EnableTLSConfigForEventlisteners("test-ns") },
+		wantLabels:      map[string]string{"team": "pipelines", "operator.tekton.dev/enable-annotation": "enabled"},
+		wantAnnotations: map[string]string{"owner": "release-tests"},
+	}, {
+		name:    "missing namespace",
+		fn:      func(a *apiBackend) { a.LabelNamespace("missing", "env=test") },
+		wantErr: `namespaces "missing" not found`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fcs := newAPIBackend(t, ns.DeepCopy())
+			err := testutil.CaptureFailures(func() { tt.fn(a) })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("failed with %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			got, err := fcs.Kube.CoreV1().Namespaces().Get(t.Context(), "test-ns", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got.Labels) != fmt.Sprint(tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", got.Labels, tt.wantLabels)
+			}
+			if fmt.Sprint(got.Annotations) != fmt.Sprint(tt.wantAnnotations) {
+				t.Errorf("annotations = %v, want %v", got.Annotations, tt.wantAnnotations)
+			}
+		})
+	}
+}
+
+func TestAPIBackendGetSecretsData(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string][]byte
+		// want is what oc get secret -o jsonpath="{.data}" prints, quotes
+		// included
+		want string
+	}{{
+		name: "data",
+		data: map[string][]byte{"token": []byte("s3cr3t"), "user": []byte("admin")},
+		want: `"{"token":"czNjcjN0","user":"YWRtaW4="}"`,
+	}, {
+		name: "empty",
+		want: `""`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newAPIBackend(t, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "test-ns"}, Data: tt.data})
+			if got := a.GetSecretsData("secret", "test-ns"); got != tt.want {
+				t.Errorf("GetSecretsData() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIBackendSecrets(t *testing.T) {
+	a, fcs := newAPIBackend(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})
+	err := testutil.CaptureFailures(func() {
+		a.CreateSecretInNamespace("token", "private-repo", "test-ns")
+		a.CreateSecretForWebhook("provider", "webhook", "test-ns")
+	})
+	if err != nil {
+		t.Fatalf("failed to create the secrets: %v", err)
+	}
+	for name, want := range map[string]map[string]string{
+		"private-repo":          {"private-repo-token": "token"},
+		"gitlab-webhook-config": {"provider.token": "provider", "webhook.secret": "webhook"},
+	} {
+		secret, err := fcs.Kube.CoreV1().Secrets("test-ns").Get(t.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("secret %s not created: %v", name, err)
+		}
+		if secret.Type != corev1.SecretTypeOpaque {
+			t.Errorf("type of secret %s = %s, want %s like oc create secret generic", name, secret.Type, corev1.SecretTypeOpaque)
+		}
+		got := map[string]string{}
+		for k, v := range secret.Data {
+			got[k] = string(v)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("data of secret %s = %v, want %v", name, got, want)
+		}
+	}
+	if !a.SecretExists("private-repo", "test-ns") {
+		t.Error("SecretExists() = false for a created secret")
+	}
+	if a.SecretExists("missing", "test-ns") {
+		t.Error("SecretExists() = true for a missing secret")
+	}
+	if !a.CheckProjectExists("test-ns") {
+		t.Error("CheckProjectExists() = false for an existing namespace")
+	}
+	if a.CheckProjectExists("missing") {
+		t.Error("CheckProjectExists() = true for a missing namespace")
+	}
+}
+
+func TestAPIBackendLinkSecretToSA(t *testing.T) {
+	a, fcs := newAPIBackend(t, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "test-ns"},
+		Secrets:    []corev1.ObjectReference{{Name: "existing"}},
+	})
+	err := testutil.CaptureFailures(func() {
+		a.LinkSecretToSA("git-auth", "pipeline", "test-ns")
+		// like oc secret link, linking twice keeps a single reference
+		a.LinkSecretToSA("git-auth", "pipeline", "test-ns")
+	})
+	if err != nil {
+		t.Fatalf("LinkSecretToSA() failed: %v", err)
+	}
+	sa, err := fcs.Kube.CoreV1().ServiceAccounts("test-ns").Get(t.Context(), "pipeline", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(sa.Secrets); got != "[{  existing    } {  git-auth    }]" {
+		t.Errorf("secrets of the service account = %s, want existing and git-auth", got)
+	}
+
+	if err := testutil.CaptureFailures(func() { a.LinkSecretToSA("git-auth", "missing", "test-ns") }); err == nil {
+		t.Error("LinkSecretToSA() to a missing service account did not fail")
+	}
+}
+
+func TestAPIBackendCopySecret(t *testing.T) {
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-auth-secret", Namespace: "openshift-pipelines", Labels: map[string]string{"app": "resolvers"}},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"github-auth-key": []byte("token"), "other": []byte("value")},
+	}
+	tests := []struct {
+		name     string
+		existing []runtime.Object
+	}{{
+		name: "new secret",
+	}, {
+		name: "existing secret",
+		existing: []runtime.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-auth-secret", Namespace: "test-ns"},
+			Data:       map[string][]byte{"stale": []byte("value")},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fcs := newAPIBackend(t, append(tt.existing, source.DeepCopy())...)
+			if err := testutil.CaptureFailures(func() { a.CopySecret("github-auth-secret", "openshift-pipelines", "test-ns") }); err != nil {
+				t.Fatalf("CopySecret() failed: %v", err)
+			}
+			got, err := fcs.Kube.CoreV1().Secrets("test-ns").Get(t.Context(), "github-auth-secret", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the github-auth-key is renamed to token, like the cli backend does
+			data := map[string]string{}
+			for k, v := range got.Data {
+				data[k] = string(v)
+			}
+			if want := "map[other:value token:token]"; fmt.Sprint(data) != want {
+				t.Errorf("data of the copy = %v, want %s", data, want)
+			}
+			if got.Labels["app"] != "resolvers" {
+				t.Errorf("labels of the copy = %v, want the labels of the source", got.Labels)
+			}
+		})
+	}
+}
+
+func TestAPIBackendUpdateTektonConfigwithInvalidData(t *testing.T) {
+	tektonConfig := &operatorv1alpha1.TektonConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}}
+	tests := []struct {
+		name string
+		// reject is the error of the admission webhook, empty if it
+		// accepts the patch
+		reject       string
+		errorMessage string
+		wantErr      string
+	}{{
+		name:         "rejected",
+		reject:       `admission webhook "webhook.operator.tekton.dev" denied the request: validation failed: invalid value: invalid: spec.pruner.schedule`,
+		errorMessage: "invalid value: invalid",
+	}, {
+		name:         "rejected with another message",
+		reject:       `admission webhook "webhook.operator.tekton.dev" denied the request: validation failed: missing field(s)`,
+		errorMessage: "invalid value: invalid",
+		wantErr:      "Expected error message substring invalid value: invalid",
+	}, {
+		name:         "accepted",
+		errorMessage: "invalid value: invalid",
+		wantErr:      "to be rejected but it was accepted",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, fcs := newAPIBackend(t, tektonConfig.DeepCopy())
+			if tt.reject != "" {
+				fcs.Operator.PrependReactor("patch", "tektonconfigs", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewBadRequest(tt.reject)
+				})
+			}
+			err := testutil.CaptureFailures(func() {
+				a.UpdateTektonConfigwithInvalidData(`{"spec":{"pruner":{"schedule":"invalid"}}}`, tt.errorMessage)
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("UpdateTektonConfigwithInvalidData() failed: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("UpdateTektonConfigwithInvalidData() failed with %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIBackendUpdateTektonConfig(t *testing.T) {
+	a, fcs := newAPIBackend(t, &operatorv1alpha1.TektonConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}})
+	if err := testutil.CaptureFailures(func() { a.UpdateTektonConfig(`{"spec":{"targetNamespace":"openshift-pipelines"}}`) }); err != nil {
+		t.Fatalf("UpdateTektonConfig() failed: %v", err)
+	}
+	got, err := fcs.Operator.OperatorV1alpha1().TektonConfigs().Get(t.Context(), "config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.TargetNamespace != "openshift-pipelines" {
+		t.Errorf("targetNamespace = %q, want the patched openshift-pipelines", got.Spec.TargetNamespace)
+	}
+
+	// like oc patch, a missing TektonConfig fails the step
+	a, _ = newAPIBackend(t)
+	if err := testutil.CaptureFailures(func() { a.UpdateTektonConfig(`{"spec":{}}`) }); err == nil {
+		t.Error("UpdateTektonConfig() of a missing TektonConfig did not fail")
+	}
+}
@@ -0,0 +1,96 @@
+package oc
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+)
+
+const (
+	// CLIBackend runs every operation through the oc binary
+	CLIBackend = "cli"
+	// APIBackend runs every operation through the Kubernetes API using client-go
+	APIBackend = "api"
+)
+
+// Backend implements the operations exposed by this package.
+// The package level functions delegate to the backend selected with
+// the ocbackend flag (OC_BACKEND environment variable), or set with SetBackend.
+type Backend interface {
+	Create(pathDir, namespace string)
+	CreateRemote(remotePath, namespace string)
+	Apply(pathDir, namespace string)
+	Delete(pathDir, namespace string)
+	CreateNewProject(ns string)
+	DeleteProject(ns string)
+	DeleteProjectIgnoreErors(ns string)
+	LinkSecretToSA(secretname, sa, namespace string)
+	CreateSecretWithSecretToken(secretname, namespace string)
+	EnableTLSConfigForEventlisteners(namespace string)
+	VerifyKubernetesEventsForEventListener(namespace string)
+	UpdateTektonConfig(patchData string)
+	UpdateTektonConfigwithInvalidData(patchData, errorMessage string)
+	AnnotateNamespace(namespace, annotation string)
+	AnnotateNamespaceIgnoreErrors(namespace, annotation string)
+	RemovePrunerConfig()
+	LabelNamespace(namespace, label string)
+	DeleteResource(resourceType, name string)
+	DeleteResourceInNamespace(resourceType, name, namespace string)
+	CheckProjectExists(projectName string) bool
+	SecretExists(secretName, namespace string) bool
+	CreateSecretForGitResolver(secretData string)
+	CreateSecretInNamespace(secretData, secretName, namespace string)
+	CreateSecretForWebhook(tokenSecretData, webhookSecretData, namespace string)
+	EnableConsolePlugin()
+	GetSecretsData(secretName, namespace string) string
+	CreateChainsImageRegistrySecret(dockerConfig string)
+	CopySecret(secretName, sourceNamespace, destNamespace string)
+}
+
+var (
+	backendMu      sync.Mutex
+	currentBackend Backend
+)
+
+// SetBackend replaces the backend used by the package level functions
+// and returns the previous one (nil if none was selected yet).
+func SetBackend(b Backend) Backend {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	prev := currentBackend
+	currentBackend = b
+	return prev
+}
+
+func backend() Backend {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if currentBackend == nil {
+		b, err := newBackend(config.Flags.OcBackend)
+		if err != nil {
+			reporter.Default().Fail(err)
+		}
+		currentBackend = b
+	}
+	return currentBackend
+}
+
+func newBackend(name string) (Backend, error) {
+	switch name {
+	case "", CLIBackend:
+		return NewCLIBackend(), nil
+	case APIBackend:
+		cs, err := clients.NewClients(config.Flags.Kubeconfig, config.Flags.Cluster, config.TargetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create clients for the %q oc backend: %v", name, err)
+		}
+		log.Printf("Using the %q backend for oc operations", name)
+		return NewAPIBackend(cs), nil
+	default:
+		return nil, fmt.Errorf("unknown oc backend %q, expected %q or %q", name, CLIBackend, APIBackend)
+	}
+}
@@ -0,0 +1,210 @@
+package oc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/cmd"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cliBackend runs every operation through the oc binary
+type cliBackend struct{}
+
+// NewCLIBackend returns the Backend that shells out to oc
+func NewCLIBackend() Backend {
+	return cliBackend{}
+}
+
+// Create resources using oc command
+func (cliBackend) Create(path_dir, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+}
+
+// Create resources using remote path using oc command
+func (cliBackend) CreateRemote(remote_path, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "-f", remote_path, "-n", namespace).Stdout())
+}
+
+func (cliBackend) Apply(path_dir, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "apply", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+}
+
+// Delete resources using oc command
+func (cliBackend) Delete(path_dir, namespace string) {
+	// Tekton Results sets a finalizer that prevent resource removal for some time
+	// see parameters "store_deadline" and "forward_buffer"
+	// by default, it waits at least 150 seconds
+	log.Printf("output: %s\n", cmd.MustSuccedIncreasedTimeout(time.Second*300, "oc", "delete", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+}
+
+// CreateNewProject Helps you to create new project
+func (cliBackend) CreateNewProject(ns string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "new-project", ns).Stdout())
+}
+
+// DeleteProject Helps you to delete new project
+func (cliBackend) DeleteProject(ns string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "delete", "project", ns).Stdout())
+}
+
+func (cliBackend) DeleteProjectIgnoreErors(ns string) {
+	log.Printf("output: %s\n", cmd.Run("oc", "delete", "project", ns).Stdout())
+}
+
+func (cliBackend) LinkSecretToSA(secretname, sa, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "secret", "link", "serviceaccount/"+sa, "secrets/"+secretname, "-n", namespace).Stdout())
+}
+
+func (cliBackend) CreateSecretWithSecretToken(secretname, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "secret", "generic", secretname, "--from-literal=secretToken="+config.TriggersSecretToken, "-n", namespace).Stdout())
+}
+
+func (cliBackend) EnableTLSConfigForEventlisteners(namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "label", "namespace", namespace, "operator.tekton.dev/enable-annotation=enabled").Stdout())
+}
+
+func (cliBackend) VerifyKubernetesEventsForEventListener(namespace string) {
+	result := cmd.Run("oc", "-n", namespace, "get", "events")
+	startedEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.started.v1")
+	successfulEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.successful.v1")
+	doneEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.done.v1")
+	if !startedEvent || !successfulEvent || !doneEvent {
+		reporter.Default().Errorf("No events for successful, done and started")
+	}
+}
+
+func (cliBackend) UpdateTektonConfig(patch_data string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "patch", "tektonconfig", "config", "-p", patch_data, "--type=merge").Stdout())
+}
+
+func (cliBackend) UpdateTektonConfigwithInvalidData(patch_data, errorMessage string) {
+	result := cmd.Run("oc", "patch", "tektonconfig", "config", "-p", patch_data, "--type=merge")
+	log.Printf("Output: %s\n", result.Stdout())
+	if result.ExitCode != 1 {
+		reporter.Default().Errorf("Expected exit code 1 but got %v", result.ExitCode)
+	}
+	if !strings.Contains(result.Stderr(), errorMessage) {
+		reporter.Default().Errorf("Expected error message substring %v in %v", errorMessage, result.Stderr())
+	}
+}
+
+func (cliBackend) AnnotateNamespace(namespace, annotation string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "annotate", "namespace", namespace, annotation).Stdout())
+}
+
+func (cliBackend) AnnotateNamespaceIgnoreErrors(namespace, annotation string) {
+	log.Printf("output: %s\n", cmd.Run("oc", "annotate", "namespace", namespace, annotation).Stdout())
+}
+
+func (cliBackend) RemovePrunerConfig() {
+	cmd.Run("oc", "patch", "tektonconfig", "config", "-p", "[{ \"op\": \"remove\", \"path\": \"/spec/pruner\" }]", "--type=json")
+}
+
+func (cliBackend) LabelNamespace(namespace, label string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "label", "namespace", namespace, label).Stdout())
+}
+
+func (cliBackend) DeleteResource(resourceType, name string) {
+	// Tekton Results sets a finalizer that prevent resource removal for some time
+	// see parameters "store_deadline" and "forward_buffer"
+	// by default, it waits at least 150 seconds
+	log.Printf("output: %s\n", cmd.MustSuccedIncreasedTimeout(time.Second*300, "oc", "delete", resourceType, name, "-n", store.Namespace()).Stdout())
+}
+
+func (cliBackend) DeleteResourceInNamespace(resourceType, name, namespace string) {
+	log.Printf("output: %s\n", cmd.MustSucceed("oc", "delete", resourceType, name, "-n", namespace).Stdout())
+}
+
+func (cliBackend) CheckProjectExists(projectName string) bool {
+	commandResult := cmd.Run("oc", "project", projectName)
+	return commandResult.ExitCode == 0 && !strings.Contains(commandResult.String(), "error")
+}
+
+func (cliBackend) SecretExists(secretName string, namespace string) bool {
+	return !strings.Contains(cmd.Run("oc", "get", "secret", secretName, "-n", namespace).String(), "Error")
+}
+
+func (cliBackend) CreateSecretForGitResolver(secretData string) {
+	cmd.MustSucceed("oc", "create", "secret", "generic", "github-auth-secret", "--from-literal", "github-auth-key="+secretData, "-n", "openshift-pipelines")
+}
+
+func (cliBackend) CreateSecretInNamespace(secretData, secretName, namespace string) {
+	cmd.MustSucceed("oc", "create", "secret", "generic", secretName, "--from-literal", "private-repo-token="+secretData, "-n", namespace)
+}
+
+func (cliBackend) CreateSecretForWebhook(tokenSecretData, webhookSecretData, namespace string) {
+	cmd.MustSucceed("oc", "create", "secret", "generic", "gitlab-webhook-config", "--from-literal", "provider.token="+tokenSecretData, "--from-literal", "webhook.secret="+webhookSecretData, "-n", namespace)
+}
+
+func (cliBackend) EnableConsolePlugin() {
+	json_output := cmd.MustSucceed("oc", "get", "consoles.operator.openshift.io", "cluster", "-o", "jsonpath={.spec.plugins}").Stdout()
+	log.Printf("Already enabled console plugins: %s", json_output)
+	var plugins []string
+
+	if len(json_output) > 0 {
+		err := json.Unmarshal([]byte(json_output), &plugins)
+
+		if err != nil {
+			reporter.Default().Errorf("Could not parse consoles.operator.openshift.io CR: %v", err)
+		}
+
+		if slices.Contains(plugins, config.ConsolePluginDeployment) {
+			log.Printf("Pipelines console plugin is already enabled.")
+			return
+		}
+	}
+
+	plugins = append(plugins, config.ConsolePluginDeployment)
+
+	patch_data := "{\"spec\":{\"plugins\":[\"" + strings.Join(plugins, "\",\"") + "\"]}}"
+	cmd.MustSucceed("oc", "patch", "consoles.operator.openshift.io", "cluster", "-p", patch_data, "--type=merge").Stdout()
+}
+
+func (cliBackend) GetSecretsData(secretName, namespace string) string {
+	return cmd.MustSucceed("oc", "get", "secrets", secretName, "-n", namespace, "-o", "jsonpath=\"{.data}\"").Stdout()
+}
+
+func (cliBackend) CreateChainsImageRegistrySecret(dockerConfig string) {
+	cmd.MustSucceed("oc", "create", "secret", "generic", "chains-image-registry-credentials", "--from-literal=.dockerconfigjson="+dockerConfig, "--from-literal=config.json="+dockerConfig, "--type=kubernetes.io/dockerconfigjson")
+}
+
+// CopySecret copies the secret like the api backend, renaming its
+// github-auth-key to token. The manifest is passed on stdin so that the
+// secret data is not part of the recorded command lines.
+func (cliBackend) CopySecret(secretName string, sourceNamespace string, destNamespace string) {
+	secretJson := cmd.MustSucceed("oc", "get", "secret", secretName, "-n", sourceNamespace, "-o", "json").Stdout()
+	var source corev1.Secret
+	if err := json.Unmarshal([]byte(secretJson), &source); err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to parse secret %s in namespace %s: %v", secretName, sourceNamespace, err))
+		return
+	}
+	data := make(map[string][]byte, len(source.Data))
+	for k, v := range source.Data {
+		if k == "github-auth-key" {
+			k = "token"
+		}
+		data[k] = v
+	}
+	manifest, err := json.Marshal(corev1.Secret{
+		TypeMeta:   source.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Labels: source.Labels},
+		Type:       source.Type,
+		Data:       data,
+	})
+	if err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to marshal secret %s: %v", secretName, err))
+		return
+	}
+	cmd.MustSucceedWithStdin(bytes.NewReader(manifest), "oc", "apply", "-n", destNamespace, "-f", "-")
+	log.Printf("Successfully copied secret %s from %s to %s", secretName, sourceNamespace, destNamespace)
+}
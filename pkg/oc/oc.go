@@ -1,175 +1,124 @@
 package oc
 
-import (
-	"encoding/json"
-	"fmt"
-	"log"
-	"slices"
-	"strings"
-	"time"
-
-	"github.com/getgauge-contrib/gauge-go/testsuit"
-	"github.com/openshift-pipelines/release-tests/pkg/cmd"
-	"github.com/openshift-pipelines/release-tests/pkg/config"
-	"github.com/openshift-pipelines/release-tests/pkg/store"
-)
+import "github.com/openshift-pipelines/release-tests/pkg/lease"
 
 // Create resources using oc command
 func Create(path_dir, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+	backend().Create(path_dir, namespace)
 }
 
 // Create resources using remote path using oc command
 func CreateRemote(remote_path, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "-f", remote_path, "-n", namespace).Stdout())
+	backend().CreateRemote(remote_path, namespace)
 }
 
 func Apply(path_dir, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "apply", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+	backend().Apply(path_dir, namespace)
 }
 
 // Delete resources using oc command
 func Delete(path_dir, namespace string) {
-	// Tekton Results sets a finalizer that prevent resource removal for some time
-	// see parameters "store_deadline" and "forward_buffer"
-	// by default, it waits at least 150 seconds
-	log.Printf("output: %s\n", cmd.MustSuccedIncreasedTimeout(time.Second*300, "oc", "delete", "-f", config.Path(path_dir), "-n", namespace).Stdout())
+	backend().Delete(path_dir, namespace)
 }
 
 // CreateNewProject Helps you to create new project
 func CreateNewProject(ns string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "new-project", ns).Stdout())
+	backend().CreateNewProject(ns)
 }
 
 // DeleteProject Helps you to delete new project
 func DeleteProject(ns string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "delete", "project", ns).Stdout())
+	backend().DeleteProject(ns)
 }
 
 func DeleteProjectIgnoreErors(ns string) {
-	log.Printf("output: %s\n", cmd.Run("oc", "delete", "project", ns).Stdout())
+	backend().DeleteProjectIgnoreErors(ns)
 }
 
 func LinkSecretToSA(secretname, sa, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "secret", "link", "serviceaccount/"+sa, "secrets/"+secretname, "-n", namespace).Stdout())
+	backend().LinkSecretToSA(secretname, sa, namespace)
 }
 
 func CreateSecretWithSecretToken(secretname, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "create", "secret", "generic", secretname, "--from-literal=secretToken="+config.TriggersSecretToken, "-n", namespace).Stdout())
+	backend().CreateSecretWithSecretToken(secretname, namespace)
 }
 
 func EnableTLSConfigForEventlisteners(namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "label", "namespace", namespace, "operator.tekton.dev/enable-annotation=enabled").Stdout())
+	backend().EnableTLSConfigForEventlisteners(namespace)
 }
 
 func VerifyKubernetesEventsForEventListener(namespace string) {
-	result := cmd.Run("oc", "-n", namespace, "get", "events")
-	startedEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.started.v1")
-	successfulEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.successful.v1")
-	doneEvent := strings.Contains(result.String(), "dev.tekton.event.triggers.done.v1")
-	if !startedEvent || !successfulEvent || !doneEvent {
-		testsuit.T.Errorf("No events for successful, done and started")
-	}
+	backend().VerifyKubernetesEventsForEventListener(namespace)
 }
 
 func UpdateTektonConfig(patch_data string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "patch", "tektonconfig", "config", "-p", patch_data, "--type=merge").Stdout())
+	defer lease.LockCluster()()
+	backend().UpdateTektonConfig(patch_data)
 }
 
 func UpdateTektonConfigwithInvalidData(patch_data, errorMessage string) {
-	result := cmd.Run("oc", "patch", "tektonconfig", "config", "-p", patch_data, "--type=merge")
-	log.Printf("Output: %s\n", result.Stdout())
-	if result.ExitCode != 1 {
-		testsuit.T.Errorf("Expected exit code 1 but got %v", result.ExitCode)
-	}
-	if !strings.Contains(result.Stderr(), errorMessage) {
-		testsuit.T.Errorf("Expected error message substring %v in %v", errorMessage, result.Stderr())
-	}
+	defer lease.LockCluster()()
+	backend().UpdateTektonConfigwithInvalidData(patch_data, errorMessage)
 }
 
 func AnnotateNamespace(namespace, annotation string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "annotate", "namespace", namespace, annotation).Stdout())
+	backend().AnnotateNamespace(namespace, annotation)
 }
 
 func AnnotateNamespaceIgnoreErrors(namespace, annotation string) {
-	log.Printf("output: %s\n", cmd.Run("oc", "annotate", "namespace", namespace, annotation).Stdout())
+	backend().AnnotateNamespaceIgnoreErrors(namespace, annotation)
 }
 
 func RemovePrunerConfig() {
-	cmd.Run("oc", "patch", "tektonconfig", "config", "-p", "[{ \"op\": \"remove\", \"path\": \"/spec/pruner\" }]", "--type=json")
+	defer lease.LockCluster()()
+	backend().RemovePrunerConfig()
 }
 
 func LabelNamespace(namespace, label string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "label", "namespace", namespace, label).Stdout())
+	backend().LabelNamespace(namespace, label)
 }
 
 func DeleteResource(resourceType, name string) {
-	// Tekton Results sets a finalizer that prevent resource removal for some time
-	// see parameters "store_deadline" and "forward_buffer"
-	// by default, it waits at least 150 seconds
-	log.Printf("output: %s\n", cmd.MustSuccedIncreasedTimeout(time.Second*300, "oc", "delete", resourceType, name, "-n", store.Namespace()).Stdout())
+	backend().DeleteResource(resourceType, name)
 }
 
 func DeleteResourceInNamespace(resourceType, name, namespace string) {
-	log.Printf("output: %s\n", cmd.MustSucceed("oc", "delete", resourceType, name, "-n", namespace).Stdout())
+	backend().DeleteResourceInNamespace(resourceType, name, namespace)
 }
 
 func CheckProjectExists(projectName string) bool {
-	commandResult := cmd.Run("oc", "project", projectName)
-	return commandResult.ExitCode == 0 && !strings.Contains(commandResult.String(), "error")
+	return backend().CheckProjectExists(projectName)
 }
 
 func SecretExists(secretName string, namespace string) bool {
-	return !strings.Contains(cmd.Run("oc", "get", "secret", secretName, "-n", namespace).String(), "Error")
+	return backend().SecretExists(secretName, namespace)
 }
 
 func CreateSecretForGitResolver(secretData string) {
-	cmd.MustSucceed("oc", "create", "secret", "generic", "github-auth-secret", "--from-literal", "github-auth-key="+secretData, "-n", "openshift-pipelines")
+	backend().CreateSecretForGitResolver(secretData)
 }
 
 func CreateSecretInNamespace(secretData, secretName, namespace string) {
-	cmd.MustSucceed("oc", "create", "secret", "generic", secretName, "--from-literal", "private-repo-token="+secretData, "-n", namespace)
+	backend().CreateSecretInNamespace(secretData, secretName, namespace)
 }
 
 func CreateSecretForWebhook(tokenSecretData, webhookSecretData, namespace string) {
-	cmd.MustSucceed("oc", "create", "secret", "generic", "gitlab-webhook-config", "--from-literal", "provider.token="+tokenSecretData, "--from-literal", "webhook.secret="+webhookSecretData, "-n", namespace)
+	backend().CreateSecretForWebhook(tokenSecretData, webhookSecretData, namespace)
 }
 
 func EnableConsolePlugin() {
-	json_output := cmd.MustSucceed("oc", "get", "consoles.operator.openshift.io", "cluster", "-o", "jsonpath={.spec.plugins}").Stdout()
-	log.Printf("Already enabled console plugins: %s", json_output)
-	var plugins []string
-
-	if len(json_output) > 0 {
-		err := json.Unmarshal([]byte(json_output), &plugins)
-
-		if err != nil {
-			testsuit.T.Errorf("Could not parse consoles.operator.openshift.io CR: %v", err)
-		}
-
-		if slices.Contains(plugins, config.ConsolePluginDeployment) {
-			log.Printf("Pipelines console plugin is already enabled.")
-			return
-		}
-	}
-
-	plugins = append(plugins, config.ConsolePluginDeployment)
-
-	patch_data := "{\"spec\":{\"plugins\":[\"" + strings.Join(plugins, "\",\"") + "\"]}}"
-	cmd.MustSucceed("oc", "patch", "consoles.operator.openshift.io", "cluster", "-p", patch_data, "--type=merge").Stdout()
+	defer lease.LockCluster()()
+	backend().EnableConsolePlugin()
 }
 
 func GetSecretsData(secretName, namespace string) string {
-	return cmd.MustSucceed("oc", "get", "secrets", secretName, "-n", namespace, "-o", "jsonpath=\"{.data}\"").Stdout()
+	return backend().GetSecretsData(secretName, namespace)
 }
 
 func CreateChainsImageRegistrySecret(dockerConfig string) {
-	cmd.MustSucceed("oc", "create", "secret", "generic", "chains-image-registry-credentials", "--from-literal=.dockerconfigjson="+dockerConfig, "--from-literal=config.json="+dockerConfig, "--type=kubernetes.io/dockerconfigjson")
+	backend().CreateChainsImageRegistrySecret(dockerConfig)
 }
 
 func CopySecret(secretName string, sourceNamespace string, destNamespace string) {
-	secretJson := cmd.MustSucceed("oc", "get", "secret", secretName, "-n", sourceNamespace, "-o", "json").Stdout()
-	cmdOutput := cmd.MustSucceed("bash", "-c", fmt.Sprintf(`echo '%s' | jq 'del(.metadata["namespace", "creationTimestamp", "resourceVersion", "selfLink", "uid", "annotations"]) | .data |= with_entries(if .key == "github-auth-key" then .key = "token" else . end)'`, secretJson)).Stdout()
-	cmd.MustSucceed("bash", "-c", fmt.Sprintf(`echo '%s' | kubectl apply -n %s -f -`, cmdOutput, destNamespace))
-	log.Printf("Successfully copied secret %s from %s to %s", secretName, sourceNamespace, destNamespace)
+	backend().CopySecret(secretName, sourceNamespace, destNamespace)
 }
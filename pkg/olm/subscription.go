@@ -6,12 +6,11 @@ import (
 	"html/template"
 	"log"
 	"os"
-	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,10 +18,6 @@ import (
 )
 
 const (
-	// Interval specifies the time between two polls.
-	Interval = 10 * time.Second
-	// Timeout specifies the timeout for the function PollImmediate to reach a certain status.
-	Timeout            = 8 * time.Minute
 	OperatorsNamespace = "openshift-operators"
 	OLMNamespace       = "openshift-marketplace"
 )
@@ -65,7 +60,7 @@ func UptadeSubscriptionAndWaitForOperatorToBeReady(cs *clients.Clients, subscrip
 func getSubcription(cs *clients.Clients, name string) *v1alpha1.Subscription {
 	subscription, err := cs.OLM.OperatorsV1alpha1().Subscriptions(OperatorsNamespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get subscription %s in namespace %s \n %v", name, OperatorsNamespace, err)
+		cs.Reporter().Errorf("failed to get subscription %s in namespace %s \n %v", name, OperatorsNamespace, err)
 	}
 	return subscription
 }
@@ -86,30 +81,30 @@ func createSubscription(name, channel, catalogsource string) {
 	}
 
 	if _, err := config.TempDir(); err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 	defer config.RemoveTempDir()
 
 	tmpl, err := config.Read("subscription.yaml.tmp")
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	sub, err := template.New("subscription").Parse(string(tmpl))
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	var buffer bytes.Buffer
 	if err = sub.Execute(&buffer, subscription); err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 	file, err := config.TempFile("subscription.yaml")
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 	if err = os.WriteFile(file, buffer.Bytes(), 0600); err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	log.Printf("output: %s\n", cmd.MustSucceed("oc", "apply", "-f", file).Stdout())
@@ -122,7 +117,7 @@ func OperatorCleanup(cs *clients.Clients, name string) {
 	// Delete CSV
 	err := cs.OLM.OperatorsV1alpha1().ClusterServiceVersions(OperatorsNamespace).DeleteCollection(context.Background(), metav1.DeleteOptions{}, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to delete CSVs in namespace %s \n %v", OperatorsNamespace, err)
+		cs.Reporter().Errorf("failed to delete CSVs in namespace %s \n %v", OperatorsNamespace, err)
 	}
 
 	log.Printf("Output %s \n", cmd.MustSucceed(
@@ -144,7 +139,7 @@ func UpdateSubscription(cs *clients.Clients, name, channel string) (*v1alpha1.Su
 func WaitForSubscriptionState(cs *clients.Clients, name, namespace string, inState func(s *v1alpha1.Subscription, err error) (bool, error)) (*v1alpha1.Subscription, error) {
 	var lastState *v1alpha1.Subscription
 	var err error
-	waitErr := wait.PollUntilContextTimeout(cs.Ctx, Interval, Timeout, true, func(context.Context) (bool, error) {
+	waitErr := wait.PollUntilContextTimeout(cs.Ctx, config.OLMInterval, config.OLMTimeout, true, func(context.Context) (bool, error) {
 		lastState, err = cs.OLM.OperatorsV1alpha1().Subscriptions(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		return inState(lastState, err)
 	})
@@ -158,7 +153,7 @@ func WaitForSubscriptionState(cs *clients.Clients, name, namespace string, inSta
 func WaitForClusterServiceVersionState(cs *clients.Clients, name, namespace string, inState func(s *v1alpha1.ClusterServiceVersion, err error) (bool, error)) (*v1alpha1.ClusterServiceVersion, error) {
 	var lastState *v1alpha1.ClusterServiceVersion
 	var err error
-	waitErr := wait.PollUntilContextTimeout(cs.Ctx, Interval, Timeout, true, func(context.Context) (bool, error) {
+	waitErr := wait.PollUntilContextTimeout(cs.Ctx, config.OLMInterval, config.OLMTimeout, true, func(context.Context) (bool, error) {
 		lastState, err = cs.OLM.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		return inState(lastState, err)
 	})
@@ -10,8 +10,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"gotest.tools/v3/icmd"
 )
 
@@ -55,7 +55,7 @@ func GetOPCServerVersion(component string) string {
 	}
 
 	if strings.Contains(version, "unknown") {
-		testsuit.T.Errorf("%s is not installed", titleComp)
+		reporter.Default().Errorf("%s is not installed", titleComp)
 	}
 	return version
 }
@@ -79,12 +79,12 @@ func AssertComponentVersion(version string, component string) {
 	case "pruner":
 		actualVersion = cmd.MustSucceed("oc", "get", "tektonpruner", "pruner", "-o", "jsonpath={.status.version}").Stdout()
 	default:
-		testsuit.T.Errorf("Unknown component")
+		reporter.Default().Errorf("Unknown component")
 	}
 
 	actualVersion = strings.Trim(actualVersion, "\n")
 	if !strings.Contains(actualVersion, version) {
-		testsuit.T.Errorf("The %s has an unexpected version: %s, expected: %s", component, actualVersion, version)
+		reporter.Default().Errorf("The %s has an unexpected version: %s, expected: %s", component, actualVersion, version)
 	}
 }
 
@@ -93,7 +93,7 @@ func DownloadCLIFromCluster() {
 	var cliDownloadURL = cmd.MustSucceed("oc", "get", "consoleclidownloads", "tkn", "-o", "jsonpath={.spec.links[?(@.text==\"Download tkn and tkn-pac for "+architecture+"\")].href}").Stdout()
 	result := cmd.MustSuccedIncreasedTimeout(time.Minute*10, "curl", "-o", "/tmp/tkn-binary.tar.gz", "-k", cliDownloadURL)
 	if result.ExitCode != 0 {
-		testsuit.T.Errorf("%s", result.Stderr())
+		reporter.Default().Errorf("%s", result.Stderr())
 	}
 	cmd.MustSucceed("tar", "-xf", "/tmp/tkn-binary.tar.gz", "-C", "/tmp")
 }
@@ -106,7 +106,7 @@ func AssertClientVersion(binary string) {
 		commandResult = cmd.MustSucceed("/tmp/tkn-pac", "version").Stdout()
 		expectedVersion := os.Getenv("PAC_VERSION")
 		if !strings.Contains(commandResult, expectedVersion) {
-			testsuit.T.Errorf("tkn-pac has an unexpected version: %s. Expected: %s", commandResult, expectedVersion)
+			reporter.Default().Errorf("tkn-pac has an unexpected version: %s. Expected: %s", commandResult, expectedVersion)
 		}
 
 	case "tkn":
@@ -117,7 +117,7 @@ func AssertClientVersion(binary string) {
 			if strings.Contains(splittedCommandResult[i], "Client") {
 				if !strings.Contains(splittedCommandResult[i], expectedVersion) {
 					unexpectedVersion = splittedCommandResult[i]
-					testsuit.T.Errorf("tkn client has an unexpected version: %s. Expected: %s", unexpectedVersion, expectedVersion)
+					reporter.Default().Errorf("tkn client has an unexpected version: %s. Expected: %s", unexpectedVersion, expectedVersion)
 				}
 			}
 		}
@@ -131,13 +131,13 @@ func AssertClientVersion(binary string) {
 			if strings.Contains(splittedCommandResult[i], components[i]) {
 				if !strings.Contains(splittedCommandResult[i], expectedVersions[i]) {
 					unexpectedVersion = splittedCommandResult[i]
-					testsuit.T.Errorf("%s has an unexpected version: %s. Expected: %s", components[i], unexpectedVersion, expectedVersions[i])
+					reporter.Default().Errorf("%s has an unexpected version: %s. Expected: %s", components[i], unexpectedVersion, expectedVersions[i])
 				}
 			}
 		}
 
 	default:
-		testsuit.T.Errorf("Unknown binary or client")
+		reporter.Default().Errorf("Unknown binary or client")
 	}
 }
 
@@ -154,12 +154,12 @@ func AssertServerVersion(binary string) {
 			if strings.Contains(splittedCommandResult[i], components[i]) {
 				if !strings.Contains(splittedCommandResult[i], expectedVersions[i]) {
 					unexpectedVersion = splittedCommandResult[i]
-					testsuit.T.Errorf("%s has an unexpected version: %s. Expected: %s", components[i], unexpectedVersion, expectedVersions[i])
+					reporter.Default().Errorf("%s has an unexpected version: %s. Expected: %s", components[i], unexpectedVersion, expectedVersions[i])
 				}
 			}
 		}
 	default:
-		testsuit.T.Errorf("Unknown binary or client")
+		reporter.Default().Errorf("Unknown binary or client")
 	}
 
 }
@@ -273,7 +273,7 @@ func HubSearch(resource string) error {
 func GetOpcPrList(pipelineRunName, namespace string) ([]PipelineRunList, error) {
 	result, err := VerifyResourceListMatchesName("pipelinerun", pipelineRunName, namespace)
 	if err != nil {
-		testsuit.T.Errorf("Failed to get pipelinerun list: %v", err)
+		reporter.Default().Errorf("Failed to get pipelinerun list: %v", err)
 	}
 	output := strings.TrimSpace(result)
 	lines := strings.Split(output, "\n")
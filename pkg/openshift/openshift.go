@@ -4,9 +4,9 @@ import (
 	"context"
 	"log"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
 	imageStream "github.com/openshift/client-go/image/clientset/versioned"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,28 +39,24 @@ func VerifyImageStreamExists(c *clients.Clients, name, namespace string) {
 		}
 		return true, err
 	}); err != nil {
-		testsuit.T.Errorf("Failed to get image stream %q in namespace %q for tests: %s", name, namespace, err)
+		c.Reporter().Errorf("Failed to get image stream %q in namespace %q for tests: %s", name, namespace, err)
 	}
 }
 
+// IsCapabilityEnabled tells whether an optional capability of the cluster is
+// enabled, from the ClusterVersion on OpenShift and by API discovery elsewhere
 func IsCapabilityEnabled(c *clients.Clients, name string) bool {
-	cv, err := c.ClusterVersion.Get(c.Ctx, "version", metav1.GetOptions{})
+	enabled, err := platform.Current().IsCapabilityEnabled(c, name)
 	if err != nil {
-		testsuit.T.Fail(err)
-	}
-
-	for _, capability := range cv.Status.Capabilities.EnabledCapabilities {
-		if string(capability) == name {
-			return true
-		}
+		c.Reporter().Fail(err)
 	}
-	return false
+	return enabled
 }
 
 func GetOpenShiftVersion(c *clients.Clients) string {
 	cv, err := c.ClusterVersion.Get(c.Ctx, "version", metav1.GetOptions{})
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 		return ""
 	}
 
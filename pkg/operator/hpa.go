@@ -0,0 +1,151 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	"github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// hpaComponents are the TektonConfig components whose options may hold HorizontalPodAutoscalers
+var hpaComponents = map[string]func(*v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions{
+	"pipeline":  func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Pipeline.Options },
+	"trigger":   func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Trigger.Options },
+	"chain":     func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Chain.Options },
+	"result":    func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Result.Options },
+	"hub":       func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Hub.Options },
+	"dashboard": func(s *v1alpha1.TektonConfigSpec) v1alpha1.AdditionalOptions { return s.Dashboard.Options },
+}
+
+// GetHPAForDeployment returns the HorizontalPodAutoscaler scaling the deployment
+func GetHPAForDeployment(c *clients.Clients, deployment, namespace string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas, err := c.KubeClient.Kube.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HorizontalPodAutoscalers in namespace %s: %v", namespace, err)
+	}
+	for i := range hpas.Items {
+		ref := hpas.Items[i].Spec.ScaleTargetRef
+		if ref.Kind == "Deployment" && ref.Name == deployment {
+			return &hpas.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no HorizontalPodAutoscaler scales deployment %s in namespace %s", deployment, namespace)
+}
+
+// AssertHPAExists verifies that a HorizontalPodAutoscaler scales the deployment
+func AssertHPAExists(c *clients.Clients, deployment, namespace string) {
+	hpa, err := GetHPAForDeployment(c, deployment, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	log.Printf("HorizontalPodAutoscaler %s scales deployment %s between %d and %d replicas", hpa.Name, deployment, minReplicas(hpa.Spec), hpa.Spec.MaxReplicas)
+}
+
+// UpdateHPAOptions sets the minimum and maximum replicas of the HorizontalPodAutoscaler
+// called hpaName in the options of a TektonConfig component (e.g. "pipeline")
+func UpdateHPAOptions(component, hpaName string, min, max int32) {
+	patch := fmt.Sprintf(`{"spec":{"%s":{"options":{"horizontalPodAutoscalers":{"%s":{"spec":{"minReplicas":%d,"maxReplicas":%d}}}}}}}`,
+		component, hpaName, min, max)
+	oc.UpdateTektonConfig(patch)
+}
+
+// AssertHPAMatchesTektonConfig waits for the HorizontalPodAutoscaler scaling
+// the deployment to have the minimum and maximum replicas set in the options
+// of TektonConfig, which must configure it
+func AssertHPAMatchesTektonConfig(c *clients.Clients, deployment, namespace string) {
+	hpa, err := GetHPAForDeployment(c, deployment, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	tc, err := c.TektonConfig().Get(c.Ctx, v1alpha1.ConfigResourceName, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get TektonConfig: %v", err))
+		return
+	}
+	options, component, found := hpaOptions(&tc.Spec, hpa.Name)
+	if !found {
+		c.Reporter().Fail(fmt.Errorf("TektonConfig has no options for HorizontalPodAutoscaler %s", hpa.Name))
+		return
+	}
+	log.Printf("HorizontalPodAutoscaler %s is configured in the %s options of TektonConfig", hpa.Name, component)
+
+	err = wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		hpa, err = GetHPAForDeployment(c, deployment, namespace)
+		if err != nil {
+			return false, err
+		}
+		if options.Spec.MinReplicas != nil && minReplicas(hpa.Spec) != *options.Spec.MinReplicas {
+			log.Printf("Waiting for min replicas of HorizontalPodAutoscaler %s: %d, expected %d", hpa.Name, minReplicas(hpa.Spec), *options.Spec.MinReplicas)
+			return false, nil
+		}
+		if options.Spec.MaxReplicas > 0 && hpa.Spec.MaxReplicas != options.Spec.MaxReplicas {
+			log.Printf("Waiting for max replicas of HorizontalPodAutoscaler %s: %d, expected %d", hpa.Name, hpa.Spec.MaxReplicas, options.Spec.MaxReplicas)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("HorizontalPodAutoscaler %s does not match the options of TektonConfig: %v", hpa.Name, err))
+	}
+}
+
+// AssertHPAConvergesTo waits for the HorizontalPodAutoscaler scaling the
+// deployment to settle on replicas, for the deployment to be scaled to them
+// and to have them available
+func AssertHPAConvergesTo(c *clients.Clients, deployment, namespace string, replicas int32) {
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		hpa, err := GetHPAForDeployment(c, deployment, namespace)
+		if err != nil {
+			return false, err
+		}
+		if hpa.Status.DesiredReplicas != replicas || hpa.Status.CurrentReplicas != replicas {
+			log.Printf("Waiting for HorizontalPodAutoscaler %s to converge to %d replicas (current %d, desired %d)", hpa.Name, replicas, hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas)
+			return false, nil
+		}
+		dep, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Get(c.Ctx, deployment, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		// the HPA status may predate a scale of the deployment
+		if dep.Spec.Replicas == nil || *dep.Spec.Replicas != replicas {
+			log.Printf("Waiting for HorizontalPodAutoscaler %s to scale deployment %s to %d replicas", hpa.Name, deployment, replicas)
+			return false, nil
+		}
+		if dep.Status.AvailableReplicas != replicas || dep.Status.UnavailableReplicas != 0 {
+			log.Printf("Waiting for full availability of deployment %s (%d/%d)", deployment, dep.Status.AvailableReplicas, replicas)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("deployment %s in namespace %s did not converge to %d replicas: %v", deployment, namespace, replicas, err))
+	}
+}
+
+// hpaOptions returns the options of the HorizontalPodAutoscaler called name
+// and the TektonConfig component holding them
+func hpaOptions(spec *v1alpha1.TektonConfigSpec, name string) (autoscalingv2.HorizontalPodAutoscaler, string, bool) {
+	for component, options := range hpaComponents {
+		if hpa, ok := options(spec).HorizontalPodAutoscalers[name]; ok {
+			return hpa, component, true
+		}
+	}
+	return autoscalingv2.HorizontalPodAutoscaler{}, "", false
+}
+
+// minReplicas returns the minimum replicas of an HPA, 1 when not set
+func minReplicas(spec autoscalingv2.HorizontalPodAutoscalerSpec) int32 {
+	if spec.MinReplicas == nil {
+		return 1
+	}
+	return *spec.MinReplicas
+}
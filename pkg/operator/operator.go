@@ -5,7 +5,6 @@ import (
 	"log"
 	"strings"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
@@ -19,7 +18,7 @@ import (
 
 func WaitForTektonConfigCR(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := EnsureTektonConfigExists(cs.TektonConfig(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonConfig doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("TektonConfig doesn't exists\n %v", err))
 	}
 }
 
@@ -58,7 +57,7 @@ func ValidateCABundleConfigMaps(cs *clients.Clients, rnames utils.ResourceNames)
 
 func ValidatePipelineDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := EnsureTektonPipelineExists(cs.TektonPipeline(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonPipelines doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("TektonPipelines doesn't exists\n %v", err))
 	}
 	k8s.ValidateDeployments(cs, rnames.TargetNamespace,
 		config.PipelineControllerName, config.PipelineWebhookName)
@@ -66,7 +65,7 @@ func ValidatePipelineDeployments(cs *clients.Clients, rnames utils.ResourceNames
 
 func ValidateTriggerDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := EnsureTektonTriggerExists(cs.TektonTrigger(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonTriggers doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("TektonTriggers doesn't exists\n %v", err))
 	}
 	k8s.ValidateDeployments(cs, rnames.TargetNamespace,
 		config.TriggerControllerName, config.TriggerWebhookName)
@@ -74,7 +73,7 @@ func ValidateTriggerDeployments(cs *clients.Clients, rnames utils.ResourceNames)
 
 func ValidateChainsDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := EnsureTektonChainsExists(cs.TektonChains(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonChains doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("TektonChains doesn't exists\n %v", err))
 	}
 	k8s.ValidateDeployments(cs, rnames.TargetNamespace,
 		config.ChainsControllerName)
@@ -82,7 +81,7 @@ func ValidateChainsDeployments(cs *clients.Clients, rnames utils.ResourceNames)
 
 func ValidateHubDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := EnsureTektonHubsExists(cs.TektonHub(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonHub doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("TektonHub doesn't exists\n %v", err))
 	}
 	k8s.ValidateDeployments(cs, rnames.TargetNamespace,
 		config.HubApiName, config.HubDbName, config.HubUiName)
@@ -90,7 +89,7 @@ func ValidateHubDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 
 func ValidateManualApprovalGateDeployments(cs *clients.Clients, rnames utils.ResourceNames) {
 	if _, err := approvalgate.EnsureManualApprovalGateExists(cs.ManualApprovalGate(), rnames); err != nil {
-		testsuit.T.Fail(fmt.Errorf("manual approval gate doesn't exists\n %v", err))
+		cs.Reporter().Fail(fmt.Errorf("manual approval gate doesn't exists\n %v", err))
 	}
 	k8s.ValidateDeployments(cs, rnames.TargetNamespace,
 		config.MAGController, config.MAGWebHook)
@@ -99,7 +98,7 @@ func ValidateManualApprovalGateDeployments(cs *clients.Clients, rnames utils.Res
 func ValidateOperatorInstallStatus(cs *clients.Clients, rnames utils.ResourceNames) {
 	operatorVersion := opc.GetOPCServerVersion("operator")
 	if strings.Contains(operatorVersion, "unknown") {
-		testsuit.T.Errorf("Operator is not installed")
+		cs.Reporter().Errorf("Operator is not installed")
 	}
 	log.Printf("Waiting for operator to be up and running....\n")
 	EnsureTektonConfigStatusInstalled(cs.TektonConfig(), rnames)
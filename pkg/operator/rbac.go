@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
 	scc "github.com/openshift/client-go/security/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -28,7 +28,7 @@ func AssertServiceAccountPresent(clients *clients.Clients, ns, targetSA string)
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Service account %v present in the namespace %v, Actual: Service account %v not present in the namespace %v, Error: %v", targetSA, ns, targetSA, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Service account %v present in the namespace %v, Actual: Service account %v not present in the namespace %v, Error: %v", targetSA, ns, targetSA, ns, err))
 	}
 }
 func AssertRoleBindingPresent(clients *clients.Clients, ns, roleBindingName string) {
@@ -46,7 +46,7 @@ func AssertRoleBindingPresent(clients *clients.Clients, ns, roleBindingName stri
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Rolebinding %v present in the namespace %v, Actual: Rolebinding %v not present in the namespace %v, Error: %v", roleBindingName, ns, roleBindingName, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Rolebinding %v present in the namespace %v, Actual: Rolebinding %v not present in the namespace %v, Error: %v", roleBindingName, ns, roleBindingName, ns, err))
 	}
 }
 
@@ -65,7 +65,7 @@ func AssertConfigMapPresent(clients *clients.Clients, ns, configMapName string)
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Configmap %v present in the namespace %v, Actual: Configmap %v not present in the namespace %v, Error: %v", configMapName, ns, configMapName, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Configmap %v present in the namespace %v, Actual: Configmap %v not present in the namespace %v, Error: %v", configMapName, ns, configMapName, ns, err))
 	}
 }
 
@@ -84,7 +84,7 @@ func AssertClusterRolePresent(clients *clients.Clients, clusterRoleName string)
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Clusterrole %v present, Actual: Clusterrole %v not present, Error: %v", clusterRoleName, clusterRoleName, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Clusterrole %v present, Actual: Clusterrole %v not present, Error: %v", clusterRoleName, clusterRoleName, err))
 	}
 }
 
@@ -103,7 +103,7 @@ func AssertServiceAccountNotPresent(clients *clients.Clients, ns, targetSA strin
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Service account %v not present in the namespace %v, Actual: Service account %v is present in the namespace %v, Error: %v", targetSA, ns, targetSA, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Service account %v not present in the namespace %v, Actual: Service account %v is present in the namespace %v, Error: %v", targetSA, ns, targetSA, ns, err))
 	}
 }
 
@@ -122,7 +122,7 @@ func AssertRoleBindingNotPresent(clients *clients.Clients, ns, roleBindingName s
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Rolebinding %v not present in the namespace %v, Actual: Rolebinding %v present in the namespace %v, Error: %v", roleBindingName, ns, roleBindingName, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Rolebinding %v not present in the namespace %v, Actual: Rolebinding %v present in the namespace %v, Error: %v", roleBindingName, ns, roleBindingName, ns, err))
 	}
 }
 
@@ -141,7 +141,7 @@ func AssertConfigMapNotPresent(clients *clients.Clients, ns, configMapName strin
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: Configmap %v not present in the namespace %v, Expected: Configmap %v present in the namespace %v, Error: %v", configMapName, ns, configMapName, ns, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: Configmap %v not present in the namespace %v, Expected: Configmap %v present in the namespace %v, Error: %v", configMapName, ns, configMapName, ns, err))
 	}
 }
 
@@ -160,11 +160,15 @@ func AssertClusterRoleNotPresent(clients *clients.Clients, clusterRoleName strin
 		return true, err
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected, Clusterrole %v not present, Actual: Clusterrole %v present, Error: %v", clusterRoleName, clusterRoleName, err))
+		clients.Reporter().Fail(fmt.Errorf("expected, Clusterrole %v not present, Actual: Clusterrole %v present, Error: %v", clusterRoleName, clusterRoleName, err))
 	}
 }
 
 func AssertSCCPresent(clients *clients.Clients, sccName string) {
+	if !platform.Current().Supports(platform.SecurityContextConstraints) {
+		log.Printf("Skipping verification of security context constraint %s as the platform has no SCC", sccName)
+		return
+	}
 	s := scc.NewForConfigOrDie(clients.KubeConfig)
 	err := wait.PollUntilContextTimeout(clients.Ctx, config.APIRetry, config.APITimeout, false, func(context.Context) (done bool, err error) {
 		log.Printf("Verifying that security context constraint %s exists\n", sccName)
@@ -180,11 +184,15 @@ func AssertSCCPresent(clients *clients.Clients, sccName string) {
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: security context constraint %q present, Actual: security context constraint %q not present , Error: %v", sccName, sccName, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: security context constraint %q present, Actual: security context constraint %q not present , Error: %v", sccName, sccName, err))
 	}
 }
 
 func AssertSCCNotPresent(clients *clients.Clients, sccName string) {
+	if !platform.Current().Supports(platform.SecurityContextConstraints) {
+		log.Printf("Skipping verification of security context constraint %s as the platform has no SCC", sccName)
+		return
+	}
 	s := scc.NewForConfigOrDie(clients.KubeConfig)
 	err := wait.PollUntilContextTimeout(clients.Ctx, config.APIRetry, config.APITimeout, false, func(context.Context) (done bool, err error) {
 		log.Printf("Verifying that security context constraint %s doesn't exist\n", sccName)
@@ -200,7 +208,7 @@ func AssertSCCNotPresent(clients *clients.Clients, sccName string) {
 		return true, err
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("expected: security context constraint %q not present, Actual: security context constraint %q present, Error: %v", sccName, sccName, err))
+		clients.Reporter().Fail(fmt.Errorf("expected: security context constraint %q not present, Actual: security context constraint %q present, Error: %v", sccName, sccName, err))
 	}
 }
 
@@ -214,6 +222,6 @@ func VerifyRolesArePresent(clients *clients.Clients, role, namespace string) {
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to verify role %q present in namespace %q. Error: %v", role, namespace, err))
+		clients.Reporter().Fail(fmt.Errorf("failed to verify role %q present in namespace %q. Error: %v", role, namespace, err))
 	}
 }
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TektonConfigSnapshot is the Spec of a TektonConfig saved to be restored
+// once the changes made to it are not needed anymore
+type TektonConfigSnapshot struct {
+	Name string
+	Spec v1alpha1.TektonConfigSpec
+}
+
+// SnapshotTektonConfig saves the Spec of the TektonConfig called name
+func SnapshotTektonConfig(cs *clients.Clients, name string) (*TektonConfigSnapshot, error) {
+	tc, err := cs.TektonConfig().Get(cs.Ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TektonConfig %s: %v", name, err)
+	}
+	return &TektonConfigSnapshot{Name: name, Spec: *tc.Spec.DeepCopy()}, nil
+}
+
+// RestorePatch returns the JSON merge patch reverting the Spec of current to
+// the one of the snapshot, nil if it did not change
+func (s *TektonConfigSnapshot) RestorePatch(current *v1alpha1.TektonConfig) ([]byte, error) {
+	saved, err := json.Marshal(s.Spec)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := json.Marshal(current.Spec)
+	if err != nil {
+		return nil, err
+	}
+	specPatch, err := jsonpatch.CreateMergePatch(changed, saved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the TektonConfig spec patch: %v", err)
+	}
+	if string(specPatch) == "{}" {
+		return nil, nil
+	}
+	return json.Marshal(map[string]json.RawMessage{"spec": specPatch})
+}
+
+// RestoreTektonConfig reverts the changes made to the Spec of the TektonConfig since the snapshot
+func RestoreTektonConfig(cs *clients.Clients, s *TektonConfigSnapshot) error {
+	tc, err := cs.TektonConfig().Get(cs.Ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get TektonConfig %s: %v", s.Name, err)
+	}
+	patch, err := s.RestorePatch(tc)
+	if err != nil {
+		return err
+	}
+	if patch == nil {
+		log.Printf("TektonConfig %s is unchanged, nothing to restore", s.Name)
+		return nil
+	}
+	log.Printf("Restoring TektonConfig %s with patch %s", s.Name, patch)
+	if _, err := cs.TektonConfig().Patch(cs.Ctx, s.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to restore TektonConfig %s: %v", s.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+)
+
+func TestTektonConfigSnapshotRestorePatch(t *testing.T) {
+	keep, otherKeep := uint(100), uint(5)
+	enable := true
+
+	tests := []struct {
+		name    string
+		saved   func(*operatorv1alpha1.TektonConfigSpec)
+		changed func(*operatorv1alpha1.TektonConfigSpec)
+		want    string
+	}{{
+		name:    "unchanged",
+		saved:   func(s *operatorv1alpha1.TektonConfigSpec) { s.Profile = "all" },
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) {},
+	}, {
+		name:    "changed field",
+		saved:   func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Keep = &keep },
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Keep = &otherKeep },
+		want:    `{"spec":{"pruner":{"keep":100}}}`,
+	}, {
+		name:    "added field",
+		saved:   func(s *operatorv1alpha1.TektonConfigSpec) {},
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Schedule = "*/5 * * * *" },
+		want:    `{"spec":{"pruner":{"schedule":null}}}`,
+	}, {
+		name:    "removed field",
+		saved:   func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Schedule = "0 8 * * *" },
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Schedule = "" },
+		want:    `{"spec":{"pruner":{"schedule":"0 8 * * *"}}}`,
+	}, {
+		name:  "added nested object",
+		saved: func(s *operatorv1alpha1.TektonConfigSpec) {},
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) {
+			s.Platforms.OpenShift.SCC = &operatorv1alpha1.SCC{Default: "pipelines-scc"}
+		},
+		want: `{"spec":{"platforms":{"openshift":{"scc":null}}}}`,
+	}, {
+		name: "removed nested object",
+		saved: func(s *operatorv1alpha1.TektonConfigSpec) {
+			s.Platforms.OpenShift.PipelinesAsCode = &operatorv1alpha1.PipelinesAsCode{Enable: &enable}
+		},
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) { s.Platforms.OpenShift.PipelinesAsCode = nil },
+		want:    `{"spec":{"platforms":{"openshift":{"pipelinesAsCode":{"enable":true,"options":{}}}}}}`,
+	}, {
+		name:  "nested null next to a changed field",
+		saved: func(s *operatorv1alpha1.TektonConfigSpec) { s.Pruner.Keep = &keep },
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) {
+			s.Pruner.Keep = nil
+			s.Pruner.KeepSince = &otherKeep
+		},
+		want: `{"spec":{"pruner":{"keep":100,"keep-since":null}}}`,
+	}, {
+		name: "replaced list",
+		saved: func(s *operatorv1alpha1.TektonConfigSpec) {
+			s.Params = []operatorv1alpha1.Param{{Name: "createRbacResource", Value: "true"}}
+		},
+		changed: func(s *operatorv1alpha1.TektonConfigSpec) { s.Params[0].Value = "false" },
+		want:    `{"spec":{"params":[{"name":"createRbacResource","value":"true"}]}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := &TektonConfigSnapshot{Name: "config"}
+			tt.saved(&snapshot.Spec)
+			current := &operatorv1alpha1.TektonConfig{Spec: *snapshot.Spec.DeepCopy()}
+			tt.changed(&current.Spec)
+
+			patch, err := snapshot.RestorePatch(current)
+			if err != nil {
+				t.Fatalf("RestorePatch() failed: %v", err)
+			}
+			if tt.want == "" {
+				if patch != nil {
+					t.Errorf("RestorePatch() = %s, want nil", patch)
+				}
+				return
+			}
+			if string(patch) != tt.want {
+				t.Errorf("RestorePatch() = %s, want %s", patch, tt.want)
+			}
+
+			// the patch applied to the changed TektonConfig gives back the snapshot
+			changed, err := json.Marshal(current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored, err := jsonpatch.MergePatch(changed, patch)
+			if err != nil {
+				t.Fatalf("failed to apply the patch %s: %v", patch, err)
+			}
+			var got operatorv1alpha1.TektonConfig
+			if err := json.Unmarshal(restored, &got); err != nil {
+				t.Fatal(err)
+			}
+			gotSpec, _ := json.Marshal(got.Spec)
+			wantSpec, _ := json.Marshal(snapshot.Spec)
+			if string(gotSpec) != string(wantSpec) {
+				t.Errorf("restored spec = %s, want %s", gotSpec, wantSpec)
+			}
+		})
+	}
+}
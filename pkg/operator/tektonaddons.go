@@ -24,10 +24,10 @@ import (
 	"os"
 	"strings"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 
 	"knative.dev/pkg/test/logging"
 
@@ -91,7 +91,7 @@ func EnsureTektonAddonsStatusInstalled(clients operatorv1alpha1.TektonAddonInter
 		// Refresh Cluster CR
 		cr, err := EnsureTektonAddonExists(clients, names)
 		if err != nil {
-			testsuit.T.Fail(err)
+			reporter.Default().Fail(err)
 		}
 		for _, ac := range cr.Status.Conditions {
 			if ac.Type != "InstallSucceeded" && ac.Status != "True" {
@@ -102,7 +102,7 @@ func EnsureTektonAddonsStatusInstalled(clients operatorv1alpha1.TektonAddonInter
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 }
 
@@ -110,14 +110,14 @@ func EnsureTektonAddonsStatusInstalled(clients operatorv1alpha1.TektonAddonInter
 func AssertTektonAddonCRReadyStatus(clients *clients.Clients, names utils.ResourceNames) {
 	if _, err := WaitForTektonAddonState(clients.TektonAddon(), names.TektonAddon,
 		IsTektonAddonReady); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonAddonCR %q failed to get to the READY status: %v", names.TektonAddon, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonAddonCR %q failed to get to the READY status: %v", names.TektonAddon, err))
 	}
 }
 
 // TektonAddonCRDelete deletes tha TektonAddon to see if all resources will be deleted
 func TektonAddonCRDelete(clients *clients.Clients, crNames utils.ResourceNames) {
 	if err := clients.TektonAddon().Delete(context.TODO(), crNames.TektonAddon, metav1.DeleteOptions{}); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonAddon %q failed to delete: %v", crNames.TektonAddon, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonAddon %q failed to delete: %v", crNames.TektonAddon, err))
 	}
 	err := wait.PollUntilContextTimeout(clients.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
 		_, err := clients.TektonAddon().Get(context.TODO(), crNames.TektonAddon, metav1.GetOptions{})
@@ -127,12 +127,12 @@ func TektonAddonCRDelete(clients *clients.Clients, crNames utils.ResourceNames)
 		return false, err
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting on TektonAddon to delete, Error: %v", err))
+		clients.Reporter().Fail(fmt.Errorf("timed out waiting on TektonAddon to delete, Error: %v", err))
 	}
 
 	err = verifyNoTektonAddonCR(clients)
 	if err != nil {
-		testsuit.T.Fail(err)
+		clients.Reporter().Fail(err)
 	}
 }
 
@@ -159,14 +159,14 @@ func VerifyVersionedTasks() {
 	}
 
 	if expectedVersion == "" {
-		testsuit.T.Errorf("OSP_VERSION is not set. Cannot determine the required version for tasks.")
+		reporter.Default().Errorf("OSP_VERSION is not set. Cannot determine the required version for tasks.")
 		return
 	}
 
 	// Remove z-stream version from OSP_VERSION
 	versionParts := strings.Split(expectedVersion, ".")
 	if len(versionParts) < 2 {
-		testsuit.T.Errorf("Invalid OSP_VERSION Version: %s", expectedVersion)
+		reporter.Default().Errorf("Invalid OSP_VERSION Version: %s", expectedVersion)
 		return
 	}
 	requiredVersion := versionParts[0] + "-" + versionParts[1] + "-0"
@@ -174,7 +174,7 @@ func VerifyVersionedTasks() {
 	for _, task := range requiredTasks {
 		taskWithVersion := task + "-" + requiredVersion
 		if !strings.Contains(taskList, taskWithVersion) {
-			testsuit.T.Errorf("Task %s not found in namespace openshift-pipelines", taskWithVersion)
+			reporter.Default().Errorf("Task %s not found in namespace openshift-pipelines", taskWithVersion)
 		}
 	}
 }
@@ -186,14 +186,14 @@ func VerifyVersionedStepActions() {
 	expectedVersion := os.Getenv("OSP_VERSION")
 
 	if expectedVersion == "" {
-		testsuit.T.Errorf("OSP_VERSION is not set. Cannot determine the required version for tasks.")
+		reporter.Default().Errorf("OSP_VERSION is not set. Cannot determine the required version for tasks.")
 		return
 	}
 
 	// Remove z-stream version from OSP_VERSION
 	versionParts := strings.Split(expectedVersion, ".")
 	if len(versionParts) < 2 {
-		testsuit.T.Errorf("Invalid OSP_VERSION Version: %s", expectedVersion)
+		reporter.Default().Errorf("Invalid OSP_VERSION Version: %s", expectedVersion)
 		return
 	}
 	requiredVersion := versionParts[0] + "-" + versionParts[1] + "-0"
@@ -201,7 +201,7 @@ func VerifyVersionedStepActions() {
 	for _, stepAction := range requiredStepActions {
 		stepActionWithVersion := stepAction + "-" + requiredVersion
 		if !strings.Contains(stepActionList, stepActionWithVersion) {
-			testsuit.T.Errorf("Step action %s not found in namespace openshift-pipelines", stepActionWithVersion)
+			reporter.Default().Errorf("Step action %s not found in namespace openshift-pipelines", stepActionWithVersion)
 		}
 	}
 }
@@ -27,9 +27,10 @@ import (
 	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
 	"github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
 	chainv1alpha "github.com/tektoncd/operator/pkg/client/clientset/versioned/typed/operator/v1alpha1"
 	"github.com/tektoncd/operator/test/utils"
@@ -38,10 +39,89 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
-// "quay.io/openshift-pipeline/chainstest"
-var repo string = os.Getenv("CHAINS_REPOSITORY")
 var publicKeyPath = config.Path("testdata/chains/key")
 
+// chainsRegistryKey is the key of the scenario data of a registry standing in
+// for CHAINS_REPOSITORY
+const chainsRegistryKey = "chains.registry"
+
+// ChainsRegistry is a registry standing in for CHAINS_REPOSITORY and
+// CHAINS_DOCKER_CONFIG_JSON in the chains steps of a scenario
+type ChainsRegistry struct {
+	// Repository of the images signed by chains
+	Repository string
+	// ExposedRepository is Repository at the address of the registry exposed
+	// to the tests, where cosign verifies the images
+	ExposedRepository string
+	// DockerConfig is the docker config JSON of the credentials of Repository
+	DockerConfig string
+	// Insecure pushes the images over plain HTTP
+	Insecure bool
+	// CAFile is the CA certificate cosign verifies the registry with, empty
+	// unless it serves TLS
+	CAFile string
+	// ServerName is the host the certificate of the registry is verified for
+	// at ExposedRepository
+	ServerName string
+}
+
+// UseChainsRegistry makes the chains steps of the scenario push the images to
+// the registry instead of CHAINS_REPOSITORY
+func UseChainsRegistry(r ChainsRegistry) {
+	store.PutScenarioValue(chainsRegistryKey, r)
+}
+
+// chainsRegistry returns the registry used by the chains steps of the
+// scenario, if any
+func chainsRegistry() (ChainsRegistry, bool) {
+	r, ok := store.GetScenarioValue(chainsRegistryKey).(ChainsRegistry)
+	return r, ok
+}
+
+// ChainsRepository returns the repository of the images signed by chains,
+// e.g. quay.io/openshift-pipeline/chainstest
+func ChainsRepository() string {
+	if r, ok := chainsRegistry(); ok {
+		return r.Repository
+	}
+	return os.Getenv("CHAINS_REPOSITORY")
+}
+
+// chainsVerifyRepository returns the repository where cosign verifies the
+// images signed by chains, ChainsRepository as the tests reach it
+func chainsVerifyRepository() string {
+	if r, ok := chainsRegistry(); ok && r.ExposedRepository != "" {
+		return r.ExposedRepository
+	}
+	return ChainsRepository()
+}
+
+// ChainsDockerConfig returns the docker config JSON of the credentials of the
+// repository of ChainsRepository
+func ChainsDockerConfig() string {
+	if r, ok := chainsRegistry(); ok {
+		return r.DockerConfig
+	}
+	return os.Getenv("CHAINS_DOCKER_CONFIG_JSON")
+}
+
+// cosignRegistryFlags returns the flags of cosign reaching the registry of the
+// chains steps of the scenario
+func cosignRegistryFlags() []string {
+	r, _ := chainsRegistry()
+	var flags []string
+	if r.Insecure {
+		flags = append(flags, "--allow-insecure-registry", "--allow-http-registry")
+	}
+	if r.CAFile != "" {
+		flags = append(flags, "--registry-cacert", r.CAFile)
+	}
+	if r.ServerName != "" {
+		flags = append(flags, "--registry-server-name", r.ServerName)
+	}
+	return flags
+}
+
 func EnsureTektonChainsExists(clients chainv1alpha.TektonChainInterface, names utils.ResourceNames) (*v1alpha1.TektonChain, error) {
 	ks, err := clients.Get(context.TODO(), names.TektonChain, metav1.GetOptions{})
 	err = wait.PollUntilContextTimeout(context.TODO(), config.APIRetry, config.APITimeout, false, func(context.Context) (bool, error) {
@@ -73,27 +153,27 @@ func VerifySignature(resourceType string) {
 	isSigned = strings.Trim(isSigned, "\"")
 
 	if isSigned != "true" {
-		testsuit.T.Errorf("Annotation chains.tekton.dev/signed is set to %s", isSigned)
+		reporter.Default().Errorf("Annotation chains.tekton.dev/signed is set to %s", isSigned)
 	}
 	if len(signature) == 0 {
-		testsuit.T.Fail(fmt.Errorf("annotation chains.tekton.dev/signature-%s-%s is not set", resourceType, resourceUID))
+		reporter.Default().Fail(fmt.Errorf("annotation chains.tekton.dev/signature-%s-%s is not set", resourceType, resourceUID))
 	}
 
 	// Decode the signature
 	decodedSignature, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
-		testsuit.T.Errorf("Error decoding base64")
+		reporter.Default().Errorf("Error decoding base64")
 	}
 	// Create file with signature
 	file, err := os.Create("sign")
 	if err != nil {
-		testsuit.T.Errorf("Error creating file")
+		reporter.Default().Errorf("Error creating file")
 	}
 	//nolint:errcheck
 	defer file.Close()
 	_, err = file.WriteString(string(decodedSignature))
 	if err != nil {
-		testsuit.T.Errorf("Error writing to file")
+		reporter.Default().Errorf("Error writing to file")
 	}
 	// Verify signature with signing-secrets
 	cmd.MustSucceed("cosign", "verify-blob-attestation", "--insecure-ignore-tlog", "--key", publicKeyPath+"/cosign.pub", "--signature", "sign", "--type", "slsaprovenance", "--check-claims=false", "/dev/null")
@@ -102,8 +182,14 @@ func VerifySignature(resourceType string) {
 func StartKanikoTask() {
 	var tag = time.Now().Format("060102150405")
 	cmd.MustSucceed("oc", "secrets", "link", "pipeline", "chains-image-registry-credentials", "--for=pull,mount")
-	image := fmt.Sprintf("IMAGE=%s:%s", repo, tag)
-	cmd.MustSucceed("opc", "task", "start", "--param", image, "--use-param-defaults", "--workspace", "name=source,claimName=chains-pvc", "--workspace", "name=dockerconfig,secret=chains-image-registry-credentials", "kaniko-chains")
+	args := []string{"opc", "task", "start", "--param", fmt.Sprintf("IMAGE=%s:%s", ChainsRepository(), tag)}
+	if r, _ := chainsRegistry(); r.Insecure {
+		args = append(args, "--param", "EXTRA_ARGS=--insecure")
+	} else if r.CAFile != "" {
+		// the task has no workspace for the CA of the registry
+		args = append(args, "--param", "EXTRA_ARGS=--skip-tls-verify")
+	}
+	cmd.MustSucceed(append(args, "--use-param-defaults", "--workspace", "name=source,claimName=chains-pvc", "--workspace", "name=dockerconfig,secret=chains-image-registry-credentials", "kaniko-chains")...)
 	log.Println("Waiting 2 minutes for images to appear in image registry")
 	cmd.MustSuccedIncreasedTimeout(time.Second*130, "sleep", "120")
 }
@@ -121,7 +207,7 @@ func GetImageUrlAndDigest() (string, string) {
 	var results []Result
 	err := json.Unmarshal([]byte(jsonOutput), &results)
 	if err != nil {
-		testsuit.T.Errorf("Error parsing Json output")
+		reporter.Default().Errorf("Error parsing Json output")
 	}
 
 	// Get IMAGE_DIGEST value
@@ -131,19 +217,21 @@ func GetImageUrlAndDigest() (string, string) {
 		}
 	}
 
-	// Return image url with digest
-	url := fmt.Sprintf("%s@sha256:%s", repo, imageDigest)
+	// Return image url with digest, where cosign verifies it
+	url := fmt.Sprintf("%s@sha256:%s", chainsVerifyRepository(), imageDigest)
 	return url, imageDigest
 }
 
 func VerifyImageSignature() {
 	url, _ := GetImageUrlAndDigest()
-	cmd.MustSucceed("cosign", "verify", "--key", publicKeyPath+"/cosign.pub", url)
+	args := append([]string{"cosign", "verify"}, cosignRegistryFlags()...)
+	cmd.MustSucceed(append(args, "--key", publicKeyPath+"/cosign.pub", url)...)
 }
 
 func VerifyAttestation() {
 	url, _ := GetImageUrlAndDigest()
-	cmd.MustSucceed("cosign", "verify-attestation", "--key", publicKeyPath+"/cosign.pub", "--type", "slsaprovenance", url)
+	args := append([]string{"cosign", "verify-attestation"}, cosignRegistryFlags()...)
+	cmd.MustSucceed(append(args, "--key", publicKeyPath+"/cosign.pub", "--type", "slsaprovenance", url)...)
 }
 
 func CheckAttestationExists() {
@@ -158,13 +246,13 @@ func CheckAttestationExists() {
 	var uuid UUID
 	err := json.Unmarshal([]byte(jsonOutput), &uuid)
 	if err != nil {
-		testsuit.T.Errorf("Error parsing Json output")
+		reporter.Default().Errorf("Error parsing Json output")
 	}
 	rekor_uuid := uuid.UUIDs[0]
 
 	// Check the Attestation
 	if strings.Contains(cmd.Run("rekor-cli", "get", "--uuid", rekor_uuid).Stdout(), "getLogEntryByUuidNotFound") {
-		testsuit.T.Errorf("Failed to find Attestation")
+		reporter.Default().Errorf("Failed to find Attestation")
 	}
 }
 
@@ -174,17 +262,17 @@ func CreateFileWithCosignPubKey() {
 	decodedPublicKey, err := base64.StdEncoding.DecodeString(chainsPublicKey)
 	cmd.MustSucceed("mkdir", "-p", publicKeyPath)
 	if err != nil {
-		testsuit.T.Errorf("Error decoding base64")
+		reporter.Default().Errorf("Error decoding base64")
 	}
 	fullPath := filepath.Join(publicKeyPath, "cosign.pub")
 	file, err := os.Create(filepath.Clean(fullPath))
 	if err != nil {
-		testsuit.T.Errorf("Error creating file")
+		reporter.Default().Errorf("Error creating file")
 	}
 	//nolint:errcheck
 	defer file.Close()
 	_, err = file.WriteString(string(decodedPublicKey))
 	if err != nil {
-		testsuit.T.Errorf("Error writing to file")
+		reporter.Default().Errorf("Error writing to file")
 	}
 }
@@ -24,9 +24,9 @@ import (
 
 	"knative.dev/pkg/test/logging"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
@@ -88,7 +88,7 @@ func EnsureTektonConfigStatusInstalled(clients configv1alpha1.TektonConfigInterf
 		// Refresh Cluster CR
 		cr, err := EnsureTektonConfigExists(clients, names)
 		if err != nil {
-			testsuit.T.Fail(err)
+			reporter.Default().Fail(err)
 		}
 		for _, cc := range cr.Status.Conditions {
 			if cc.Type != "InstallSucceeded" && cc.Status != "True" {
@@ -99,21 +99,21 @@ func EnsureTektonConfigStatusInstalled(clients configv1alpha1.TektonConfigInterf
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 }
 
 // AssertTektonConfigCRReadyStatus verifies if the TektonConfig reaches the READY status.
 func AssertTektonConfigCRReadyStatus(clients *clients.Clients, names utils.ResourceNames) {
 	if _, err := WaitForTektonConfigState(clients.TektonConfig(), names.TektonConfig, IsTektonConfigReady); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonConfigCR %q failed to get to the READY status: %v", names.TektonConfig, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonConfigCR %q failed to get to the READY status: %v", names.TektonConfig, err))
 	}
 }
 
 // TektonConfigCRDelete deletes tha TektonConfig to see if all resources will be deleted
 func TektonConfigCRDelete(clients *clients.Clients, crNames utils.ResourceNames) {
 	if err := clients.TektonConfig().Delete(context.TODO(), crNames.TektonConfig, metav1.DeleteOptions{}); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonConfigCR %q failed to delete: %v", crNames.TektonConfig, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonConfigCR %q failed to delete: %v", crNames.TektonConfig, err))
 	}
 	err := wait.PollUntilContextTimeout(clients.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
 		_, err := clients.TektonConfig().Get(context.TODO(), crNames.TektonConfig, metav1.GetOptions{})
@@ -123,11 +123,11 @@ func TektonConfigCRDelete(clients *clients.Clients, crNames utils.ResourceNames)
 		return false, err
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting on TektonConfigCR to delete, Error: %v", err))
+		clients.Reporter().Fail(fmt.Errorf("timed out waiting on TektonConfigCR to delete, Error: %v", err))
 	}
 	err = verifyNoTektonConfigCR(clients)
 	if err != nil {
-		testsuit.T.Fail(err)
+		clients.Reporter().Fail(err)
 	}
 }
 
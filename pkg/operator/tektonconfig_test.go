@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	"github.com/tektoncd/operator/test/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+func TestEnsureTektonConfigStatusInstalled(t *testing.T) {
+	timeouts := config.CurrentTimeouts()
+	defer config.SetTimeouts(timeouts)
+	short := timeouts
+	short.APIRetry, short.APITimeout = 10*time.Millisecond, 100*time.Millisecond
+	config.SetTimeouts(short)
+
+	tektonConfig := func(conditions ...apis.Condition) runtime.Object {
+		tc := &operatorv1alpha1.TektonConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}}
+		tc.Status.Conditions = conditions
+		return tc
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		wantErr string
+	}{{
+		name: "installed",
+		objects: []runtime.Object{tektonConfig(
+			apis.Condition{Type: "InstallSucceeded", Status: corev1.ConditionTrue},
+			apis.Condition{Type: apis.ConditionReady, Status: corev1.ConditionTrue},
+		)},
+	}, {
+		name:    "not installed",
+		objects: []runtime.Object{tektonConfig(apis.Condition{Type: "PreReconciler", Status: corev1.ConditionFalse})},
+		wantErr: "context deadline exceeded",
+	}, {
+		name:    "missing",
+		wantErr: "context deadline exceeded",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tt.objects...)
+			err := testutil.CaptureFailures(func() {
+				EnsureTektonConfigStatusInstalled(c.TektonConfig(), utils.ResourceNames{TektonConfig: "config"})
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
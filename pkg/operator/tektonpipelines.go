@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 
@@ -87,14 +86,14 @@ func IsTektonPipelineReady(s *v1alpha1.TektonPipeline, err error) (bool, error)
 func AssertTektonPipelineCRReadyStatus(clients *clients.Clients, names utils.ResourceNames) {
 	if _, err := WaitForTektonPipelineState(clients.TektonPipeline(), names.TektonPipeline,
 		IsTektonPipelineReady); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonPipelineCR %q failed to get to the READY status: %v", names.TektonPipeline, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonPipelineCR %q failed to get to the READY status: %v", names.TektonPipeline, err))
 	}
 }
 
 // TektonPipelineCRDelete deletes tha TektonPipeline to see if all resources will be deleted
 func TektonPipelineCRDelete(clients *clients.Clients, crNames utils.ResourceNames) {
 	if err := clients.TektonPipeline().Delete(context.TODO(), crNames.TektonPipeline, metav1.DeleteOptions{}); err != nil {
-		testsuit.T.Fail(fmt.Errorf("TektonPipeline %q failed to delete: %v", crNames.TektonPipeline, err))
+		clients.Reporter().Fail(fmt.Errorf("TektonPipeline %q failed to delete: %v", crNames.TektonPipeline, err))
 	}
 	err := wait.PollUntilContextTimeout(clients.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
 		_, err := clients.TektonPipeline().Get(context.TODO(), crNames.TektonPipeline, metav1.GetOptions{})
@@ -104,10 +103,10 @@ func TektonPipelineCRDelete(clients *clients.Clients, crNames utils.ResourceName
 		return false, err
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting on TektonPipeline to delete, Error: %v", err))
+		clients.Reporter().Fail(fmt.Errorf("timed out waiting on TektonPipeline to delete, Error: %v", err))
 	}
 	if err := verifyNoTektonPipelineCR(clients); err != nil {
-		testsuit.T.Fail(err)
+		clients.Reporter().Fail(err)
 	}
 }
 
@@ -2,6 +2,7 @@ package operator
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"strings"
@@ -10,36 +11,76 @@ import (
 	"encoding/base64"
 	"encoding/json"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/pki"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// CreateSecretsForTektonResults creates the database credentials and the TLS
+// certificate of the Results API, issued for its service and written to the
+// temporary directory of the scenario
 func CreateSecretsForTektonResults() {
-	var password = cmd.MustSucceed("openssl", "rand", "-base64", "20").Stdout()
-	password = strings.ReplaceAll(password, "\n", "")
-	cmd.MustSucceed("oc", "create", "secret", "-n", "openshift-pipelines", "generic", "tekton-results-postgres", "--from-literal=POSTGRES_USER=result", "--from-literal=POSTGRES_PASSWORD="+password)
+	password, err := randomPassword()
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
+	cmd.MustSucceed("oc", "create", "secret", "-n", config.TargetNamespace(), "generic", "tekton-results-postgres", "--from-literal=POSTGRES_USER=result", "--from-literal=POSTGRES_PASSWORD="+password)
 	// generating tls certificate
-	cmd.MustSucceed("openssl", "req", "-x509", "-newkey", "rsa:4096", "-keyout", "key.pem", "-out", "cert.pem", "-days", "365", "-nodes", "-subj", "/CN=tekton-results-api-service.openshift-pipelines.svc.cluster.local", "-addext", "subjectAltName=DNS:tekton-results-api-service.openshift-pipelines.svc.cluster.local")
+	service := fmt.Sprintf("tekton-results-api-service.%s", config.TargetNamespace())
+	cert, err := pki.NewSelfSigned(service+".svc.cluster.local", "tekton-results-api-service", service, service+".svc", service+".svc.cluster.local")
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
+	certPath, keyPath, err := cert.Write(store.TempDir(), "tekton-results-tls")
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
 	// creating secret with generated certificate
-	cmd.MustSucceed("oc", "create", "secret", "tls", "-n", "openshift-pipelines", "tekton-results-tls", "--cert=cert.pem", "--key=key.pem")
+	cmd.MustSucceed("oc", "create", "secret", "tls", "-n", config.TargetNamespace(), "tekton-results-tls", "--cert="+certPath, "--key="+keyPath)
+}
+
+// randomPassword returns 20 random bytes encoded in base64, like `openssl rand -base64 20`
+func randomPassword() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
 }
 
 func EnsureResultsReady() {
 	cmd.MustSuccedIncreasedTimeout(time.Minute*5, "oc", "wait", "--for=condition=Ready", "tektoninstallerset", "-l", "operator.tekton.dev/type=result", "--timeout=120s")
 }
 
+// resultsAPI is the gRPC port of the Results API, which serves TLS
+func resultsAPI() expose.Service {
+	return expose.Service{Namespace: config.TargetNamespace(), Name: "tekton-results-api-service", Port: "8080", TLS: true}
+}
+
+// CreateResultsRoute exposes the Results API with the expose mode, a
+// passthrough Route by default
 func CreateResultsRoute() {
-	cmd.Run("oc", "create", "route", "-n", "openshift-pipelines", "passthrough", "tekton-results-api-service", "--service=tekton-results-api-service", "--port=8080")
+	if _, err := expose.Current().Expose(store.Clients(), resultsAPI()); err != nil {
+		reporter.Default().Fail(err)
+	}
 }
 
+// GetResultsApi returns the host:port where the exposed Results API is reachable
 func GetResultsApi() string {
-	var results_api = cmd.MustSucceed("oc", "get", "route", "tekton-results-api-service", "-n", "openshift-pipelines", "--no-headers", "-o", "custom-columns=:spec.host").Stdout() + ":443"
-	results_api = strings.ReplaceAll(results_api, "\n", "")
+	url, err := expose.Current().Expose(store.Clients(), resultsAPI())
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
+	results_api, err := expose.HostPort(url)
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
 	return results_api
 }
 
@@ -94,7 +135,7 @@ func VerifyResultsAnnotationStored(resourceType string) {
 	})
 
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("annotation 'results.tekton.dev/stored' is not true: %v", err))
+		reporter.Default().Fail(fmt.Errorf("annotation 'results.tekton.dev/stored' is not true: %v", err))
 	}
 }
 
@@ -105,12 +146,12 @@ func VerifyResultsLogs(resourceType string) {
 	results_api = GetResultsApi()
 
 	if record_uuid == "" {
-		testsuit.T.Fail(fmt.Errorf("annotation results.tekton.dev/record is not set"))
+		reporter.Default().Fail(fmt.Errorf("annotation results.tekton.dev/record is not set"))
 	}
 
 	var resultsJsonData = cmd.MustSucceed("opc", "results", "logs", "get", "--insecure", "--addr", results_api, record_uuid).Stdout()
 	if strings.Contains(resultsJsonData, "record not found") {
-		testsuit.T.Errorf("Results log not found")
+		reporter.Default().Errorf("Results log not found")
 	} else {
 		type ResultLogs struct {
 			Name string `json:"name"`
@@ -119,14 +160,14 @@ func VerifyResultsLogs(resourceType string) {
 		var resultLogs ResultLogs
 		err := json.Unmarshal([]byte(resultsJsonData), &resultLogs)
 		if err != nil {
-			testsuit.T.Errorf("Error parsing JSON")
+			reporter.Default().Errorf("Error parsing JSON")
 		}
 		decodedResultsLogs, err := base64.StdEncoding.Strict().DecodeString(resultLogs.Data)
 		if err != nil {
-			testsuit.T.Errorf("Error decoding base64 data")
+			reporter.Default().Errorf("Error decoding base64 data")
 		}
 		if !strings.Contains(string(decodedResultsLogs), "Hello, Results!") || !strings.Contains(string(decodedResultsLogs), "Goodbye, Results!") {
-			testsuit.T.Errorf("Logs are incorrect")
+			reporter.Default().Errorf("Logs are incorrect")
 		}
 	}
 }
@@ -138,7 +179,7 @@ func VerifyResultsRecords(resourceType string) {
 	results_api = GetResultsApi()
 	var results_record = cmd.MustSucceed("opc", "results", "records", "get", "--insecure", "--addr", results_api, record_uuid).Stdout()
 	if strings.Contains(results_record, "record not found") {
-		testsuit.T.Errorf("Results record not found")
+		reporter.Default().Errorf("Results record not found")
 	} else {
 		type ResultRecords struct {
 			Data struct {
@@ -150,14 +191,14 @@ func VerifyResultsRecords(resourceType string) {
 		var resultRecords ResultRecords
 		err := json.Unmarshal([]byte(resultsJsonData), &resultRecords)
 		if err != nil {
-			testsuit.T.Errorf("Error parsing JSON: %v", err)
+			reporter.Default().Errorf("Error parsing JSON: %v", err)
 		}
 		decodedResultsLogs, err := base64.StdEncoding.Strict().DecodeString(resultRecords.Data.Value)
 		if err != nil {
-			testsuit.T.Errorf("Error decoding base64 data: %v", err)
+			reporter.Default().Errorf("Error decoding base64 data: %v", err)
 		}
 		if !strings.Contains(string(decodedResultsLogs), "Hello, Results!") || !strings.Contains(string(decodedResultsLogs), "Goodbye, Results!") {
-			testsuit.T.Errorf("Records are incorrect")
+			reporter.Default().Errorf("Records are incorrect")
 		}
 	}
 }
@@ -12,13 +12,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/getgauge-contrib/gauge-go/gauge"
 	"github.com/google/go-github/v74/github"
 	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
 	"github.com/openshift-pipelines/release-tests/pkg/pipelines"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	"golang.org/x/oauth2"
 	corev1 "k8s.io/api/core/v1"
@@ -30,17 +31,24 @@ const githubWebhookConfigName = "github-webhook-config"
 
 const pacEventTypeAnnotationKey = "pipelinesascode.tekton.dev/event-type"
 
-var ghClient *github.Client
+const gitHubClientKey = "pac.githubClient"
 
+// SetGitHubClient makes c the GitHub client of the scenario
 func SetGitHubClient(c *github.Client) {
-	ghClient = c
+	gauge.GetScenarioStore()[gitHubClientKey] = c
+}
+
+// gitHubClient returns the GitHub client of the scenario, nil if none was set
+func gitHubClient() *github.Client {
+	c, _ := gauge.GetScenarioStore()[gitHubClientKey].(*github.Client)
+	return c
 }
 
 // InitGitHubClient initializes a GitHub client for GitHub
 func InitGitHubClient() *github.Client {
 	token := os.Getenv("PAC_GITHUB_TOKEN")
 	if token == "" {
-		testsuit.T.Fail(fmt.Errorf("PAC_GITHUB_TOKEN was not exported as a system variable"))
+		reporter.Default().Fail(fmt.Errorf("PAC_GITHUB_TOKEN was not exported as a system variable"))
 	}
 
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
@@ -129,7 +137,7 @@ func createGitHubRepositoryCR(c *clients.Clients, repoName, repoURL, namespace s
 func waitForRepoReady(ctx context.Context, owner, repo string) error {
 	deadline := time.Now().Add(60 * time.Second)
 	for time.Now().Before(deadline) {
-		_, resp, err := ghClient.Repositories.Get(ctx, owner, repo)
+		_, resp, err := gitHubClient().Repositories.Get(ctx, owner, repo)
 		if err == nil {
 			return nil
 		}
@@ -146,7 +154,7 @@ func ensureDefaultBranchMain(ctx context.Context, owner, repo, defaultBranch str
 	if defaultBranch == "" || defaultBranch == "main" {
 		return nil
 	}
-	_, _, err := ghClient.Repositories.RenameBranch(ctx, owner, repo, defaultBranch, "main")
+	_, _, err := gitHubClient().Repositories.RenameBranch(ctx, owner, repo, defaultBranch, "main")
 	return err
 }
 
@@ -161,7 +169,7 @@ func addGitHubWebhook(ctx context.Context, owner, repo, smeeURL, webhookSecret s
 		},
 		Events: []string{"commit_comment", "issue_comment", "pull_request", "push"},
 	}
-	_, _, err := ghClient.Repositories.CreateHook(ctx, owner, repo, hook)
+	_, _, err := gitHubClient().Repositories.CreateHook(ctx, owner, repo, hook)
 	return err
 }
 
@@ -187,7 +195,7 @@ func sanitizeK8sName(in string) string {
 }
 
 func createBranchWithGitHub(ctx context.Context, owner, repo, baseBranch, newBranch, message string, files map[string]string) error {
-	baseRef, _, err := ghClient.Git.GetRef(ctx, owner, repo, "refs/heads/"+baseBranch)
+	baseRef, _, err := gitHubClient().Git.GetRef(ctx, owner, repo, "refs/heads/"+baseBranch)
 	if err != nil {
 		return err
 	}
@@ -196,7 +204,7 @@ func createBranchWithGitHub(ctx context.Context, owner, repo, baseBranch, newBra
 		return fmt.Errorf("base branch %q has empty SHA", baseBranch)
 	}
 
-	baseCommit, _, err := ghClient.Git.GetCommit(ctx, owner, repo, baseCommitSHA)
+	baseCommit, _, err := gitHubClient().Git.GetCommit(ctx, owner, repo, baseCommitSHA)
 	if err != nil {
 		return err
 	}
@@ -220,7 +228,7 @@ func createBranchWithGitHub(ctx context.Context, owner, repo, baseBranch, newBra
 		})
 	}
 
-	newTree, _, err := ghClient.Git.CreateTree(ctx, owner, repo, baseTreeSHA, entries)
+	newTree, _, err := gitHubClient().Git.CreateTree(ctx, owner, repo, baseTreeSHA, entries)
 	if err != nil {
 		return err
 	}
@@ -230,7 +238,7 @@ func createBranchWithGitHub(ctx context.Context, owner, repo, baseBranch, newBra
 		Tree:    newTree,
 		Parents: []*github.Commit{{SHA: github.Ptr(baseCommitSHA)}},
 	}
-	newCommit, _, err := ghClient.Git.CreateCommit(ctx, owner, repo, commit, nil)
+	newCommit, _, err := gitHubClient().Git.CreateCommit(ctx, owner, repo, commit, nil)
 	if err != nil {
 		return err
 	}
@@ -245,14 +253,14 @@ func createBranchWithGitHub(ctx context.Context, owner, repo, baseBranch, newBra
 			SHA: github.Ptr(newCommitSHA),
 		},
 	}
-	_, _, err = ghClient.Git.CreateRef(ctx, owner, repo, ref)
+	_, _, err = gitHubClient().Git.CreateRef(ctx, owner, repo, ref)
 	return err
 }
 
 // SetupGitHubProject creates a new GitHub repository
 func SetupGitHubProject() *github.Repository {
-	if ghClient == nil {
-		testsuit.T.Fail(fmt.Errorf("github client not initialized; call InitGitHubClient/SetGitHubClient first"))
+	if gitHubClient() == nil {
+		reporter.Default().Fail(fmt.Errorf("github client not initialized; call InitGitHubClient/SetGitHubClient first"))
 	}
 
 	ctx := context.Background()
@@ -264,7 +272,7 @@ func SetupGitHubProject() *github.Repository {
 	if webhookSecret == "" {
 		sec, err := randWebhookSecret()
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("failed generating github webhook secret: %v", err))
+			reporter.Default().Fail(fmt.Errorf("failed generating github webhook secret: %v", err))
 		}
 		webhookSecret = sec
 	}
@@ -277,9 +285,9 @@ func SetupGitHubProject() *github.Repository {
 		AllowSquashMerge:    github.Ptr(true),
 		DeleteBranchOnMerge: github.Ptr(true),
 	}
-	created, _, err := ghClient.Repositories.Create(ctx, org, createReq)
+	created, _, err := gitHubClient().Repositories.Create(ctx, org, createReq)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create github repository: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to create github repository: %v", err))
 	}
 
 	var owner string
@@ -289,18 +297,18 @@ func SetupGitHubProject() *github.Repository {
 	case created.Owner != nil && created.Owner.Login != nil:
 		owner = created.GetOwner().GetLogin()
 	default:
-		u, _, uerr := ghClient.Users.Get(ctx, "")
+		u, _, uerr := gitHubClient().Users.Get(ctx, "")
 		if uerr != nil {
-			testsuit.T.Fail(fmt.Errorf("failed to determine github username: %v", uerr))
+			reporter.Default().Fail(fmt.Errorf("failed to determine github username: %v", uerr))
 		}
 		owner = u.GetLogin()
 	}
 
 	if err := waitForRepoReady(ctx, owner, repoName); err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 	if err := ensureDefaultBranchMain(ctx, owner, repoName, created.GetDefaultBranch()); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to rename default branch to main: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to rename default branch to main: %v", err))
 	}
 
 	repoURL := created.GetHTMLURL()
@@ -315,15 +323,15 @@ func SetupGitHubProject() *github.Repository {
 	// Create the local webhook+token secret and Repository CR in the scenario namespace.
 	namespace := store.Namespace()
 	if err := ensureWebhookSecret(store.Clients(), namespace, token, webhookSecret); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to ensure github webhook secret: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to ensure github webhook secret: %v", err))
 	}
 	if err := createGitHubRepositoryCR(store.Clients(), sanitizeK8sName(repoName), repoURL, namespace); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create PAC Repository CR: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to create PAC Repository CR: %v", err))
 	}
 
 	// Configure GitHub webhook to smee.io (gosmee forwards to controller service in-cluster).
 	if err := addGitHubWebhook(ctx, owner, repoName, smeeURL, webhookSecret); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to add github webhook: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to add github webhook: %v", err))
 	}
 
 	log.Printf("GitHub repo created: %s", strings.ReplaceAll(strings.ReplaceAll(repoURL, "\n", ""), "\r", "")) //nolint:gosec // repoURL comes from GitHub API or is built from sanitised owner+name
@@ -333,7 +341,7 @@ func SetupGitHubProject() *github.Repository {
 func waitForPRMergeable(ctx context.Context, owner, repo string, number int) error {
 	deadline := time.Now().Add(60 * time.Second)
 	for time.Now().Before(deadline) {
-		pr, _, err := ghClient.PullRequests.Get(ctx, owner, repo, number)
+		pr, _, err := gitHubClient().PullRequests.Get(ctx, owner, repo, number)
 		if err != nil {
 			return err
 		}
@@ -350,11 +358,11 @@ func ConfigurePreviewChangesGitHub() {
 	repo := store.GetScenarioData("PAC_GITHUB_REPO_NAME")
 	ctx := context.Background()
 
-	prData, err := os.ReadFile(filepath.Clean(pullRequestFileName))
+	prData, err := os.ReadFile(filepath.Clean(pullRequestFile()))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("read %s: %v", pullRequestFileName, err))
+		reporter.Default().Fail(fmt.Errorf("read %s: %v", pullRequestFile(), err))
 	}
-	pushData, pushErr := os.ReadFile(filepath.Clean(pushFileName))
+	pushData, pushErr := os.ReadFile(filepath.Clean(pushFile()))
 	hasPush := pushErr == nil
 
 	branchName := fmt.Sprintf("preview-%08d", time.Now().UnixNano()%1e8)
@@ -365,7 +373,7 @@ func ConfigurePreviewChangesGitHub() {
 		files[".tekton/push.yaml"] = string(pushData)
 	}
 	if err := createBranchWithGitHub(ctx, owner, repo, "main", branchName, "ci(pac): add pipelines-as-code definitions", files); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create single-commit branch %q: %v", branchName, err))
+		reporter.Default().Fail(fmt.Errorf("failed to create single-commit branch %q: %v", branchName, err))
 	}
 
 	newPR := &github.NewPullRequest{
@@ -373,9 +381,9 @@ func ConfigurePreviewChangesGitHub() {
 		Head:  github.Ptr(owner + ":" + branchName),
 		Base:  github.Ptr("main"),
 	}
-	pr, _, err := ghClient.PullRequests.Create(ctx, owner, repo, newPR)
+	pr, _, err := gitHubClient().PullRequests.Create(ctx, owner, repo, newPR)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create PR: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to create PR: %v", err))
 	}
 	store.PutScenarioData("prURL", pr.GetHTMLURL())
 	store.PutScenarioData("prNumber", strconv.Itoa(pr.GetNumber()))
@@ -389,18 +397,18 @@ func TriggerPushOnGitHubMain() {
 
 	prNum, err := strconv.Atoi(store.GetScenarioData("prNumber"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("bad prNumber: %v", err))
+		reporter.Default().Fail(fmt.Errorf("bad prNumber: %v", err))
 	}
 
 	if err := waitForPRMergeable(ctx, owner, repo, prNum); err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
-	_, _, err = ghClient.PullRequests.Merge(ctx, owner, repo, prNum, "", &github.PullRequestOptions{
+	_, _, err = gitHubClient().PullRequests.Merge(ctx, owner, repo, prNum, "", &github.PullRequestOptions{
 		MergeMethod: "squash",
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to merge PR #%d: %v", prNum, err))
+		reporter.Default().Fail(fmt.Errorf("failed to merge PR #%d: %v", prNum, err))
 	}
 }
 
@@ -417,9 +425,9 @@ func WaitForNewPipelineRunName(previousName string) string {
 	}
 
 	if previousName == "" {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting for a PipelineRun to be created in namespace %q", store.Namespace()))
+		reporter.Default().Fail(fmt.Errorf("timed out waiting for a PipelineRun to be created in namespace %q", store.Namespace()))
 	} else {
-		testsuit.T.Fail(fmt.Errorf("timed out waiting for a new PipelineRun in namespace %q (previous=%q)", store.Namespace(), previousName))
+		reporter.Default().Fail(fmt.Errorf("timed out waiting for a new PipelineRun in namespace %q (previous=%q)", store.Namespace(), previousName))
 	}
 	return ""
 }
@@ -460,23 +468,23 @@ func WaitForNewPipelineRunNameByEventType(previousName, eventType string) string
 		time.Sleep(config.APIRetry)
 	}
 
-	testsuit.T.Fail(fmt.Errorf("timed out waiting for a new PipelineRun with event-type=%q in namespace %q (previous=%q)", eventType, store.Namespace(), previousName))
+	reporter.Default().Fail(fmt.Errorf("timed out waiting for a new PipelineRun with event-type=%q in namespace %q (previous=%q)", eventType, store.Namespace(), previousName))
 	return ""
 }
 
 func CleanupPACGitHub(c *clients.Clients, smeeDeploymentName, namespace string) {
-	_ = os.Remove(pullRequestFileName)
-	_ = os.Remove(pushFileName)
+	_ = os.Remove(pullRequestFile())
+	_ = os.Remove(pushFile())
 
 	owner := store.GetScenarioData("PAC_GITHUB_REPO_OWNER")
 	repo := store.GetScenarioData("PAC_GITHUB_REPO_NAME")
-	if owner != "" && repo != "" && ghClient != nil {
-		if _, err := ghClient.Repositories.Delete(context.Background(), owner, repo); err != nil {
-			testsuit.T.Fail(fmt.Errorf("failed to delete github repository %s/%s: %v", owner, repo, err))
+	if owner != "" && repo != "" && gitHubClient() != nil {
+		if _, err := gitHubClient().Repositories.Delete(context.Background(), owner, repo); err != nil {
+			c.Reporter().Fail(fmt.Errorf("failed to delete github repository %s/%s: %v", owner, repo, err))
 		}
 	}
 
 	if err := k8s.DeleteDeployment(c, namespace, smeeDeploymentName); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to delete smee deployment: %v", err))
+		c.Reporter().Fail(fmt.Errorf("failed to delete smee deployment: %v", err))
 	}
 }
@@ -15,7 +15,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/getgauge-contrib/gauge-go/gauge"
 	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
 	pacgenerate "github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/generate"
@@ -27,6 +27,7 @@ import (
 	"github.com/openshift-pipelines/release-tests/pkg/oc"
 	"github.com/openshift-pipelines/release-tests/pkg/opc"
 	"github.com/openshift-pipelines/release-tests/pkg/pipelines"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	gitlab "github.com/xanzy/go-gitlab"
 	yaml "gopkg.in/yaml.v2"
@@ -41,14 +42,30 @@ const (
 	maxRetriesPipelineStatus = 10
 	targetURL                = "http://pipelines-as-code-controller.openshift-pipelines:8080"
 	webhookConfigName        = "gitlab-webhook-config"
-	pullRequestFileName      = "/tmp/pull_request.yaml"
-	pushFileName             = "/tmp/push.yaml"
+	pullRequestFileName      = "pull_request.yaml"
+	pushFileName             = "push.yaml"
+	gitLabClientKey          = "pac.gitlabClient"
 )
 
-var client *gitlab.Client
-
+// SetGitLabClient makes c the GitLab client of the scenario
 func SetGitLabClient(c *gitlab.Client) {
-	client = c
+	gauge.GetScenarioStore()[gitLabClientKey] = c
+}
+
+// gitLabClient returns the GitLab client of the scenario, nil if none was set
+func gitLabClient() *gitlab.Client {
+	c, _ := gauge.GetScenarioStore()[gitLabClientKey].(*gitlab.Client)
+	return c
+}
+
+// pullRequestFile returns the path of the pull_request PipelineRun generated for the scenario
+func pullRequestFile() string {
+	return filepath.Join(store.TempDir(), pullRequestFileName)
+}
+
+// pushFile returns the path of the push PipelineRun generated for the scenario
+func pushFile() string {
+	return filepath.Join(store.TempDir(), pushFileName)
 }
 
 // Initialize Gitlab Client
@@ -56,7 +73,7 @@ func InitGitLabClient() *gitlab.Client {
 	tokenSecretData := os.Getenv("GITLAB_TOKEN")
 	webhookSecretData := os.Getenv("GITLAB_WEBHOOK_TOKEN")
 	if tokenSecretData == "" && webhookSecretData == "" {
-		testsuit.T.Fail(fmt.Errorf("token for authorization to the GitLab repository was not exported as a system variable"))
+		reporter.Default().Fail(fmt.Errorf("token for authorization to the GitLab repository was not exported as a system variable"))
 	} else {
 		if !oc.SecretExists(webhookConfigName, store.Namespace()) {
 			oc.CreateSecretForWebhook(tokenSecretData, webhookSecretData, store.Namespace())
@@ -66,7 +83,7 @@ func InitGitLabClient() *gitlab.Client {
 	}
 	client, err := gitlab.NewClient(tokenSecretData)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to initialize GitLab client: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to initialize GitLab client: %v", err))
 	}
 	return client
 }
@@ -147,12 +164,12 @@ func SetupSmeeDeployment() {
 
 	smeeURL, err := getNewSmeeURL()
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to get a new Smee URL: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to get a new Smee URL: %v", err))
 	}
 	store.PutScenarioData("SMEE_URL", smeeURL)
 
 	if err = createSmeeDeployment(store.Clients(), store.Namespace(), smeeURL); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create deployment: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to create deployment: %v", err))
 	}
 }
 
@@ -160,7 +177,7 @@ func SetupSmeeDeployment() {
 func forkProject(projectID, targetNamespace string) (*gitlab.Project, error) {
 	for i := 0; i < maxRetriesForkProject; i++ {
 		projectName := fmt.Sprintf("release-tests-fork-%08d", time.Now().UnixNano()%1e8)
-		project, _, err := client.Projects.ForkProject(projectID, &gitlab.ForkProjectOptions{
+		project, _, err := gitLabClient().Projects.ForkProject(projectID, &gitlab.ForkProjectOptions{
 			Namespace: &targetNamespace,
 			Name:      &projectName,
 			Path:      &projectName,
@@ -191,7 +208,7 @@ func addWebhook(projectID int, webhookURL, token string) error {
 		Token:               &token,
 	}
 
-	_, _, err := client.Projects.AddProjectHook(projectID, hookOptions)
+	_, _, err := gitLabClient().Projects.AddProjectHook(projectID, hookOptions)
 	if err != nil {
 		return fmt.Errorf("failed to add webhook: %w", err)
 	}
@@ -237,7 +254,7 @@ func createNewRepository(c *clients.Clients, projectName, targetGroupNamespace,
 // addLabelToProject adds a label to a GitLab project
 func addLabelToProject(projectID int, labelName, color, description string) error {
 	// Check if the label already exists
-	labels, _, err := client.Labels.ListLabels(projectID, &gitlab.ListLabelsOptions{})
+	labels, _, err := gitLabClient().Labels.ListLabels(projectID, &gitlab.ListLabelsOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to fetch project labels: %w", err)
 	}
@@ -249,7 +266,7 @@ func addLabelToProject(projectID int, labelName, color, description string) erro
 	}
 
 	// Create label if it doesn't exist
-	_, _, err = client.Labels.CreateLabel(projectID, &gitlab.CreateLabelOptions{
+	_, _, err = gitLabClient().Labels.CreateLabel(projectID, &gitlab.CreateLabelOptions{
 		Name:        gitlab.Ptr(labelName),
 		Color:       gitlab.Ptr(color),
 		Description: gitlab.Ptr(description),
@@ -266,7 +283,7 @@ func SetupGitLabProject() *gitlab.Project {
 	projectIDOrPath := os.Getenv("GITLAB_PROJECT_ID")
 
 	if gitlabGroupNamespace == "" || projectIDOrPath == "" {
-		testsuit.T.Fail(fmt.Errorf("failed to get system variables"))
+		reporter.Default().Fail(fmt.Errorf("failed to get system variables"))
 	}
 
 	smeeURL := store.GetScenarioData("SMEE_URL")
@@ -274,17 +291,17 @@ func SetupGitLabProject() *gitlab.Project {
 
 	project, err := forkProject(projectIDOrPath, gitlabGroupNamespace)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("error during project forking: %w", err))
+		reporter.Default().Fail(fmt.Errorf("error during project forking: %w", err))
 	}
 
 	err = addWebhook(project.ID, smeeURL, webhookToken)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to add webhook: %w", err))
+		reporter.Default().Fail(fmt.Errorf("failed to add webhook: %w", err))
 	}
 
 	err = createNewRepository(store.Clients(), project.Name, gitlabGroupNamespace, store.Namespace())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create repository"))
+		reporter.Default().Fail(fmt.Errorf("failed to create repository"))
 	}
 	store.PutScenarioData("projectID", strconv.Itoa(project.ID))
 
@@ -299,16 +316,16 @@ func AddComment(comment string) {
 		Body: gitlab.Ptr(comment),
 	}
 
-	_, _, err := client.Notes.CreateMergeRequestNote(projectID, mrID, opts)
+	_, _, err := gitLabClient().Notes.CreateMergeRequestNote(projectID, mrID, opts)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to add comment to MR %d in project %d: %v", mrID, projectID, err))
+		reporter.Default().Fail(fmt.Errorf("failed to add comment to MR %d in project %d: %v", mrID, projectID, err))
 	}
 	log.Printf("Successfully added comment %s to merge request %d\n", comment, mrID)
 }
 
 // getCommitSHAForTag resolves a Git tag to its commit SHA in the current GitLab project.
 func getCommitSHAForTag(projectID int, tag string) (string, error) {
-	t, _, err := client.Tags.GetTag(projectID, tag)
+	t, _, err := gitLabClient().Tags.GetTag(projectID, tag)
 	if err != nil {
 		return "", fmt.Errorf("failed to get tag %q: %w", tag, err)
 	}
@@ -322,7 +339,7 @@ func getCommitSHAForTag(projectID int, tag string) (string, error) {
 func CreateTagOnBranch(tagName, branch string) {
 	projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
 		return
 	}
 
@@ -331,8 +348,8 @@ func CreateTagOnBranch(tagName, branch string) {
 		Ref:     gitlab.Ptr(branch),
 	}
 
-	if _, _, err := client.Tags.CreateTag(projectID, opts); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to create tag %q on branch %q: %v", tagName, branch, err))
+	if _, _, err := gitLabClient().Tags.CreateTag(projectID, opts); err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to create tag %q on branch %q: %v", tagName, branch, err))
 		return
 	}
 	log.Printf("Successfully created tag %q on branch %q\n", tagName, branch)
@@ -341,13 +358,13 @@ func CreateTagOnBranch(tagName, branch string) {
 func AddCommitCommentOnTag(comment, tag string) {
 	projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
 		return
 	}
 
 	sha, err := getCommitSHAForTag(projectID, tag)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to resolve tag %q to commit: %v", tag, err))
+		reporter.Default().Fail(fmt.Errorf("failed to resolve tag %q to commit: %v", tag, err))
 		return
 	}
 
@@ -355,8 +372,8 @@ func AddCommitCommentOnTag(comment, tag string) {
 		Note: gitlab.Ptr(comment),
 	}
 
-	if _, _, err := client.Commits.PostCommitComment(projectID, sha, opts); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to add comment %q on tag %q (commit %s): %v", comment, tag, sha, err))
+	if _, _, err := gitLabClient().Commits.PostCommitComment(projectID, sha, opts); err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to add comment %q on tag %q (commit %s): %v", comment, tag, sha, err))
 		return
 	}
 	log.Printf("Successfully added comment %q on tag %q (commit %s)\n", comment, tag, sha)
@@ -369,24 +386,24 @@ func AddLabel(label, color, description string) {
 	// Add a label to the project
 	err := addLabelToProject(projectID, label, color, description)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to add label to project: %w", err))
+		reporter.Default().Fail(fmt.Errorf("failed to add label to project: %w", err))
 	}
 	// Create a LabelOptions instance
 	addLabels := gitlab.LabelOptions{label}
 
 	// Update the merge request to add the label
-	_, _, err = client.MergeRequests.UpdateMergeRequest(projectID, mrID, &gitlab.UpdateMergeRequestOptions{
+	_, _, err = gitLabClient().MergeRequests.UpdateMergeRequest(projectID, mrID, &gitlab.UpdateMergeRequestOptions{
 		AddLabels: &addLabels,
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to update merge request with label 'bug': %w", err))
+		reporter.Default().Fail(fmt.Errorf("failed to update merge request with label 'bug': %w", err))
 	}
 	log.Printf("Successfully added label %s to merge request %d\n", label, mrID)
 }
 
 // Create new branch to push the commit
 func createBranch(projectID int, branchName string) error {
-	_, _, err := client.Branches.CreateBranch(projectID, &gitlab.CreateBranchOptions{
+	_, _, err := gitLabClient().Branches.CreateBranch(projectID, &gitlab.CreateBranchOptions{
 		Branch: gitlab.Ptr(branchName),
 		Ref:    gitlab.Ptr("main"),
 	})
@@ -442,48 +459,48 @@ func validateYAML(yamlContent []byte) error {
 }
 
 func GeneratePipelineRunYaml(eventType, branch string) {
-	fileName := eventType + ".yaml"
+	fileName := filepath.Join(store.TempDir(), "generated-"+eventType+".yaml")
 
 	// Generate the PipelineRun YAML.
 	if err := generatePipelineRun(eventType, branch, fileName); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to generate pipelinerun: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to generate pipelinerun: %v", err))
 	}
 
 	fileContent, err := os.ReadFile(filepath.Clean(fileName))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("could not read file %s: %v", fileName, err))
+		reporter.Default().Fail(fmt.Errorf("could not read file %s: %v", fileName, err))
 	}
 
 	if err := validateYAML(fileContent); err != nil {
-		testsuit.T.Fail(fmt.Errorf("invalid YAML content: %v", err))
+		reporter.Default().Fail(fmt.Errorf("invalid YAML content: %v", err))
 	}
 
 	var destPath string
 	switch eventType {
 	case "pull_request":
-		destPath = pullRequestFileName
+		destPath = pullRequestFile()
 	case "push":
-		destPath = pushFileName
+		destPath = pushFile()
 	default:
-		testsuit.T.Fail(fmt.Errorf("unknown eventType: %s", eventType))
+		reporter.Default().Fail(fmt.Errorf("unknown eventType: %s", eventType))
 	}
-	// #nosec G703 -- destPath is set from validated constants, not user input
+	// #nosec G703 -- destPath is built from constants, not user input
 	if err := os.WriteFile(destPath, fileContent, 0600); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to write %s: %v", destPath, err))
+		reporter.Default().Fail(fmt.Errorf("failed to write %s: %v", destPath, err))
 	}
 }
 
 // updateAnnotation updates the specified annotation in the pull-request.yaml file
 func UpdateAnnotation(annotationKey, annotationValue string) {
-	fileName := pullRequestFileName
+	fileName := pullRequestFile()
 	data, err := os.ReadFile(filepath.Clean(fileName))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to read YAML file: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to read YAML file: %v", err))
 	}
 
 	var content map[string]any
 	if err := yaml.Unmarshal(data, &content); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to unmarshal YAML: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to unmarshal YAML: %v", err))
 	}
 
 	meta := content["metadata"].(map[any]any)
@@ -498,15 +515,15 @@ func UpdateAnnotation(annotationKey, annotationValue string) {
 
 	out, err := yaml.Marshal(content)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to marshal YAML: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to marshal YAML: %v", err))
 	}
 
 	if err := os.WriteFile(fileName, out, 0600); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to write YAML file: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to write YAML file: %v", err))
 	}
 
 	if err := validateYAML(out); err != nil {
-		testsuit.T.Fail(fmt.Errorf("invalid YAML content: %v", err))
+		reporter.Default().Fail(fmt.Errorf("invalid YAML content: %v", err))
 	}
 
 	store.PutScenarioData("fileContent", string(out))
@@ -520,7 +537,7 @@ func createCommit(projectID int, branch, commitMessage, eventType string) error
 
 	switch eventType {
 	case "pull_request":
-		data, err := os.ReadFile(pullRequestFileName)
+		data, err := os.ReadFile(pullRequestFile())
 		if err != nil {
 			return fmt.Errorf("read PR file: %v", err)
 		}
@@ -530,7 +547,7 @@ func createCommit(projectID int, branch, commitMessage, eventType string) error
 			Content:  gitlab.Ptr(string(data)),
 		})
 	case "push":
-		data, err := os.ReadFile(pushFileName)
+		data, err := os.ReadFile(pushFile())
 		if err != nil {
 			return fmt.Errorf("read push file: %v", err)
 		}
@@ -548,7 +565,7 @@ func createCommit(projectID int, branch, commitMessage, eventType string) error
 		CommitMessage: &commitMessage,
 		Actions:       actions,
 	}
-	if _, _, err := client.Commits.CreateCommit(projectID, commitOpts); err != nil {
+	if _, _, err := gitLabClient().Commits.CreateCommit(projectID, commitOpts); err != nil {
 		return fmt.Errorf("failed to create commit: %v", err)
 	}
 	return nil
@@ -561,7 +578,7 @@ func createMergeRequest(projectID int, sourceBranch, targetBranch, title string)
 		TargetBranch: &targetBranch,
 		Title:        &title,
 	}
-	mr, _, err := client.MergeRequests.CreateMergeRequest(projectID, mrOptions)
+	mr, _, err := gitLabClient().MergeRequests.CreateMergeRequest(projectID, mrOptions)
 	if err != nil {
 		return "", err
 	}
@@ -593,7 +610,7 @@ func checkPipelineStatus(projectID, mergeRequestID int) error {
 	const maxDelay = 60 * time.Second
 
 	for {
-		pipelinesList, _, err := client.MergeRequests.ListMergeRequestPipelines(projectID, mergeRequestID)
+		pipelinesList, _, err := gitLabClient().MergeRequests.ListMergeRequestPipelines(projectID, mergeRequestID)
 		if err != nil {
 			return fmt.Errorf("failed to list merge request pipelines: %w", err)
 		}
@@ -626,7 +643,7 @@ func checkPipelineStatus(projectID, mergeRequestID int) error {
 func ConfigurePreviewChanges() {
 	projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("bad projectID: %v", err))
+		reporter.Default().Fail(fmt.Errorf("bad projectID: %v", err))
 	}
 
 	gen := func(n int) (string, error) {
@@ -642,12 +659,12 @@ func ConfigurePreviewChanges() {
 		return string(out), nil
 	}
 	branchExists := func(name string) bool {
-		_, resp, err := client.Branches.GetBranch(projectID, name)
+		_, resp, err := gitLabClient().Branches.GetBranch(projectID, name)
 		if err != nil {
 			if resp != nil && resp.StatusCode == 404 {
 				return false
 			}
-			testsuit.T.Fail(fmt.Errorf("GetBranch(%q): %v", name, err))
+			reporter.Default().Fail(fmt.Errorf("GetBranch(%q): %v", name, err))
 		}
 		return true
 	}
@@ -656,7 +673,7 @@ func ConfigurePreviewChanges() {
 	for range 10 {
 		suf, err := gen(8)
 		if err != nil {
-			testsuit.T.Fail(err)
+			reporter.Default().Fail(err)
 		}
 		n := "preview-" + suf
 		if !branchExists(n) {
@@ -669,28 +686,28 @@ func ConfigurePreviewChanges() {
 	}
 
 	if err := createBranch(projectID, branchName); err != nil {
-		testsuit.T.Fail(fmt.Errorf("createBranch %q: %v", branchName, err))
+		reporter.Default().Fail(fmt.Errorf("createBranch %q: %v", branchName, err))
 	}
 
 	prExists := false
 	pushExists := false
-	if _, err := os.Stat(pullRequestFileName); err == nil {
+	if _, err := os.Stat(pullRequestFile()); err == nil {
 		prExists = true
 	}
-	if _, err := os.Stat(pushFileName); err == nil {
+	if _, err := os.Stat(pushFile()); err == nil {
 		pushExists = true
 	}
 
 	switch {
 	case prExists && pushExists:
 		action := gitlab.FileCreate
-		prData, err := os.ReadFile(pullRequestFileName)
+		prData, err := os.ReadFile(pullRequestFile())
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("read PR file: %v", err))
+			reporter.Default().Fail(fmt.Errorf("read PR file: %v", err))
 		}
-		pushData, err := os.ReadFile(pushFileName)
+		pushData, err := os.ReadFile(pushFile())
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("read push file: %v", err))
+			reporter.Default().Fail(fmt.Errorf("read push file: %v", err))
 		}
 		msg := "ci(pac): add push & pull_request files"
 		commitOpts := &gitlab.CreateCommitOptions{
@@ -701,37 +718,37 @@ func ConfigurePreviewChanges() {
 				{Action: &action, FilePath: gitlab.Ptr(".tekton/push.yaml"), Content: gitlab.Ptr(string(pushData))},
 			},
 		}
-		if _, _, err := client.Commits.CreateCommit(projectID, commitOpts); err != nil {
-			testsuit.T.Fail(fmt.Errorf("commit both: %v", err))
+		if _, _, err := gitLabClient().Commits.CreateCommit(projectID, commitOpts); err != nil {
+			reporter.Default().Fail(fmt.Errorf("commit both: %v", err))
 		}
 	case prExists:
 		if err := createCommit(projectID, branchName, "ci(pac): add pull_request file", "pull_request"); err != nil {
-			testsuit.T.Fail(fmt.Errorf("commit pull_request: %v", err))
+			reporter.Default().Fail(fmt.Errorf("commit pull_request: %v", err))
 		}
 	case pushExists:
 		if err := createCommit(projectID, branchName, "ci(pac): add push file", "push"); err != nil {
-			testsuit.T.Fail(fmt.Errorf("commit push: %v", err))
+			reporter.Default().Fail(fmt.Errorf("commit push: %v", err))
 		}
 	default:
-		testsuit.T.Fail(fmt.Errorf("no pipeline files found to commit in /tmp"))
+		reporter.Default().Fail(fmt.Errorf("no pipeline files found to commit in %s", store.TempDir()))
 	}
 
 	mrURL, err := createMergeRequest(projectID, branchName, "main", "Add preview changes for feature")
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("createMergeRequest: %v", err))
+		reporter.Default().Fail(fmt.Errorf("createMergeRequest: %v", err))
 	}
 	log.Printf("Merge Request Created: %s\n", mrURL)
 
 	mrID, err := extractMergeRequestID(mrURL)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("extract MR ID: %v", err))
+		reporter.Default().Fail(fmt.Errorf("extract MR ID: %v", err))
 	}
 	store.PutScenarioData("mrID", strconv.Itoa(mrID))
 }
 
 // repoFileExists checks if file exists at path on the given branch.
 func repoFileExists(projectID int, branch, path string) (bool, error) {
-	f, resp, err := client.RepositoryFiles.GetFile(projectID, path, &gitlab.GetFileOptions{Ref: gitlab.Ptr(branch)})
+	f, resp, err := gitLabClient().RepositoryFiles.GetFile(projectID, path, &gitlab.GetFileOptions{Ref: gitlab.Ptr(branch)})
 	if err != nil {
 		// If the API returns 404, it's simply absent; any other error is real
 		if resp != nil && resp.StatusCode == 404 {
@@ -745,12 +762,12 @@ func repoFileExists(projectID int, branch, path string) (bool, error) {
 func TriggerPushOnForkMain() {
 	projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
 	}
 
-	data, err := os.ReadFile("/tmp/push.yaml")
+	data, err := os.ReadFile(pushFile())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to read /tmp/push.yaml: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to read %s: %v", pushFile(), err))
 	}
 	pushFileContent := string(data)
 
@@ -760,7 +777,7 @@ func TriggerPushOnForkMain() {
 
 	exists, err := repoFileExists(projectID, branch, pushYamlPath)
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	var actionPushYaml gitlab.FileActionValue
@@ -792,8 +809,8 @@ func TriggerPushOnForkMain() {
 		Actions:       actions,
 	}
 
-	if _, _, err := client.Commits.CreateCommit(projectID, commitOpts); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to commit push.yaml+trigger to main: %v", err))
+	if _, _, err := gitLabClient().Commits.CreateCommit(projectID, commitOpts); err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to commit push.yaml+trigger to main: %v", err))
 	}
 }
 
@@ -802,16 +819,16 @@ func GetPipelineName(validateMR bool) (pipelineName string) {
 	if validateMR {
 		projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
+			reporter.Default().Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
 		}
 		mrID, err := strconv.Atoi(store.GetScenarioData("mrID"))
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("failed to convert MR ID to integer: %v", err))
+			reporter.Default().Fail(fmt.Errorf("failed to convert MR ID to integer: %v", err))
 		}
 
 		err = checkPipelineStatus(projectID, mrID)
 		if err != nil {
-			testsuit.T.Fail(fmt.Errorf("failed to check pipeline status: %v", err))
+			reporter.Default().Fail(fmt.Errorf("failed to check pipeline status: %v", err))
 		}
 	} else {
 		time.Sleep(10 * time.Second)
@@ -819,7 +836,7 @@ func GetPipelineName(validateMR bool) (pipelineName string) {
 
 	pipelineName, err := pipelines.GetLatestPipelinerun(store.Clients(), store.Namespace())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to get the latest Pipelinerun: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to get the latest Pipelinerun: %v", err))
 	}
 	return pipelineName
 }
@@ -835,23 +852,23 @@ func GetPushPipelineNameFromMain() (pipelineName string) {
 // getPipelineRunNameFromPushYAML reads the generated push.yaml and returns the
 // PipelineRun metadata.name defined there.
 func getPipelineRunNameFromPushYAML() string {
-	data, err := os.ReadFile(pushFileName)
+	data, err := os.ReadFile(pushFile())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to read push YAML file %s: %v", pushFileName, err))
+		reporter.Default().Fail(fmt.Errorf("failed to read push YAML file %s: %v", pushFile(), err))
 	}
 
 	var content map[string]any
 	if err := yaml.Unmarshal(data, &content); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to unmarshal push YAML: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to unmarshal push YAML: %v", err))
 	}
 
 	meta, ok := content["metadata"].(map[any]any)
 	if !ok {
-		testsuit.T.Fail(fmt.Errorf("push YAML missing metadata section"))
+		reporter.Default().Fail(fmt.Errorf("push YAML missing metadata section"))
 	}
 	nameVal, ok := meta["name"].(string)
 	if !ok || nameVal == "" {
-		testsuit.T.Fail(fmt.Errorf("push YAML missing or invalid metadata.name"))
+		reporter.Default().Fail(fmt.Errorf("push YAML missing or invalid metadata.name"))
 	}
 	log.Printf("PipelineRun name from push.yaml: %s\n", nameVal)
 	return nameVal
@@ -859,19 +876,19 @@ func getPipelineRunNameFromPushYAML() string {
 
 // UpdatePushOnTargetBranch updates the pipelinesascode.tekton.dev/on-target-branch
 func UpdatePushOnTargetBranch(target string) {
-	data, err := os.ReadFile(pushFileName)
+	data, err := os.ReadFile(pushFile())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to read push YAML file %s: %v", pushFileName, err))
+		reporter.Default().Fail(fmt.Errorf("failed to read push YAML file %s: %v", pushFile(), err))
 	}
 
 	var content map[string]any
 	if err := yaml.Unmarshal(data, &content); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to unmarshal push YAML: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to unmarshal push YAML: %v", err))
 	}
 
 	meta, ok := content["metadata"].(map[any]any)
 	if !ok {
-		testsuit.T.Fail(fmt.Errorf("push YAML missing metadata section"))
+		reporter.Default().Fail(fmt.Errorf("push YAML missing metadata section"))
 	}
 	anns, ok := meta["annotations"].(map[any]any)
 	if !ok {
@@ -883,18 +900,18 @@ func UpdatePushOnTargetBranch(target string) {
 
 	out, err := yaml.Marshal(content)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to marshal updated push YAML: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to marshal updated push YAML: %v", err))
 	}
 
-	if err := os.WriteFile(pushFileName, out, 0600); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to write updated push YAML file: %v", err))
+	if err := os.WriteFile(pushFile(), out, 0600); err != nil {
+		reporter.Default().Fail(fmt.Errorf("failed to write updated push YAML file: %v", err))
 	}
 
 	if err := validateYAML(out); err != nil {
-		testsuit.T.Fail(fmt.Errorf("invalid YAML content after updating on-target-branch: %v", err))
+		reporter.Default().Fail(fmt.Errorf("invalid YAML content after updating on-target-branch: %v", err))
 	}
 
-	log.Printf("Updated pipelinesascode.tekton.dev/on-target-branch to %q in %s\n", target, pushFileName)
+	log.Printf("Updated pipelinesascode.tekton.dev/on-target-branch to %q in %s\n", target, pushFile())
 }
 
 func AddTestCommentForLatestPipelineRunOnTag(tag string) {
@@ -912,7 +929,7 @@ func AddCancelCommentForLatestPipelineRunOnTag(tag string) {
 func AssertPACInfoInstall() {
 	pacInfo, err := opc.GetOpcPacInfoInstall()
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to get pac info: %v", err))
+		reporter.Default().Fail(fmt.Errorf("failed to get pac info: %v", err))
 		return
 	}
 
@@ -920,14 +937,14 @@ func AssertPACInfoInstall() {
 	expectedVersion := os.Getenv("PAC_VERSION")
 
 	if !strings.Contains(clusterVersion, expectedVersion) ||
-		pacInfo.PipelinesAsCode.InstallNamespace != config.TargetNamespace {
-		testsuit.T.Fail(fmt.Errorf("PAC version %s doesn't match the expected version %s or namespace %s is wrong",
+		pacInfo.PipelinesAsCode.InstallNamespace != config.TargetNamespace() {
+		reporter.Default().Fail(fmt.Errorf("PAC version %s doesn't match the expected version %s or namespace %s is wrong",
 			clusterVersion, expectedVersion, pacInfo.PipelinesAsCode.InstallNamespace))
 	}
 }
 
 func deleteGitlabProject(projectID int) error {
-	_, err := client.Projects.DeleteProject(projectID)
+	_, err := gitLabClient().Projects.DeleteProject(projectID)
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
@@ -937,20 +954,20 @@ func deleteGitlabProject(projectID int) error {
 
 func CleanupPAC(c *clients.Clients, smeeDeploymentName, namespace string) {
 	// Remove the generated PipelineRun YAML files
-	_ = os.Remove(pullRequestFileName)
-	_ = os.Remove(pushFileName)
+	_ = os.Remove(pullRequestFile())
+	_ = os.Remove(pushFile())
 
 	projectID, err := strconv.Atoi(store.GetScenarioData("projectID"))
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
+		c.Reporter().Fail(fmt.Errorf("failed to convert project ID to integer: %v", err))
 	}
 	// Remove Forked Project
 	if cleanupErr := deleteGitlabProject(projectID); cleanupErr != nil {
-		testsuit.T.Fail(fmt.Errorf("cleanup failed: %v", cleanupErr))
+		c.Reporter().Fail(fmt.Errorf("cleanup failed: %v", cleanupErr))
 	}
 
 	// Delete Smee Deployment
 	if err = k8s.DeleteDeployment(c, namespace, smeeDeploymentName); err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to Delete Smee Deployment: %v", err))
+		c.Reporter().Fail(fmt.Errorf("failed to Delete Smee Deployment: %v", err))
 	}
 }
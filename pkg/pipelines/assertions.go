@@ -0,0 +1,416 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/wait"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Expectation is the expected value of a result or a param
+type Expectation struct {
+	// Name is the name of the result or param, followed by the key of an
+	// object or the index of an array between brackets to check one of its
+	// values, e.g. report[url] or images[0]
+	Name string
+	// Operator is equals, the default, or matches, Value being a regular
+	// expression
+	Operator string
+	// Value is compared to strings, and to the JSON of whole arrays and
+	// objects, e.g. ["a","b"] or {"url":"https://example.com"}
+	Value string
+}
+
+// ChildStatus is the expected status of the runs of a PipelineTask
+type ChildStatus struct {
+	PipelineTask string
+	// Status is successful, failed or running, like the status given to
+	// ValidatePipelineRun
+	Status string
+	// Reason is the reason of the Succeeded condition of the runs, e.g.
+	// Succeeded, Failed or TaskRunTimeout. It is not checked if empty.
+	Reason string
+}
+
+// SkippedTask is a PipelineTask expected to be skipped
+type SkippedTask struct {
+	PipelineTask string
+	// Reason is the skipping reason, e.g. "When Expressions evaluated to
+	// false". It is not checked if empty.
+	Reason string
+}
+
+// childRun is a TaskRun or CustomRun of a pipeline run
+type childRun struct {
+	kind, name     string
+	condition      *apis.Condition
+	start, stopped *metav1.Time
+}
+
+var selector = regexp.MustCompile(`^(.+)\[([^\]]+)\]$`)
+
+// AssertPipelineRunResults waits for the pipeline run to complete and checks
+// its results
+func AssertPipelineRunResults(c *clients.Clients, prname, namespace string, expected []Expectation) {
+	pr := completedPipelineRun(c, prname, namespace)
+	values := map[string]v1.ParamValue{}
+	for _, r := range pr.Status.Results {
+		values[r.Name] = r.Value
+	}
+	assertValues(c, "result", "pipeline run "+prname, values, expected)
+}
+
+// AssertTaskRunResults waits for the task run to complete and checks its
+// results
+func AssertTaskRunResults(c *clients.Clients, trname, namespace string, expected []Expectation) {
+	tr := completedTaskRun(c, trname, namespace)
+	assertValues(c, "result", "task run "+trname, taskRunResults(tr), expected)
+}
+
+// AssertTaskRunParams checks the params of the task run, their values being
+// substituted by the pipeline run creating it, if any, e.g. with the results
+// of other tasks
+func AssertTaskRunParams(c *clients.Clients, trname, namespace string, expected []Expectation) {
+	tr, err := c.TaskRunClient.Get(c.Ctx, trname, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get task run %s in namespace %s: %v", trname, namespace, err))
+		return
+	}
+	values := map[string]v1.ParamValue{}
+	for _, p := range tr.Spec.Params {
+		values[p.Name] = p.Value
+	}
+	assertValues(c, "param", "task run "+trname, values, expected)
+}
+
+// AssertPipelineTaskResults waits for the pipeline run to complete and checks
+// the results of the task run of the PipelineTask
+func AssertPipelineTaskResults(c *clients.Clients, prname, pipelineTask, namespace string, expected []Expectation) {
+	AssertTaskRunResults(c, pipelineTaskRun(c, prname, pipelineTask, namespace), namespace, expected)
+}
+
+// AssertPipelineTaskParams waits for the pipeline run to complete and checks
+// the params of the task run of the PipelineTask
+func AssertPipelineTaskParams(c *clients.Clients, prname, pipelineTask, namespace string, expected []Expectation) {
+	AssertTaskRunParams(c, pipelineTaskRun(c, prname, pipelineTask, namespace), namespace, expected)
+}
+
+// AssertChildStatuses waits for the pipeline run to complete and checks the
+// status of the runs of each PipelineTask, e.g. that a task failed with the
+// TaskRunTimeout reason while the others succeeded
+func AssertChildStatuses(c *clients.Clients, prname, namespace string, expected []ChildStatus) {
+	pr := completedPipelineRun(c, prname, namespace)
+	for _, e := range expected {
+		assertChildStatus(c, pr, e)
+	}
+}
+
+// AssertSkippedTasks waits for the pipeline run to complete and checks that
+// the PipelineTasks were skipped, with their skipping reason, and did not run
+func AssertSkippedTasks(c *clients.Clients, prname, namespace string, expected []SkippedTask) {
+	pr := completedPipelineRun(c, prname, namespace)
+	for _, e := range expected {
+		i := slices.IndexFunc(pr.Status.SkippedTasks, func(t v1.SkippedTask) bool { return t.Name == e.PipelineTask })
+		if i < 0 {
+			c.Reporter().Errorf("task %s of pipeline run %s was not skipped, skipped tasks: %v", e.PipelineTask, prname, skippedTaskNames(pr))
+			continue
+		}
+		if reason := string(pr.Status.SkippedTasks[i].Reason); e.Reason != "" && reason != e.Reason {
+			c.Reporter().Errorf("expected task %s of pipeline run %s to be skipped with reason %q, got %q", e.PipelineTask, prname, e.Reason, reason)
+		}
+		if runs := childRuns(c, pr, e.PipelineTask); len(runs) > 0 {
+			c.Reporter().Errorf("skipped task %s of pipeline run %s has run %s", e.PipelineTask, prname, runs[0].name)
+		}
+	}
+}
+
+// AssertFinalTasks waits for the pipeline run to complete and checks that the
+// PipelineTasks are finally tasks of its pipeline, have the status, and only
+// started once every other task was done
+func AssertFinalTasks(c *clients.Clients, prname, namespace string, expected []ChildStatus) {
+	pr := completedPipelineRun(c, prname, namespace)
+	var finally []string
+	if pr.Status.PipelineSpec != nil {
+		for _, t := range pr.Status.PipelineSpec.Finally {
+			finally = append(finally, t.Name)
+		}
+	}
+	// the last completion of the runs of the tasks
+	var tasksDone *metav1.Time
+	for _, ref := range pr.Status.ChildReferences {
+		if slices.Contains(finally, ref.PipelineTaskName) {
+			continue
+		}
+		for _, run := range childRuns(c, pr, ref.PipelineTaskName) {
+			if run.stopped != nil && (tasksDone == nil || tasksDone.Before(run.stopped)) {
+				tasksDone = run.stopped
+			}
+		}
+	}
+	for _, e := range expected {
+		if !slices.Contains(finally, e.PipelineTask) {
+			c.Reporter().Errorf("task %s of pipeline run %s is not a finally task, finally tasks: %v", e.PipelineTask, prname, finally)
+			continue
+		}
+		for _, run := range assertChildStatus(c, pr, e) {
+			if tasksDone != nil && run.start != nil && run.start.Before(tasksDone) {
+				c.Reporter().Errorf("finally task %s of pipeline run %s started at %s, before the other tasks were done at %s",
+					e.PipelineTask, prname, run.start, tasksDone)
+			}
+		}
+	}
+}
+
+// completedPipelineRun waits for the pipeline run to complete and returns it
+func completedPipelineRun(c *clients.Clients, prname, namespace string) *v1.PipelineRun {
+	log.Printf("Waiting for PipelineRun %s in namespace %s to complete", prname, namespace)
+	if err := wait.WaitForPipelineRunState(c, prname, wait.Done(prname), "PipelineRunDone"); err != nil {
+		c.Reporter().Fail(fmt.Errorf("error waiting for pipeline run %s in namespace %s to complete \n %v", prname, namespace, err))
+	}
+	pr, err := c.PipelineRunClient.Get(c.Ctx, prname, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get pipeline run %s in namespace %s: %v", prname, namespace, err))
+	}
+	return pr
+}
+
+// completedTaskRun waits for the task run to complete and returns it
+func completedTaskRun(c *clients.Clients, trname, namespace string) *v1.TaskRun {
+	log.Printf("Waiting for TaskRun %s in namespace %s to complete", trname, namespace)
+	if err := wait.WaitForTaskRunState(c, trname, wait.Done(trname), "TaskRunDone"); err != nil {
+		c.Reporter().Fail(fmt.Errorf("error waiting for task run %s in namespace %s to complete \n %v", trname, namespace, err))
+	}
+	tr, err := c.TaskRunClient.Get(c.Ctx, trname, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Fail(fmt.Errorf("failed to get task run %s in namespace %s: %v", trname, namespace, err))
+	}
+	return tr
+}
+
+func taskRunResults(tr *v1.TaskRun) map[string]v1.ParamValue {
+	values := map[string]v1.ParamValue{}
+	for _, r := range tr.Status.Results {
+		values[r.Name] = r.Value
+	}
+	return values
+}
+
+// pipelineTaskRun waits for the pipeline run to complete and returns the name
+// of the task run of the PipelineTask
+func pipelineTaskRun(c *clients.Clients, prname, pipelineTask, namespace string) string {
+	pr := completedPipelineRun(c, prname, namespace)
+	for _, ref := range pr.Status.ChildReferences {
+		if ref.PipelineTaskName == pipelineTask && ref.Kind == pipeline.TaskRunControllerName {
+			return ref.Name
+		}
+	}
+	c.Reporter().Fail(fmt.Errorf("no task run of task %s in pipeline run %s in namespace %s", pipelineTask, prname, namespace))
+	return ""
+}
+
+// childRuns returns the runs of the PipelineTask, several for a matrix
+func childRuns(c *clients.Clients, pr *v1.PipelineRun, pipelineTask string) []childRun {
+	var runs []childRun
+	for _, ref := range pr.Status.ChildReferences {
+		if ref.PipelineTaskName != pipelineTask {
+			continue
+		}
+		run := childRun{kind: ref.Kind, name: ref.Name}
+		switch ref.Kind {
+		case pipeline.TaskRunControllerName:
+			tr, err := c.TaskRunClient.Get(c.Ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				c.Reporter().Fail(fmt.Errorf("failed to get task run %s of pipeline run %s: %v", ref.Name, pr.Name, err))
+			}
+			run.condition = tr.Status.GetCondition(apis.ConditionSucceeded)
+			run.start, run.stopped = tr.Status.StartTime, tr.Status.CompletionTime
+		default:
+			cr, err := c.Tekton.TektonV1beta1().CustomRuns(pr.Namespace).Get(c.Ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				c.Reporter().Fail(fmt.Errorf("failed to get custom run %s of pipeline run %s: %v", ref.Name, pr.Name, err))
+			}
+			run.condition = cr.Status.GetCondition(apis.ConditionSucceeded)
+			run.start, run.stopped = cr.Status.StartTime, cr.Status.CompletionTime
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// assertChildStatus checks the status of the runs of the PipelineTask and
+// returns them
+func assertChildStatus(c *clients.Clients, pr *v1.PipelineRun, e ChildStatus) []childRun {
+	want := expectedRunStatus(e.Status)
+	if want == "" {
+		c.Reporter().Fail(fmt.Errorf("invalid status %q of task %s, expected successful, failed or running", e.Status, e.PipelineTask))
+	}
+	runs := childRuns(c, pr, e.PipelineTask)
+	if len(runs) == 0 {
+		c.Reporter().Errorf("task %s of pipeline run %s did not run, skipped tasks: %v", e.PipelineTask, pr.Name, skippedTaskNames(pr))
+		return nil
+	}
+	for _, run := range runs {
+		if run.condition == nil {
+			c.Reporter().Errorf("%s %s of task %s has no succeeded condition", run.kind, run.name, e.PipelineTask)
+			continue
+		}
+		if status := runStatus(run.condition); status != want {
+			c.Reporter().Errorf("expected %s %s of task %s to be %s, it is %s: %s %s",
+				run.kind, run.name, e.PipelineTask, e.Status, status, run.condition.Reason, run.condition.Message)
+		}
+		if e.Reason != "" && run.condition.Reason != e.Reason {
+			c.Reporter().Errorf("expected %s %s of task %s to have reason %s, got %s: %s",
+				run.kind, run.name, e.PipelineTask, e.Reason, run.condition.Reason, run.condition.Message)
+		}
+	}
+	return runs
+}
+
+// runStatus returns success, fail or running, as matched in the statuses
+// given to ValidatePipelineRun
+func runStatus(condition *apis.Condition) string {
+	switch {
+	case condition.IsTrue():
+		return "success"
+	case condition.IsFalse():
+		return "fail"
+	default:
+		return "running"
+	}
+}
+
+// expectedRunStatus normalises the status given to ValidatePipelineRun, e.g.
+// successful, Failed or Running, to the one of runStatus, empty if it is none
+// of them
+func expectedRunStatus(status string) string {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, "success"):
+		return "success"
+	case strings.Contains(status, "fail"):
+		return "fail"
+	case strings.Contains(status, "running"):
+		return "running"
+	default:
+		return ""
+	}
+}
+
+func skippedTaskNames(pr *v1.PipelineRun) []string {
+	var skipped []string
+	for _, t := range pr.Status.SkippedTasks {
+		skipped = append(skipped, t.Name)
+	}
+	return skipped
+}
+
+// assertValues checks the values of the results or params of the run
+func assertValues(c *clients.Clients, kind, run string, values map[string]v1.ParamValue, expected []Expectation) {
+	for _, e := range expected {
+		actual, err := value(values, e.Name)
+		if err != nil {
+			c.Reporter().Errorf("%s %s of %s: %v", kind, e.Name, run, err)
+			continue
+		}
+		if err := compare(actual, e); err != nil {
+			c.Reporter().Errorf("%s %s of %s: %v", kind, e.Name, run, err)
+		}
+	}
+}
+
+// value returns the value of the name, selecting a key of an object or an
+// index of an array between brackets
+func value(values map[string]v1.ParamValue, name string) (v1.ParamValue, error) {
+	if v, ok := values[name]; ok {
+		return v, nil
+	}
+	m := selector.FindStringSubmatch(name)
+	if m == nil {
+		return v1.ParamValue{}, fmt.Errorf("not found in %v", names(values))
+	}
+	v, ok := values[m[1]]
+	if !ok {
+		return v1.ParamValue{}, fmt.Errorf("%s not found in %v", m[1], names(values))
+	}
+	switch v.Type {
+	case v1.ParamTypeArray:
+		i, err := strconv.Atoi(m[2])
+		if err != nil || i < 0 || i >= len(v.ArrayVal) {
+			return v1.ParamValue{}, fmt.Errorf("no index %s in %s of %d values", m[2], m[1], len(v.ArrayVal))
+		}
+		return *v1.NewStructuredValues(v.ArrayVal[i]), nil
+	case v1.ParamTypeObject:
+		s, ok := v.ObjectVal[m[2]]
+		if !ok {
+			return v1.ParamValue{}, fmt.Errorf("no key %s in %s", m[2], m[1])
+		}
+		return *v1.NewStructuredValues(s), nil
+	default:
+		return v1.ParamValue{}, fmt.Errorf("%s is a string, not an array or an object", m[1])
+	}
+}
+
+// compare compares the value to the expectation
+func compare(actual v1.ParamValue, e Expectation) error {
+	s := format(actual)
+	switch e.Operator {
+	case "", "equals":
+		if actual.Type == v1.ParamTypeString {
+			if s != e.Value {
+				return fmt.Errorf("expected %q, got %q", e.Value, s)
+			}
+			return nil
+		}
+		var expected v1.ParamValue
+		if err := json.Unmarshal([]byte(e.Value), &expected); err != nil || expected.Type != actual.Type {
+			return fmt.Errorf("expected %s, got the %s %s", e.Value, actual.Type, s)
+		}
+		if !reflect.DeepEqual(expected.ArrayVal, actual.ArrayVal) || !reflect.DeepEqual(expected.ObjectVal, actual.ObjectVal) {
+			return fmt.Errorf("expected %s, got %s", e.Value, s)
+		}
+		return nil
+	case "matches":
+		re, err := regexp.Compile(e.Value)
+		if err != nil {
+			return fmt.Errorf("invalid regular expression %q: %v", e.Value, err)
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("expected to match %q, got %q", e.Value, s)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown operator %q, expected equals or matches", e.Operator)
+	}
+}
+
+// format returns the string value, or the JSON of an array or an object
+func format(v v1.ParamValue) string {
+	if v.Type == v1.ParamTypeString {
+		return v.StringVal
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func names(values map[string]v1.ParamValue) []string {
+	var list []string
+	for name := range values {
+		list = append(list, name)
+	}
+	slices.Sort(list)
+	return list
+}
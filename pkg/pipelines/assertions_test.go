@@ -0,0 +1,276 @@
+package pipelines
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var testValues = map[string]v1.ParamValue{
+	"digest": *v1.NewStructuredValues("sha256:0123"),
+	"images": *v1.NewStructuredValues("a", "b"),
+	"report": *v1.NewObject(map[string]string{"url": "https://example.com"}),
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{{
+		name: "digest",
+		want: "sha256:0123",
+	}, {
+		name: "images",
+		want: `["a","b"]`,
+	}, {
+		name: "images[1]",
+		want: "b",
+	}, {
+		name: "report[url]",
+		want: "https://example.com",
+	}, {
+		name:    "missing",
+		wantErr: "not found in [digest images report]",
+	}, {
+		name:    "missing[0]",
+		wantErr: "missing not found",
+	}, {
+		name:    "images[2]",
+		wantErr: "no index 2 in images of 2 values",
+	}, {
+		name:    "images[first]",
+		wantErr: "no index first",
+	}, {
+		name:    "report[name]",
+		wantErr: "no key name in report",
+	}, {
+		name:    "digest[0]",
+		wantErr: "digest is a string",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := value(testValues, tt.name)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("value() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("value() error = %v", err)
+			}
+			if format(got) != tt.want {
+				t.Errorf("value() = %s, want %s", format(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  v1.ParamValue
+		e       Expectation
+		wantErr string
+	}{{
+		name:   "string equals",
+		actual: testValues["digest"],
+		e:      Expectation{Value: "sha256:0123"},
+	}, {
+		name:    "string differs",
+		actual:  testValues["digest"],
+		e:       Expectation{Operator: "equals", Value: "sha256:4567"},
+		wantErr: `expected "sha256:4567", got "sha256:0123"`,
+	}, {
+		name:   "array equals",
+		actual: testValues["images"],
+		e:      Expectation{Value: `["a", "b"]`},
+	}, {
+		name:    "array differs",
+		actual:  testValues["images"],
+		e:       Expectation{Value: `["b","a"]`},
+		wantErr: `expected ["b","a"], got ["a","b"]`,
+	}, {
+		name:    "array compared to an object",
+		actual:  testValues["images"],
+		e:       Expectation{Value: `{"a":"b"}`},
+		wantErr: `got the array ["a","b"]`,
+	}, {
+		name:   "object equals",
+		actual: testValues["report"],
+		e:      Expectation{Value: `{"url":"https://example.com"}`},
+	}, {
+		name:    "object differs",
+		actual:  testValues["report"],
+		e:       Expectation{Value: `{"url":"https://example.org"}`},
+		wantErr: `expected {"url":"https://example.org"}`,
+	}, {
+		name:   "matches",
+		actual: testValues["digest"],
+		e:      Expectation{Operator: "matches", Value: "^sha256:[0-9a-f]+$"},
+	}, {
+		name:   "array matches",
+		actual: testValues["images"],
+		e:      Expectation{Operator: "matches", Value: `"b"\]$`},
+	}, {
+		name:    "does not match",
+		actual:  testValues["digest"],
+		e:       Expectation{Operator: "matches", Value: "^sha512:"},
+		wantErr: `expected to match "^sha512:"`,
+	}, {
+		name:    "invalid regular expression",
+		actual:  testValues["digest"],
+		e:       Expectation{Operator: "matches", Value: "sha256:("},
+		wantErr: "invalid regular expression",
+	}, {
+		name:    "unknown operator",
+		actual:  testValues["digest"],
+		e:       Expectation{Operator: "contains", Value: "sha256"},
+		wantErr: `unknown operator "contains"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compare(tt.actual, tt.e)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("compare() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("compare() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssertTaskRunParams(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "test-ns"},
+		Spec: v1.TaskRunSpec{Params: v1.Params{
+			{Name: "digest", Value: testValues["digest"]},
+			{Name: "images", Value: testValues["images"]},
+		}},
+	}
+	tests := []struct {
+		name     string
+		expected []Expectation
+		wantErr  string
+	}{{
+		name: "matching",
+		expected: []Expectation{
+			{Name: "digest", Value: "sha256:0123"},
+			{Name: "images[0]", Value: "a"},
+		},
+	}, {
+		name: "mismatching",
+		expected: []Expectation{
+			{Name: "digest", Value: "sha256:0123"},
+			{Name: "images", Value: `["a"]`},
+		},
+		wantErr: `param images of task run tr: expected ["a"]`,
+	}, {
+		name:     "missing",
+		expected: []Expectation{{Name: "report", Value: "https://example.com"}},
+		wantErr:  "param report of task run tr: not found in [digest images]",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tr)
+			err := testutil.CaptureFailures(func() {
+				AssertTaskRunParams(c, "tr", "test-ns", tt.expected)
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAssertChildStatuses(t *testing.T) {
+	pr := pipelineRun("pr", corev1.ConditionFalse, "Failed")
+	pr.Status.ChildReferences = []v1.ChildStatusReference{
+		{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, PipelineTaskName: "build", Name: "pr-build"},
+		{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, PipelineTaskName: "test", Name: "pr-test"},
+		{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, PipelineTaskName: "deploy", Name: "pr-deploy"},
+	}
+	taskRun := func(name string, status corev1.ConditionStatus, reason string) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: status,
+				Reason: reason,
+			}}}},
+		}
+	}
+	objects := []runtime.Object{
+		pr,
+		taskRun("pr-build", corev1.ConditionTrue, "Succeeded"),
+		taskRun("pr-test", corev1.ConditionFalse, "Failed"),
+		taskRun("pr-deploy", corev1.ConditionUnknown, "Running"),
+	}
+	tests := []struct {
+		name     string
+		expected ChildStatus
+		wantErr  string
+	}{
+		{name: "successful", expected: ChildStatus{PipelineTask: "build", Status: "successful", Reason: "Succeeded"}},
+		{name: "success", expected: ChildStatus{PipelineTask: "build", Status: "Success"}},
+		{name: "failed", expected: ChildStatus{PipelineTask: "test", Status: "failed", Reason: "Failed"}},
+		{name: "failure", expected: ChildStatus{PipelineTask: "test", Status: "Failure"}},
+		{name: "running", expected: ChildStatus{PipelineTask: "deploy", Status: "Running"}},
+		{
+			name:     "failed instead of successful",
+			expected: ChildStatus{PipelineTask: "test", Status: "successful"},
+			wantErr:  "expected TaskRun pr-test of task test to be successful, it is fail",
+		},
+		{
+			name:     "successful instead of failed",
+			expected: ChildStatus{PipelineTask: "build", Status: "failed"},
+			wantErr:  "expected TaskRun pr-build of task build to be failed, it is success",
+		},
+		{
+			name:     "running instead of successful",
+			expected: ChildStatus{PipelineTask: "deploy", Status: "successful"},
+			wantErr:  "expected TaskRun pr-deploy of task deploy to be successful, it is running",
+		},
+		{
+			name:     "wrong reason",
+			expected: ChildStatus{PipelineTask: "test", Status: "failed", Reason: "TaskRunTimeout"},
+			wantErr:  "to have reason TaskRunTimeout, got Failed",
+		},
+		{
+			name:     "invalid status",
+			expected: ChildStatus{PipelineTask: "build", Status: "done"},
+			wantErr:  `invalid status "done" of task build`,
+		},
+		{
+			name:     "did not run",
+			expected: ChildStatus{PipelineTask: "lint", Status: "successful"},
+			wantErr:  "task lint of pipeline run pr did not run",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", objects...)
+			err := testutil.CaptureFailures(func() {
+				AssertChildStatuses(c, "pr", "test-ns", []ChildStatus{tt.expected})
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
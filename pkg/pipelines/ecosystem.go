@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,7 +21,7 @@ func AssertTaskPresent(c *clients.Clients, namespace string, taskName string) {
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("tasks %v Expected: Present, Actual: Not Present, Error: %v", taskName, err))
+		c.Reporter().Fail(fmt.Errorf("tasks %v Expected: Present, Actual: Not Present, Error: %v", taskName, err))
 	} else {
 		log.Printf("Task %v is present", taskName)
 	}
@@ -38,7 +37,7 @@ func AssertTaskNotPresent(c *clients.Clients, namespace string, taskName string)
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("tasks %v Expected: Not Present, Actual: Present, Error: %v", taskName, err))
+		c.Reporter().Fail(fmt.Errorf("tasks %v Expected: Not Present, Actual: Present, Error: %v", taskName, err))
 	} else {
 		log.Printf("Task %v is not present", taskName)
 	}
@@ -54,7 +53,7 @@ func AssertStepActionPresent(c *clients.Clients, namespace string, stepActionNam
 		return false, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("StepAction %v Expected: Present, Actual: Not Present, Error: %v", stepActionName, err))
+		c.Reporter().Fail(fmt.Errorf("StepAction %v Expected: Present, Actual: Not Present, Error: %v", stepActionName, err))
 	} else {
 		log.Printf("StepAction %v is present", stepActionName)
 	}
@@ -70,7 +69,7 @@ func AssertStepActionNotPresent(c *clients.Clients, namespace string, stepAction
 		return true, nil
 	})
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("StepAction %v Expected: Not Present, Actual: Present, Error: %v", stepActionName, err))
+		c.Reporter().Fail(fmt.Errorf("StepAction %v Expected: Not Present, Actual: Present, Error: %v", stepActionName, err))
 	} else {
 		log.Printf("StepAction %v is not present", stepActionName)
 	}
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -19,11 +19,11 @@ func GetPodForTaskRun(c *clients.Clients, namespace string, tr *v1.TaskRun) *cor
 		LabelSelector: pipeline.TaskRunLabelKey + " = " + tr.Name,
 	})
 	if err != nil {
-		testsuit.T.Errorf("failed to get pod for task run %s \n %v", tr.Name, err)
+		c.Reporter().Errorf("failed to get pod for task run %s \n %v", tr.Name, err)
 	}
 
 	if numPods := len(pods.Items); numPods != 1 {
-		testsuit.T.Errorf("Expected 1 pod for task run %s, but got %d pods", tr.Name, numPods)
+		c.Reporter().Errorf("Expected 1 pod for task run %s, but got %d pods", tr.Name, numPods)
 	}
 	return &pods.Items[0]
 }
@@ -31,7 +31,7 @@ func GetPodForTaskRun(c *clients.Clients, namespace string, tr *v1.TaskRun) *cor
 func AssertLabelsMatch(expectedLabels, actualLabels map[string]string) {
 	for key, expectedVal := range expectedLabels {
 		if actualVal := actualLabels[key]; actualVal != expectedVal {
-			testsuit.T.Errorf("Expected labels containing %s=%s but labels were %v", key, expectedVal, actualLabels)
+			reporter.Default().Errorf("Expected labels containing %s=%s but labels were %v", key, expectedVal, actualLabels)
 		}
 	}
 }
@@ -39,7 +39,7 @@ func AssertLabelsMatch(expectedLabels, actualLabels map[string]string) {
 func AssertAnnotationsMatch(expectedAnnotations, actualAnnotations map[string]string) {
 	for key, expectedVal := range expectedAnnotations {
 		if actualVal := actualAnnotations[key]; actualVal != expectedVal {
-			testsuit.T.Errorf("Expected annotations containing %s=%s but annotations were %v", key, expectedVal, actualAnnotations)
+			reporter.Default().Errorf("Expected annotations containing %s=%s but annotations were %v", key, expectedVal, actualAnnotations)
 		}
 	}
 }
@@ -0,0 +1,304 @@
+package pipelines
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+	"github.com/openshift-pipelines/release-tests/pkg/wait"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StepLogs are the logs of the containers of task runs keyed by step, then
+// by container as <pod>/<container>, the step of a matrix task running in
+// several pods. Sidecars are keyed by their container, sidecar-<name>.
+type StepLogs map[string]map[string]string
+
+// TaskLogs are the step logs of the task runs of a pipeline run keyed by
+// PipelineTask
+type TaskLogs map[string]StepLogs
+
+// LogExpectation is a pattern expected in the logs of a step
+type LogExpectation struct {
+	PipelineTask string
+	Step         string
+	// Operator is matches, the default, or not matches for the pattern to
+	// be absent from the logs
+	Operator string
+	// Pattern is a regular expression, ^ and $ matching at line boundaries
+	Pattern string
+}
+
+// TaskRunStepLogs waits for the containers of the steps and sidecars of the
+// task run to terminate and returns their logs
+func TaskRunStepLogs(c *clients.Clients, trname, namespace string) (StepLogs, error) {
+	var logs StepLogs
+	var lastErr error
+	log.Printf("Waiting for the containers of TaskRun %s in namespace %s to terminate", trname, namespace)
+	err := wait.WaitFor(c.Ctx, func() (bool, error) {
+		tr, err := c.TaskRunClient.Get(c.Ctx, trname, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get task run %s in namespace %s: %v", trname, namespace, err)
+		}
+		if !terminated(tr) {
+			return false, nil
+		}
+		// the logs of terminated containers may not be available yet
+		logs, lastErr = containerLogs(c, tr, namespace)
+		return lastErr == nil, nil
+	})
+	if err != nil && lastErr != nil {
+		return nil, lastErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("containers of task run %s in namespace %s did not terminate: %v", trname, namespace, err)
+	}
+	return logs, nil
+}
+
+// PipelineRunStepLogs waits for the pipeline run to complete and returns the
+// step logs of its task runs
+func PipelineRunStepLogs(c *clients.Clients, prname, namespace string) (TaskLogs, error) {
+	pr := completedPipelineRun(c, prname, namespace)
+	logs := TaskLogs{}
+	for _, ref := range pr.Status.ChildReferences {
+		if ref.Kind != pipeline.TaskRunControllerName {
+			continue
+		}
+		steps, err := TaskRunStepLogs(c, ref.Name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if logs[ref.PipelineTaskName] == nil {
+			logs[ref.PipelineTaskName] = StepLogs{}
+		}
+		for step, containers := range steps {
+			if logs[ref.PipelineTaskName][step] == nil {
+				logs[ref.PipelineTaskName][step] = map[string]string{}
+			}
+			for container, l := range containers {
+				logs[ref.PipelineTaskName][step][container] = l
+			}
+		}
+	}
+	return logs, nil
+}
+
+// Save writes each log to dir/<step>/<pod>/<container>.log
+func (l StepLogs) Save(dir string) error {
+	for step, containers := range l {
+		for container, content := range containers {
+			path := filepath.Join(dir, step, filepath.FromSlash(container)+".log")
+			if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Save writes each log to dir/<pipeline task>/<step>/<pod>/<container>.log
+func (l TaskLogs) Save(dir string) error {
+	for task, steps := range l {
+		if err := steps.Save(filepath.Join(dir, task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertPipelineRunStepLogs waits for the pipeline run to complete, saves the
+// step logs of its task runs in the artifacts of the scenario and checks
+// them, each pattern being searched in the logs of every container of the
+// step
+func AssertPipelineRunStepLogs(c *clients.Clients, prname, namespace string, expected []LogExpectation) {
+	logs, err := PipelineRunStepLogs(c, prname, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	saveLogs(c, logs, filepath.Join("step-logs", prname))
+	for _, e := range expected {
+		assertStepLogs(c, "pipeline run "+prname+" task "+e.PipelineTask, logs[e.PipelineTask], e)
+	}
+}
+
+// AssertTaskRunStepLogs waits for the containers of the task run to
+// terminate, saves their logs in the artifacts of the scenario and checks
+// them, the PipelineTask of the expectations being ignored
+func AssertTaskRunStepLogs(c *clients.Clients, trname, namespace string, expected []LogExpectation) {
+	logs, err := TaskRunStepLogs(c, trname, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	saveLogs(c, logs, filepath.Join("step-logs", trname))
+	for _, e := range expected {
+		assertStepLogs(c, "task run "+trname, logs, e)
+	}
+}
+
+// AssertPipelineRunStepLogLines waits for the pipeline run to complete, saves
+// the step logs of its task runs in the artifacts of the scenario and checks
+// that the logs of each container of the step have lines matching the
+// patterns in their order
+func AssertPipelineRunStepLogLines(c *clients.Clients, prname, pipelineTask, step, namespace string, patterns []string) {
+	logs, err := PipelineRunStepLogs(c, prname, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	saveLogs(c, logs, filepath.Join("step-logs", prname))
+	assertStepLogLines(c, "pipeline run "+prname+" task "+pipelineTask, logs[pipelineTask], step, patterns)
+}
+
+// AssertTaskRunStepLogLines waits for the containers of the task run to
+// terminate, saves their logs in the artifacts of the scenario and checks
+// that the logs of each container of the step have lines matching the
+// patterns in their order
+func AssertTaskRunStepLogLines(c *clients.Clients, trname, step, namespace string, patterns []string) {
+	logs, err := TaskRunStepLogs(c, trname, namespace)
+	if err != nil {
+		c.Reporter().Fail(err)
+		return
+	}
+	saveLogs(c, logs, filepath.Join("step-logs", trname))
+	assertStepLogLines(c, "task run "+trname, logs, step, patterns)
+}
+
+// terminated tells whether the containers of the steps and sidecars of the
+// task run terminated, or whether it completed without running them
+func terminated(tr *v1.TaskRun) bool {
+	if tr.Status.PodName == "" || len(tr.Status.Steps) == 0 {
+		return tr.IsDone()
+	}
+	for _, s := range tr.Status.Steps {
+		if s.Terminated == nil {
+			return false
+		}
+	}
+	for _, s := range tr.Status.Sidecars {
+		if s.Terminated == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// containerLogs returns the logs of the containers of the steps and sidecars
+// of the task run
+func containerLogs(c *clients.Clients, tr *v1.TaskRun, namespace string) (StepLogs, error) {
+	logs := StepLogs{}
+	if tr.Status.PodName == "" {
+		return logs, nil
+	}
+	containers := map[string]string{}
+	for _, s := range tr.Status.Steps {
+		containers[s.Container] = s.Name
+	}
+	for _, s := range tr.Status.Sidecars {
+		containers[s.Container] = s.Container
+	}
+	for container, step := range containers {
+		stream, err := c.KubeClient.Kube.CoreV1().Pods(namespace).GetLogs(tr.Status.PodName, &corev1.PodLogOptions{Container: container}).Stream(c.Ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of container %s of pod %s in namespace %s: %v", container, tr.Status.PodName, namespace, err)
+		}
+		content, err := io.ReadAll(stream)
+		stream.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read logs of container %s of pod %s in namespace %s: %v", container, tr.Status.PodName, namespace, err)
+		}
+		if logs[step] == nil {
+			logs[step] = map[string]string{}
+		}
+		logs[step][tr.Status.PodName+"/"+container] = string(content)
+	}
+	return logs, nil
+}
+
+// saveLogs saves the logs in the artifacts of the scenario, under dir
+func saveLogs(c *clients.Clients, logs interface{ Save(string) error }, dir string) {
+	dir = filepath.Join(store.ArtifactsDir(), dir)
+	if err := logs.Save(dir); err != nil {
+		c.Reporter().Errorf("failed to save the step logs in %s: %v", dir, err)
+		return
+	}
+	log.Printf("Step logs saved in %s", dir)
+}
+
+// stepLogs returns the logs of the containers of the step, reporting a
+// missing step
+func stepLogs(c *clients.Clients, run string, logs StepLogs, step string) map[string]string {
+	containers, ok := logs[step]
+	if !ok {
+		var steps []string
+		for s := range logs {
+			steps = append(steps, s)
+		}
+		slices.Sort(steps)
+		c.Reporter().Errorf("no logs of step %s in %s, steps: %v", step, run, steps)
+	}
+	return containers
+}
+
+func assertStepLogs(c *clients.Clients, run string, logs StepLogs, e LogExpectation) {
+	re, err := regexp.Compile("(?m)" + e.Pattern)
+	if err != nil {
+		c.Reporter().Errorf("invalid regular expression %q: %v", e.Pattern, err)
+		return
+	}
+	for container, content := range stepLogs(c, run, logs, e.Step) {
+		switch e.Operator {
+		case "", "matches":
+			if !re.MatchString(content) {
+				c.Reporter().Errorf("logs of step %s of %s (%s) do not match %q:\n%s", e.Step, run, container, e.Pattern, content)
+			}
+		case "not matches":
+			if re.MatchString(content) {
+				c.Reporter().Errorf("logs of step %s of %s (%s) match %q with %q:\n%s", e.Step, run, container, e.Pattern, re.FindString(content), content)
+			}
+		default:
+			c.Reporter().Errorf("unknown operator %q, expected matches or not matches", e.Operator)
+			return
+		}
+	}
+}
+
+func assertStepLogLines(c *clients.Clients, run string, logs StepLogs, step string, patterns []string) {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			c.Reporter().Errorf("invalid regular expression %q: %v", p, err)
+			return
+		}
+		res = append(res, re)
+	}
+	for container, content := range stepLogs(c, run, logs, step) {
+		lines := strings.Split(content, "\n")
+		next := 0
+		for i, re := range res {
+			found := slices.IndexFunc(lines[next:], re.MatchString)
+			if found < 0 {
+				c.Reporter().Errorf("logs of step %s of %s (%s) have no line matching %q after the lines matching %q:\n%s",
+					step, run, container, patterns[i], patterns[:i], content)
+				break
+			}
+			next += found + 1
+		}
+	}
+}
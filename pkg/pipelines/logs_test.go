@@ -0,0 +1,271 @@
+package pipelines
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// testLogs are the logs of a matrix task, its build step running in two pods
+var testLogs = StepLogs{
+	"build": {
+		"pr-build-0-pod/step-build": "Cloning into 'source'...\nBuilding image for amd64\nBuild succeeded\n",
+		"pr-build-1-pod/step-build": "Cloning into 'source'...\nBuilding image for arm64\nBuild succeeded\n",
+	},
+	"sidecar-registry": {
+		"pr-build-0-pod/sidecar-registry": "listening on [::]:5000\n",
+	},
+}
+
+func TestAssertStepLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected LogExpectation
+		wantErr  string
+	}{{
+		name:     "matches in every container",
+		expected: LogExpectation{Step: "build", Pattern: "Build succeeded"},
+	}, {
+		name:     "anchored at line boundaries",
+		expected: LogExpectation{Step: "build", Operator: "matches", Pattern: "^Building image for (amd|arm)64$"},
+	}, {
+		name:     "sidecar",
+		expected: LogExpectation{Step: "sidecar-registry", Pattern: "listening on .*:5000"},
+	}, {
+		name:     "matches in one container only",
+		expected: LogExpectation{Step: "build", Pattern: "for arm64"},
+		wantErr:  `logs of step build of task run tr (pr-build-0-pod/step-build) do not match "for arm64"`,
+	}, {
+		name:     "not matches",
+		expected: LogExpectation{Step: "build", Operator: "not matches", Pattern: "(?i)error"},
+	}, {
+		name:     "not matches found",
+		expected: LogExpectation{Step: "build", Operator: "not matches", Pattern: "for amd\\d+"},
+		wantErr:  `(pr-build-0-pod/step-build) match "for amd\\d+" with "for amd64"`,
+	}, {
+		name:     "anchor not matching within a line",
+		expected: LogExpectation{Step: "build", Pattern: "^image"},
+		wantErr:  `do not match "^image"`,
+	}, {
+		name:     "missing step",
+		expected: LogExpectation{Step: "push", Pattern: "pushed"},
+		wantErr:  "no logs of step push in task run tr, steps: [build sidecar-registry]",
+	}, {
+		name:     "invalid pattern",
+		expected: LogExpectation{Step: "build", Pattern: "Build ("},
+		wantErr:  `invalid regular expression "Build ("`,
+	}, {
+		name:     "unknown operator",
+		expected: LogExpectation{Step: "build", Operator: "contains", Pattern: "Build"},
+		wantErr:  `unknown operator "contains"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns")
+			err := testutil.CaptureFailures(func() {
+				assertStepLogs(c, "task run tr", testLogs, tt.expected)
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAssertStepLogLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		step     string
+		patterns []string
+		wantErr  string
+	}{{
+		name:     "in order",
+		step:     "build",
+		patterns: []string{"^Cloning", "Building image", "succeeded$"},
+	}, {
+		name:     "lines skipped",
+		step:     "build",
+		patterns: []string{"Cloning", "succeeded"},
+	}, {
+		name:     "no pattern",
+		step:     "build",
+		patterns: nil,
+	}, {
+		name:     "out of order",
+		step:     "build",
+		patterns: []string{"Build succeeded", "Cloning"},
+		wantErr:  `have no line matching "Cloning" after the lines matching ["Build succeeded"]`,
+	}, {
+		name:     "a line matches once",
+		step:     "build",
+		patterns: []string{"Build", "Build", "Build"},
+		wantErr:  `have no line matching "Build" after the lines matching ["Build" "Build"]`,
+	}, {
+		name:     "missing in one container",
+		step:     "build",
+		patterns: []string{"Cloning", "arm64"},
+		wantErr:  `(pr-build-0-pod/step-build) have no line matching "arm64"`,
+	}, {
+		name:     "missing step",
+		step:     "push",
+		patterns: []string{"pushed"},
+		wantErr:  "no logs of step push in task run tr",
+	}, {
+		name:     "invalid pattern",
+		step:     "build",
+		patterns: []string{"Cloning", "[a-"},
+		wantErr:  `invalid regular expression "[a-"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns")
+			err := testutil.CaptureFailures(func() {
+				assertStepLogLines(c, "task run tr", testLogs, tt.step, tt.patterns)
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// taskRunWithSteps returns a task run of pod tr-pod with the steps and
+// sidecars, terminated when their name is in terminated
+func taskRunWithSteps(status corev1.ConditionStatus, steps, sidecars []string, terminated ...string) *v1.TaskRun {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "test-ns"},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}}},
+		},
+	}
+	if len(steps) > 0 {
+		tr.Status.PodName = "tr-pod"
+	}
+	state := func(name string) corev1.ContainerState {
+		for _, t := range terminated {
+			if t == name {
+				return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+			}
+		}
+		return corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	}
+	for _, s := range steps {
+		tr.Status.Steps = append(tr.Status.Steps, v1.StepState{Name: s, Container: "step-" + s, ContainerState: state(s)})
+	}
+	for _, s := range sidecars {
+		tr.Status.Sidecars = append(tr.Status.Sidecars, v1.SidecarState{Name: s, Container: "sidecar-" + s, ContainerState: state(s)})
+	}
+	return tr
+}
+
+func TestTerminated(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   *v1.TaskRun
+		want bool
+	}{
+		{name: "steps and sidecars terminated", tr: taskRunWithSteps(corev1.ConditionTrue, []string{"clone", "build"}, []string{"registry"}, "clone", "build", "registry"), want: true},
+		{name: "step running", tr: taskRunWithSteps(corev1.ConditionUnknown, []string{"clone", "build"}, nil, "clone")},
+		{name: "sidecar running", tr: taskRunWithSteps(corev1.ConditionTrue, []string{"build"}, []string{"registry"}, "build")},
+		{name: "completed without pod", tr: taskRunWithSteps(corev1.ConditionFalse, nil, nil), want: true},
+		{name: "pending without pod", tr: taskRunWithSteps(corev1.ConditionUnknown, nil, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminated(tt.tr); got != tt.want {
+				t.Errorf("terminated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerLogs(t *testing.T) {
+	c, fcs := testutil.NewFakeClients("test-ns")
+	tr := taskRunWithSteps(corev1.ConditionTrue, []string{"clone", "build"}, []string{"registry"}, "clone", "build", "registry")
+
+	logs, err := containerLogs(c, tr, "test-ns")
+	if err != nil {
+		t.Fatalf("containerLogs() failed: %v", err)
+	}
+	// the fake clientset returns the same logs for every container
+	want := StepLogs{
+		"clone":            {"tr-pod/step-clone": "fake logs"},
+		"build":            {"tr-pod/step-build": "fake logs"},
+		"sidecar-registry": {"tr-pod/sidecar-registry": "fake logs"},
+	}
+	if !reflect.DeepEqual(logs, want) {
+		t.Errorf("containerLogs() = %v, want %v", logs, want)
+	}
+
+	var containers []string
+	for _, action := range fcs.Kube.Actions() {
+		if action.GetSubresource() != "log" {
+			continue
+		}
+		opts := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+		containers = append(containers, opts.Container)
+	}
+	if len(containers) != 3 {
+		t.Errorf("got the logs of containers %v, want the ones of the steps and the sidecar", containers)
+	}
+
+	logs, err = containerLogs(c, taskRunWithSteps(corev1.ConditionFalse, nil, nil), "test-ns")
+	if err != nil || len(logs) != 0 {
+		t.Errorf("containerLogs() of a task run without pod = %v, %v, want no logs", logs, err)
+	}
+}
+
+func TestTaskRunStepLogs(t *testing.T) {
+	tr := taskRunWithSteps(corev1.ConditionTrue, []string{"build"}, nil, "build")
+	c, _ := testutil.NewFakeClients("test-ns", tr)
+
+	logs, err := TaskRunStepLogs(c, "tr", "test-ns")
+	if err != nil {
+		t.Fatalf("TaskRunStepLogs() failed: %v", err)
+	}
+	if want := (StepLogs{"build": {"tr-pod/step-build": "fake logs"}}); !reflect.DeepEqual(logs, want) {
+		t.Errorf("TaskRunStepLogs() = %v, want %v", logs, want)
+	}
+}
+
+func TestStepLogsSave(t *testing.T) {
+	dir := t.TempDir()
+	if err := (TaskLogs{"build": testLogs}).Save(dir); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	for step, containers := range testLogs {
+		for container, want := range containers {
+			path := filepath.Join(dir, "build", step, filepath.FromSlash(container)+".log")
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Errorf("log of %s of step %s not saved: %v", container, step, err)
+				continue
+			}
+			if string(got) != want {
+				t.Errorf("%s = %q, want %q", path, got, want)
+			}
+			if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+				t.Errorf("%s is %o, want 600", path, info.Mode().Perm())
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "build", "build", "pr-build-1-pod", "step-build.log")); err != nil {
+		t.Errorf("logs of the pods of a matrix step are not saved apart: %v", err)
+	}
+}
@@ -12,11 +12,11 @@ import (
 	"time"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	"github.com/openshift-pipelines/release-tests/pkg/runreport"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	"github.com/openshift-pipelines/release-tests/pkg/wait"
 	"github.com/tektoncd/cli/pkg/cli"
@@ -41,17 +41,18 @@ func validatePipelineRunForSuccessStatus(c *clients.Clients, prname, namespace s
 	if err != nil {
 		buf, logsErr := getPipelinerunLogs(c, prname, namespace)
 		events, eventError := k8s.GetWarningEvents(c, namespace)
+		recordDiagnostics("pipelinerun/"+prname, buf, logsErr, events, eventError)
 		if logsErr != nil {
 			if eventError != nil {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events error: \n%v", prname, err, logsErr, eventError)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events error: \n%v", prname, err, logsErr, eventError)
 			} else {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events: \n%v", prname, err, logsErr, events)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events: \n%v", prname, err, logsErr, events)
 			}
 		} else {
 			if eventError != nil {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events error: \n%v", prname, err, buf.String(), eventError)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events error: \n%v", prname, err, buf.String(), eventError)
 			} else {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events: \n%v", prname, err, buf.String(), events)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events: \n%v", prname, err, buf.String(), events)
 			}
 		}
 	}
@@ -66,17 +67,18 @@ func validatePipelineRunForFailedStatus(c *clients.Clients, prname, namespace st
 	if err != nil {
 		buf, logsErr := getPipelinerunLogs(c, prname, namespace)
 		events, eventError := k8s.GetWarningEvents(c, namespace)
+		recordDiagnostics("pipelinerun/"+prname, buf, logsErr, events, eventError)
 		if logsErr != nil {
 			if eventError != nil {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events error: \n%v", prname, err, logsErr, eventError)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events error: \n%v", prname, err, logsErr, eventError)
 			} else {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events: \n%v", prname, err, logsErr, events)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs error: \n%v \npipelinerun events: \n%v", prname, err, logsErr, events)
 			}
 		} else {
 			if eventError != nil {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events error: \n%v", prname, err, buf.String(), eventError)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events error: \n%v", prname, err, buf.String(), eventError)
 			} else {
-				testsuit.T.Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events: \n%v", prname, err, buf.String(), events)
+				c.Reporter().Errorf("error waiting for pipeline run %s to finish \n%v \npipelinerun logs: \n%v \npipelinerun events: \n%v", prname, err, buf.String(), events)
 			}
 		}
 	}
@@ -86,17 +88,17 @@ func validatePipelineRunTimeoutFailure(c *clients.Clients, prname, namespace str
 	var err error
 	pipelineRun, err := c.PipelineRunClient.Get(c.Ctx, prname, metav1.GetOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get pipeline run %s in namespaces %s \n %v", prname, namespace, err)
+		c.Reporter().Errorf("failed to get pipeline run %s in namespaces %s \n %v", prname, namespace, err)
 	}
 
 	log.Printf("Waiting for Pipelinerun %s in namespace %s to be started", pipelineRun.Name, namespace)
 	if err := wait.WaitForPipelineRunState(c, pipelineRun.Name, wait.Running(pipelineRun.Name), "PipelineRunRunning"); err != nil {
-		testsuit.T.Errorf("Error waiting for PipelineRun %s to be running: %s", pipelineRun.Name, err)
+		c.Reporter().Errorf("Error waiting for PipelineRun %s to be running: %s", pipelineRun.Name, err)
 	}
 
 	taskrunList, err := c.TaskRunClient.List(c.Ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("tekton.dev/pipelineRun=%s", pipelineRun.Name)})
 	if err != nil {
-		testsuit.T.Errorf("Error listing TaskRuns for PipelineRun %s: %v", pipelineRun.Name, err)
+		c.Reporter().Errorf("Error listing TaskRuns for PipelineRun %s: %v", pipelineRun.Name, err)
 	}
 
 	log.Printf("Waiting for TaskRuns from PipelineRun %s in namespace %s to be running", pipelineRun.Name, namespace)
@@ -112,17 +114,17 @@ func validatePipelineRunTimeoutFailure(c *clients.Clients, prname, namespace str
 
 	for i := 1; i <= len(taskrunList.Items); i++ {
 		if <-errChan != nil {
-			testsuit.T.Errorf("Error waiting for TaskRun %s to be running: %v", taskrunList.Items[i-1].Name, err)
+			c.Reporter().Errorf("Error waiting for TaskRun %s to be running: %v", taskrunList.Items[i-1].Name, err)
 		}
 	}
 
 	if _, err := c.PipelineRunClient.Get(c.Ctx, pipelineRun.Name, metav1.GetOptions{}); err != nil {
-		testsuit.T.Errorf("Failed to get PipelineRun `%s`: %s", pipelineRun.Name, err)
+		c.Reporter().Errorf("Failed to get PipelineRun `%s`: %s", pipelineRun.Name, err)
 	}
 
 	log.Printf("Waiting for PipelineRun %s in namespace %s to be timed out", pipelineRun.Name, namespace)
 	if err := wait.WaitForPipelineRunState(c, pipelineRun.Name, wait.FailedWithReason(v1.PipelineRunReasonTimedOut.String(), pipelineRun.Name), "PipelineRunTimedOut"); err != nil {
-		testsuit.T.Errorf("Error waiting for PipelineRun %s to finish: %s", pipelineRun.Name, err)
+		c.Reporter().Errorf("Error waiting for PipelineRun %s to finish: %s", pipelineRun.Name, err)
 	}
 
 	log.Printf("Waiting for TaskRuns from PipelineRun %s in namespace %s to be cancelled", pipelineRun.Name, namespace)
@@ -133,14 +135,14 @@ func validatePipelineRunTimeoutFailure(c *clients.Clients, prname, namespace str
 			defer wg.Done()
 			err := wait.WaitForTaskRunState(c, name, wait.FailedWithReason(v1.TaskRunReasonCancelled.String(), name), v1.TaskRunReasonCancelled.String())
 			if err != nil {
-				testsuit.T.Errorf("error waiting for task run %s to be cancelled on pipeline timeout \n %v", name, err)
+				c.Reporter().Errorf("error waiting for task run %s to be cancelled on pipeline timeout \n %v", name, err)
 			}
 		}(taskrunItem.Name)
 	}
 	wg.Wait()
 
 	if _, err := c.PipelineRunClient.Get(c.Ctx, pipelineRun.Name, metav1.GetOptions{}); err != nil {
-		testsuit.T.Errorf("Failed to get PipelineRun `%s`: %s", pipelineRun.Name, err)
+		c.Reporter().Errorf("Failed to get PipelineRun `%s`: %s", pipelineRun.Name, err)
 	}
 }
 
@@ -149,19 +151,19 @@ func validatePipelineRunCancel(c *clients.Clients, prname, namespace string) {
 
 	log.Printf("Waiting for Pipelinerun %s in namespace %s to be started", prname, namespace)
 	if err := wait.WaitForPipelineRunState(c, prname, wait.Running(prname), "PipelineRunRunning"); err != nil {
-		testsuit.T.Errorf("Error waiting for PipelineRun %s to be running: %s", prname, err)
+		c.Reporter().Errorf("Error waiting for PipelineRun %s to be running: %s", prname, err)
 	}
 
 	taskrunList, err := c.TaskRunClient.List(c.Ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("tekton.dev/pipelineRun=%s", prname)})
 	if err != nil {
-		testsuit.T.Errorf("Error listing TaskRuns for PipelineRun %s: %s", prname, err)
+		c.Reporter().Errorf("Error listing TaskRuns for PipelineRun %s: %s", prname, err)
 	}
 
 	var wg sync.WaitGroup
 	log.Printf("Canceling pipeline run: %s\n", cmd.MustSucceed("opc", "pipelinerun", "cancel", prname, "-n", namespace).Stdout())
 
 	if err := wait.WaitForPipelineRunState(c, prname, wait.FailedWithReason("Cancelled", prname), "Cancelled"); err != nil {
-		testsuit.T.Errorf("Error waiting for PipelineRun `%s` to finished: %s", prname, err)
+		c.Reporter().Errorf("Error waiting for PipelineRun `%s` to finished: %s", prname, err)
 	}
 
 	log.Printf("Waiting for TaskRuns in PipelineRun %s in namespace %s to be cancelled", prname, namespace)
@@ -171,7 +173,7 @@ func validatePipelineRunCancel(c *clients.Clients, prname, namespace string) {
 			defer wg.Done()
 			err := wait.WaitForTaskRunState(c, name, wait.FailedWithReason(v1.TaskRunReasonCancelled.String(), name), "TaskRunCancelled")
 			if err != nil {
-				testsuit.T.Errorf("task run %s failed to finish \n %v", name, err)
+				c.Reporter().Errorf("task run %s failed to finish \n %v", name, err)
 			}
 		}(taskrunItem.Name)
 	}
@@ -182,7 +184,7 @@ func validatePipelineRunCancel(c *clients.Clients, prname, namespace string) {
 func WaitForPipelineRunCancelled(c *clients.Clients, prname, namespace string) {
 	log.Printf("Waiting for PipelineRun %s in namespace %s to be cancelled", prname, namespace)
 	if err := wait.WaitForPipelineRunState(c, prname, wait.FailedWithReason("Cancelled", prname), "Cancelled"); err != nil {
-		testsuit.T.Errorf("Error waiting for PipelineRun `%s` to be cancelled: %s", prname, err)
+		c.Reporter().Errorf("Error waiting for PipelineRun `%s` to be cancelled: %s", prname, err)
 	}
 }
 
@@ -190,7 +192,7 @@ func ValidatePipelineRun(c *clients.Clients, prname, status, namespace string) {
 	var err error
 	pr, err := c.PipelineRunClient.Get(c.Ctx, prname, metav1.GetOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get pipeline run %s in namespace %s \n %v", prname, namespace, err)
+		c.Reporter().Errorf("failed to get pipeline run %s in namespace %s \n %v", prname, namespace, err)
 	}
 
 	// Verify status of PipelineRun (wait for it)
@@ -208,15 +210,47 @@ func ValidatePipelineRun(c *clients.Clients, prname, status, namespace string) {
 		log.Printf("validating pipeline run %s to be cancelled...", prname)
 		validatePipelineRunCancel(c, pr.GetName(), namespace)
 	default:
-		testsuit.T.Errorf("Error: %s ", "Not valid input")
+		c.Reporter().Errorf("Error: %s ", "Not valid input")
 	}
 }
 
+// ValidatePipelineRunFailure waits for the pipeline run to fail and checks the
+// reason of its failure and that its message contains the message, e.g.
+// CouldntGetPipeline when a resolver could not fetch its pipeline. The message
+// is not checked if empty.
+func ValidatePipelineRunFailure(c *clients.Clients, prname, reason, message, namespace string) {
+	validatePipelineRunForFailedStatus(c, prname, namespace)
+	pr, err := c.PipelineRunClient.Get(c.Ctx, prname, metav1.GetOptions{})
+	if err != nil {
+		c.Reporter().Errorf("failed to get pipeline run %s in namespace %s \n %v", prname, namespace, err)
+		return
+	}
+	condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil {
+		c.Reporter().Errorf("pipeline run %s in namespace %s has no succeeded condition", prname, namespace)
+		return
+	}
+	if condition.Reason != reason {
+		c.Reporter().Errorf("expected pipeline run %s to fail with reason %s, got %s: %s", prname, reason, condition.Reason, condition.Message)
+	}
+	if !strings.Contains(condition.Message, message) {
+		c.Reporter().Errorf("expected the failure message of pipeline run %s to contain %q, got %q", prname, message, condition.Message)
+	}
+}
+
+// observation windows of the pipeline runs of the cron scenarios, whose cron
+// job creates a pipeline run every minute. They are not timeouts: changing
+// them changes how many runs the scenarios expect.
+const (
+	cronRunsObservation  = 5 * time.Minute
+	noNewRunsObservation = time.Minute
+)
+
 func WatchForPipelineRun(c *clients.Clients, namespace string) {
 	var prnames = []string{}
 	watchRun, err := k8s.Watch(c.Ctx, prGroupResource, c, namespace, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to watch pipeline runs in namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to watch pipeline runs in namespace %s \n %v", namespace, err)
 	}
 
 	ch := watchRun.ResultChan()
@@ -224,7 +258,7 @@ func WatchForPipelineRun(c *clients.Clients, namespace string) {
 		for event := range ch {
 			run, err := cast2pipelinerun(event.Object)
 			if err != nil {
-				testsuit.T.Errorf("failed to convert pipeline run to v1beta1 in namespace %s \n %v", namespace, err)
+				c.Reporter().Errorf("failed to convert pipeline run to v1beta1 in namespace %s \n %v", namespace, err)
 			}
 			if event.Type == watch.Added {
 				log.Printf("pipeline run : %s", run.Name)
@@ -233,7 +267,7 @@ func WatchForPipelineRun(c *clients.Clients, namespace string) {
 
 		}
 	}()
-	time.Sleep(5 * time.Minute)
+	time.Sleep(cronRunsObservation)
 	gauge.GetScenarioStore()["prcount"] = len(prnames)
 	gauge.WriteMessage("%+v", prnames)
 }
@@ -255,7 +289,7 @@ func AssertForNoNewPipelineRunCreation(c *clients.Clients, namespace string) {
 	expectedCount := gauge.GetScenarioStore()["prcount"].(int)
 	watchRun, err := k8s.Watch(c.Ctx, prGroupResource, c, namespace, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to watch pipeline runs in namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to watch pipeline runs in namespace %s \n %v", namespace, err)
 	}
 	ch := watchRun.ResultChan()
 	go func() {
@@ -265,9 +299,9 @@ func AssertForNoNewPipelineRunCreation(c *clients.Clients, namespace string) {
 			}
 		}
 	}()
-	time.Sleep(1 * time.Minute)
+	time.Sleep(noNewRunsObservation)
 	if count < expectedCount {
-		testsuit.T.Errorf("Error:  Expected: %+v (tekton resources add newly in namespace %s), \n Actual: %+v ", expectedCount, namespace, count)
+		c.Reporter().Errorf("Error:  Expected: %+v (tekton resources add newly in namespace %s), \n Actual: %+v ", expectedCount, namespace, count)
 	}
 }
 
@@ -284,7 +318,7 @@ func AssertNumberOfPipelineruns(c *clients.Clients, namespace, numberOfPr, timeo
 	})
 	if err != nil {
 		prlist, _ := c.PipelineRunClient.List(c.Ctx, metav1.ListOptions{})
-		testsuit.T.Fail(fmt.Errorf("error: Expected %v pipelineruns but found %v pipelineruns: %s", numberOfPr, len(prlist.Items), err))
+		c.Reporter().Fail(fmt.Errorf("error: Expected %v pipelineruns but found %v pipelineruns: %s", numberOfPr, len(prlist.Items), err))
 	}
 }
 
@@ -328,11 +362,11 @@ func AssertNumberOfPipelinerunsWithStatus(c *clients.Clients, namespace, numberO
 	if err != nil {
 		prlist, listErr := c.PipelineRunClient.List(c.Ctx, metav1.ListOptions{})
 		if listErr != nil {
-			testsuit.T.Fail(fmt.Errorf("error waiting for pipelineruns: %v. Additionally, failed to list pipelineruns to provide details: %v", err, listErr))
+			c.Reporter().Fail(fmt.Errorf("error waiting for pipelineruns: %v. Additionally, failed to list pipelineruns to provide details: %v", err, listErr))
 			return
 		}
 		count := countPipelinerunsByStatus(prlist, status)
-		testsuit.T.Fail(fmt.Errorf("error: Expected %v pipelineruns with status %s but found %v (total pipelineruns: %v): %s", numberOfPr, status, count, len(prlist.Items), err))
+		c.Reporter().Fail(fmt.Errorf("error: Expected %v pipelineruns with status %s but found %v (total pipelineruns: %v): %s", numberOfPr, status, count, len(prlist.Items), err))
 	}
 }
 
@@ -349,7 +383,7 @@ func AssertNumberOfTaskruns(c *clients.Clients, namespace, numberOfTr, timeoutSe
 	})
 	if err != nil {
 		trlist, _ := c.TaskRunClient.List(c.Ctx, metav1.ListOptions{})
-		testsuit.T.Fail(fmt.Errorf("error: Expected %v taskruns but found %v taskruns: %s", numberOfTr, len(trlist.Items), err))
+		c.Reporter().Fail(fmt.Errorf("error: Expected %v taskruns but found %v taskruns: %s", numberOfTr, len(trlist.Items), err))
 	}
 }
 func AssertPipelinesPresent(c *clients.Clients, namespace string) {
@@ -371,7 +405,7 @@ func AssertPipelinesPresent(c *clients.Clients, namespace string) {
 	})
 	if err != nil {
 		p, _ := pclient.List(c.Ctx, metav1.ListOptions{})
-		testsuit.T.Fail(fmt.Errorf("expected: %v pipelines present in namespace %v, Actual: %v pipelines present in namespace %v , Error: %v", expectedNumberOfPipelines, namespace, len(p.Items), namespace, err))
+		c.Reporter().Fail(fmt.Errorf("expected: %v pipelines present in namespace %v, Actual: %v pipelines present in namespace %v , Error: %v", expectedNumberOfPipelines, namespace, len(p.Items), namespace, err))
 	}
 	log.Printf("Pipelines are present in namespace %v", namespace)
 }
@@ -388,11 +422,26 @@ func AssertPipelinesNotPresent(c *clients.Clients, namespace string) {
 	})
 	if err != nil {
 		p, _ := pclient.List(c.Ctx, metav1.ListOptions{})
-		testsuit.T.Fail(fmt.Errorf("expected: %v number of pipelines present in namespace %v, Actual: %v number of pipelines present in namespace %v , Error: %v", 0, namespace, len(p.Items), namespace, err))
+		c.Reporter().Fail(fmt.Errorf("expected: %v number of pipelines present in namespace %v, Actual: %v number of pipelines present in namespace %v , Error: %v", 0, namespace, len(p.Items), namespace, err))
 	}
 	log.Printf("Pipelines are present in namespace %v", namespace)
 }
 
+// recordDiagnostics attaches the logs and the Warning events gathered for a run
+// which did not reach the expected state to the run report
+func recordDiagnostics(run string, logs *bytes.Buffer, logsErr error, events string, eventsErr error) {
+	if logsErr != nil {
+		runreport.AddDiagnostic(run+" logs error", logsErr.Error())
+	} else {
+		runreport.AddDiagnostic(run+" logs", logs.String())
+	}
+	if eventsErr != nil {
+		runreport.AddDiagnostic(run+" events error", eventsErr.Error())
+	} else {
+		runreport.AddDiagnostic(run+" events", events)
+	}
+}
+
 func getPipelinerunLogs(c *clients.Clients, prname, namespace string) (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 
@@ -438,13 +487,13 @@ func GetLatestPipelinerun(c *clients.Clients, namespace string) (string, error)
 func CheckLogVersion(c *clients.Clients, binary, namespace string) {
 	prname, err := GetLatestPipelinerun(store.Clients(), store.Namespace())
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to get PipelineRun: %v", err))
+		c.Reporter().Fail(fmt.Errorf("failed to get PipelineRun: %v", err))
 		return
 	}
 	// Get PipelineRun logs
 	logsBuffer, err := getPipelinerunLogs(c, prname, namespace)
 	if err != nil {
-		testsuit.T.Fail(fmt.Errorf("failed to get PipelineRun logs: %v", err))
+		c.Reporter().Fail(fmt.Errorf("failed to get PipelineRun logs: %v", err))
 		return
 	}
 
@@ -452,18 +501,18 @@ func CheckLogVersion(c *clients.Clients, binary, namespace string) {
 	case "tkn-pac":
 		expectedVersion := os.Getenv("PAC_VERSION")
 		if !strings.Contains(logsBuffer.String(), expectedVersion) {
-			testsuit.T.Fail(fmt.Errorf("tkn-pac Version %s not found in logs:\n%s ", expectedVersion, logsBuffer))
+			c.Reporter().Fail(fmt.Errorf("tkn-pac Version %s not found in logs:\n%s ", expectedVersion, logsBuffer))
 		}
 	case "tkn":
 		expectedVersion := os.Getenv("TKN_CLIENT_VERSION")
 		if !strings.Contains(logsBuffer.String(), "Client version:") {
-			testsuit.T.Fail(fmt.Errorf("tkn client version not found! \nlogs:%s", logsBuffer))
+			c.Reporter().Fail(fmt.Errorf("tkn client version not found! \nlogs:%s", logsBuffer))
 			return
 		}
 		if !strings.Contains(logsBuffer.String(), expectedVersion) {
-			testsuit.T.Fail(fmt.Errorf("tkn Version %s not found in logs:\n%s ", expectedVersion, logsBuffer))
+			c.Reporter().Fail(fmt.Errorf("tkn Version %s not found in logs:\n%s ", expectedVersion, logsBuffer))
 		}
 	default:
-		testsuit.T.Fail(fmt.Errorf("unknown binary or client"))
+		c.Reporter().Fail(fmt.Errorf("unknown binary or client"))
 	}
 }
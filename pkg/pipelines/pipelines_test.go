@@ -0,0 +1,65 @@
+package pipelines
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func pipelineRun(name string, status corev1.ConditionStatus, reason string) *v1.PipelineRun {
+	return &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+		Status: v1.PipelineRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		}}}},
+	}
+}
+
+func TestValidatePipelineRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		pr      *v1.PipelineRun
+		status  string
+		wantErr string
+	}{{
+		name:   "succeeded",
+		pr:     pipelineRun("pr", corev1.ConditionTrue, "Succeeded"),
+		status: "successful",
+	}, {
+		name:   "failed",
+		pr:     pipelineRun("pr", corev1.ConditionFalse, "Failed"),
+		status: "Failure",
+	}, {
+		name:    "failed instead of succeeded",
+		pr:      pipelineRun("pr", corev1.ConditionFalse, "Failed"),
+		status:  "success",
+		wantErr: "error waiting for pipeline run pr to finish",
+	}, {
+		name:    "unknown status",
+		pr:      pipelineRun("pr", corev1.ConditionTrue, "Succeeded"),
+		status:  "skipped",
+		wantErr: "Not valid input",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tt.pr)
+			err := testutil.CaptureFailures(func() {
+				ValidatePipelineRun(c, tt.pr.Name, tt.status, "test-ns")
+			})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected a failure containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
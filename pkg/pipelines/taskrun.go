@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
 	"github.com/openshift-pipelines/release-tests/pkg/wait"
@@ -22,7 +21,7 @@ import (
 func ValidateTaskRun(c *clients.Clients, trname, status, namespace string) {
 	matched_trname := getTaskRunNameMatches(c, trname, namespace)
 	if matched_trname == "" {
-		testsuit.T.Errorf("Error: Nothing matched with Taskrun name: %s in namespace %s", trname, namespace)
+		c.Reporter().Errorf("Error: Nothing matched with Taskrun name: %s in namespace %s", trname, namespace)
 	}
 	// Verify status of TaskRun (wait for it)
 	switch {
@@ -33,7 +32,7 @@ func ValidateTaskRun(c *clients.Clients, trname, status, namespace string) {
 	case strings.Contains(strings.ToLower(status), "timeout"):
 		validateTaskRunTimeOutFailure(c, matched_trname, namespace)
 	default:
-		testsuit.T.Errorf("Error: %s ", "Not valid input")
+		c.Reporter().Errorf("Error: %s ", "Not valid input")
 	}
 }
 
@@ -44,17 +43,18 @@ func validateTaskRunForFailedStatus(c *clients.Clients, trname, namespace string
 	if err != nil {
 		buf, logsErr := getTaskrunLogs(c, trname, namespace)
 		events, eventError := k8s.GetWarningEvents(c, namespace)
+		recordDiagnostics("taskrun/"+trname, buf, logsErr, events, eventError)
 		if logsErr != nil {
 			if eventError != nil {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs error: \n%v \ntaskrun events error: \n%v", trname, err, logsErr, eventError)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs error: \n%v \ntaskrun events error: \n%v", trname, err, logsErr, eventError)
 			} else {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs error: \n%v \ntaskrun events: \n%v", trname, err, logsErr, events)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs error: \n%v \ntaskrun events: \n%v", trname, err, logsErr, events)
 			}
 		} else {
 			if eventError != nil {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs: \n%v \ntaskrun events error: \n%v", trname, err, buf.String(), eventError)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunFailed state \n%v \ntaskrun logs: \n%v \ntaskrun events error: \n%v", trname, err, buf.String(), eventError)
 			} else {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunFailed state \n %v \ntaskrun logs: \n%v \ntaskrun events: \n%v", trname, err, buf.String(), events)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunFailed state \n %v \ntaskrun logs: \n%v \ntaskrun events: \n%v", trname, err, buf.String(), events)
 			}
 		}
 	}
@@ -67,17 +67,18 @@ func validateTaskRunForSuccessStatus(c *clients.Clients, trname, namespace strin
 	if err != nil {
 		buf, logsErr := getTaskrunLogs(c, trname, namespace)
 		events, eventError := k8s.GetWarningEvents(c, namespace)
+		recordDiagnostics("taskrun/"+trname, buf, logsErr, events, eventError)
 		if logsErr != nil {
 			if eventError != nil {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs error: \n%v \ntaskrun events error: \n%v", trname, err, logsErr, eventError)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs error: \n%v \ntaskrun events error: \n%v", trname, err, logsErr, eventError)
 			} else {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs error: \n%v \ntaskrun events: \n%v", trname, err, logsErr, events)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs error: \n%v \ntaskrun events: \n%v", trname, err, logsErr, events)
 			}
 		} else {
 			if eventError != nil {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs: \n%v \ntaskrun events error: \n%v", trname, err, buf.String(), eventError)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs: \n%v \ntaskrun events error: \n%v", trname, err, buf.String(), eventError)
 			} else {
-				testsuit.T.Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs: \n%v \ntaskrun events: \n%v", trname, err, buf.String(), events)
+				c.Reporter().Errorf("task run %s was expected to be in TaskRunSucceed state \n%v \ntaskrun logs: \n%v \ntaskrun events: \n%v", trname, err, buf.String(), events)
 			}
 		}
 	}
@@ -88,14 +89,14 @@ func validateTaskRunTimeOutFailure(c *clients.Clients, trname, namespace string)
 	log.Printf("Waiting for TaskRun %s in namespace %s to complete", trname, namespace)
 	err = wait.WaitForTaskRunState(c, "run-giraffe", wait.FailedWithReason("TaskRunTimeout", trname), "TaskRunTimeout")
 	if err != nil {
-		testsuit.T.Errorf("task run %s was expected to be in TaskRunTimeout state \n %v", trname, err)
+		c.Reporter().Errorf("task run %s was expected to be in TaskRunTimeout state \n %v", trname, err)
 	}
 }
 
 func getTaskRunNameMatches(c *clients.Clients, trname, namespace string) string {
 	trlist, err := c.TaskRunClient.List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to list task runs in namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to list task runs in namespace %s \n %v", namespace, err)
 	}
 
 	var matched_tr string
@@ -112,7 +113,7 @@ func getTaskRunNameMatches(c *clients.Clients, trname, namespace string) string
 func ValidateTaskRunLabelPropogation(c *clients.Clients, trname, namespace string) {
 	trlist, err := c.TaskRunClient.List(c.Ctx, metav1.ListOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to list task runs in namespace %s \n %v", namespace, err)
+		c.Reporter().Errorf("failed to list task runs in namespace %s \n %v", namespace, err)
 	}
 
 	var matched_tr string
@@ -127,7 +128,7 @@ func ValidateTaskRunLabelPropogation(c *clients.Clients, trname, namespace strin
 
 	tr, err := c.TaskRunClient.Get(c.Ctx, matched_tr, metav1.GetOptions{})
 	if err != nil {
-		testsuit.T.Errorf("failed to get task run %s in namespace %s \n %v", matched_tr, namespace, err)
+		c.Reporter().Errorf("failed to get task run %s in namespace %s \n %v", matched_tr, namespace, err)
 	}
 
 	for key, val := range tr.Labels {
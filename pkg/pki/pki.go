@@ -0,0 +1,171 @@
+// Package pki issues the TLS certificates of the tests in process with
+// crypto/x509: a CA per scenario and the server and client certificates it
+// signs, and the SSH keys of the in-cluster git server and its clients. Keys
+// are only written to the directories given to Write, e.g. the temporary
+// directory of the scenario (see store.TempDir).
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	keyBits = 2048
+	// validity of the certificates, long enough for an upgrade run between
+	// the pre and post upgrade specs
+	validity = 90 * 24 * time.Hour
+	// organization of the subjects of the certificates
+	organization = "OpenShift Pipelines release tests"
+)
+
+// Certificate is a certificate and its private key
+type Certificate struct {
+	Cert *x509.Certificate
+	Key  *rsa.PrivateKey
+}
+
+// NewCA returns a self-signed CA
+func NewCA(commonName string) (*Certificate, error) {
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	return issue(template, nil)
+}
+
+// NewSelfSigned returns a self-signed server certificate for the hosts. Like
+// `openssl req -x509`, it is also a CA so clients can trust it as their root.
+func NewSelfSigned(commonName string, hosts ...string) (*Certificate, error) {
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	setServer(template, hosts)
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage |= x509.KeyUsageCertSign
+	return issue(template, nil)
+}
+
+// NewServer returns a server certificate signed by the CA for the hosts, DNS
+// names or IP addresses, which are also the subject alternative names
+func (ca *Certificate) NewServer(commonName string, hosts ...string) (*Certificate, error) {
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	setServer(template, hosts)
+	return issue(template, ca)
+}
+
+// NewClient returns a client certificate signed by the CA
+func (ca *Certificate) NewClient(commonName string) (*Certificate, error) {
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return issue(template, ca)
+}
+
+// CertPEM returns the PEM encoded certificate
+func (c *Certificate) CertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Cert.Raw})
+}
+
+// KeyPEM returns the PEM encoded PKCS #1 private key
+func (c *Certificate) KeyPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(c.Key)})
+}
+
+// TLSCertificate returns the certificate to present in TLS handshakes
+func (c *Certificate) TLSCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.Cert.Raw}, PrivateKey: c.Key, Leaf: c.Cert}
+}
+
+// Pool returns a pool trusting the certificate, e.g. a CA
+func (c *Certificate) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.Cert)
+	return pool
+}
+
+// Write writes the certificate to <dir>/<name>.crt and its private key to
+// <dir>/<name>.key, readable only by the current user, and returns their paths
+func (c *Certificate) Write(dir, name string) (string, string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", "", err
+	}
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certPath, c.CertPEM(), 0600); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(keyPath, c.KeyPEM(), 0600); err != nil {
+		return "", "", err
+	}
+	return certPath, keyPath, nil
+}
+
+func newTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a serial number: %v", err)
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{organization}},
+		// tolerate clocks of the cluster slightly behind the local one
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+func setServer(template *x509.Certificate, hosts []string) {
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+}
+
+// issue signs the template with the CA, or self-signs it if ca is nil
+func issue(template *x509.Certificate, ca *Certificate) (*Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the key of %s: %v", template.Subject.CommonName, err)
+	}
+	parent, signer := template, key
+	if ca != nil {
+		parent, signer = ca.Cert, ca.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate of %s: %v", template.Subject.CommonName, err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{Cert: cert, Key: key}, nil
+}
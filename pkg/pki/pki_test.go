@@ -0,0 +1,180 @@
+package pki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCertificates(t *testing.T) {
+	ca, err := NewCA("releasetest-ca")
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	server, err := ca.NewServer("el-listener", "el-listener.releasetest-a.svc", "127.0.0.1", "::1")
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	client, err := ca.NewClient("releasetest-client")
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	selfSigned, err := NewSelfSigned("registry", "registry.releasetest-a.svc", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("NewSelfSigned() failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cert         *Certificate
+		issuer       *Certificate
+		isCA         bool
+		keyUsage     x509.KeyUsage
+		extKeyUsage  []x509.ExtKeyUsage
+		dnsNames     []string
+		ipAddresses  []net.IP
+		verifyUsages []x509.ExtKeyUsage
+	}{{
+		name:     "CA",
+		cert:     ca,
+		issuer:   ca,
+		isCA:     true,
+		keyUsage: x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}, {
+		name:         "server",
+		cert:         server,
+		issuer:       ca,
+		keyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		dnsNames:     []string{"el-listener.releasetest-a.svc"},
+		ipAddresses:  []net.IP{net.ParseIP("127.0.0.1").To4(), net.ParseIP("::1")},
+		verifyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, {
+		name:         "client",
+		cert:         client,
+		issuer:       ca,
+		keyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		verifyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, {
+		name:         "self-signed",
+		cert:         selfSigned,
+		issuer:       selfSigned,
+		isCA:         true,
+		keyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		extKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		dnsNames:     []string{"registry.releasetest-a.svc"},
+		ipAddresses:  []net.IP{net.ParseIP("10.0.0.1").To4()},
+		verifyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := tt.cert.Cert
+			if cert.IsCA != tt.isCA {
+				t.Errorf("IsCA = %v, want %v", cert.IsCA, tt.isCA)
+			}
+			if tt.isCA && !cert.BasicConstraintsValid {
+				t.Error("BasicConstraintsValid = false for a CA")
+			}
+			if cert.KeyUsage != tt.keyUsage {
+				t.Errorf("KeyUsage = %b, want %b", cert.KeyUsage, tt.keyUsage)
+			}
+			if !reflect.DeepEqual(cert.ExtKeyUsage, tt.extKeyUsage) {
+				t.Errorf("ExtKeyUsage = %v, want %v", cert.ExtKeyUsage, tt.extKeyUsage)
+			}
+			if !reflect.DeepEqual(cert.DNSNames, tt.dnsNames) {
+				t.Errorf("DNSNames = %v, want %v", cert.DNSNames, tt.dnsNames)
+			}
+			if !reflect.DeepEqual(cert.IPAddresses, tt.ipAddresses) {
+				t.Errorf("IPAddresses = %v, want %v", cert.IPAddresses, tt.ipAddresses)
+			}
+			if cert.Subject.Organization[0] != organization {
+				t.Errorf("Organization = %v, want %s", cert.Subject.Organization, organization)
+			}
+			if !cert.NotBefore.Before(cert.NotAfter) || cert.NotAfter.Sub(cert.NotBefore) < validity {
+				t.Errorf("valid from %s to %s, want at least %s", cert.NotBefore, cert.NotAfter, validity)
+			}
+			if err := cert.CheckSignatureFrom(tt.issuer.Cert); err != nil {
+				t.Errorf("not signed by %s: %v", tt.issuer.Cert.Subject.CommonName, err)
+			}
+
+			opts := x509.VerifyOptions{Roots: tt.issuer.Pool(), KeyUsages: tt.verifyUsages}
+			if len(tt.dnsNames) > 0 {
+				opts.DNSName = tt.dnsNames[0]
+			}
+			if _, err := cert.Verify(opts); err != nil {
+				t.Errorf("Verify() failed: %v", err)
+			}
+		})
+	}
+
+	// a server certificate is not valid for other hosts nor as a client
+	if _, err := server.Cert.Verify(x509.VerifyOptions{Roots: ca.Pool(), DNSName: "other.svc"}); err == nil {
+		t.Error("server certificate verified for a host not in its SANs")
+	}
+	if _, err := server.Cert.Verify(x509.VerifyOptions{Roots: ca.Pool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err == nil {
+		t.Error("server certificate verified as a client certificate")
+	}
+	// the certificates of a CA are only trusted by its pool
+	if _, err := server.Cert.Verify(x509.VerifyOptions{Roots: selfSigned.Pool(), DNSName: "el-listener.releasetest-a.svc"}); err == nil {
+		t.Error("server certificate verified with another root")
+	}
+}
+
+func TestCertificatePEM(t *testing.T) {
+	ca, err := NewCA("releasetest-ca")
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	server, err := ca.NewServer("localhost", "localhost")
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	if _, err := tls.X509KeyPair(server.CertPEM(), server.KeyPEM()); err != nil {
+		t.Errorf("the PEM certificate and key do not make a key pair: %v", err)
+	}
+	block, _ := pem.Decode(server.KeyPEM())
+	if block == nil || block.Type != "RSA PRIVATE KEY" {
+		t.Fatalf("KeyPEM() is not a PKCS #1 key: %v", block)
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+		t.Errorf("KeyPEM() is not a PKCS #1 key: %v", err)
+	}
+
+	if leaf := server.TLSCertificate().Leaf; leaf != server.Cert {
+		t.Error("TLSCertificate() does not carry the certificate as leaf")
+	}
+}
+
+func TestCertificateWrite(t *testing.T) {
+	ca, err := NewCA("releasetest-ca")
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	dir := filepath.Join(t.TempDir(), "tls")
+	certPath, keyPath, err := ca.Write(dir, "ca")
+	if err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if certPath != filepath.Join(dir, "ca.crt") || keyPath != filepath.Join(dir, "ca.key") {
+		t.Errorf("Write() = %s, %s, want ca.crt and ca.key in %s", certPath, keyPath, dir)
+	}
+	for path, want := range map[string][]byte{certPath: ca.CertPEM(), keyPath: ca.KeyPEM()} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("%s is %o, want 600", path, perm)
+		}
+		if got, _ := os.ReadFile(path); string(got) != string(want) {
+			t.Errorf("%s does not hold the PEM encoding", path)
+		}
+	}
+}
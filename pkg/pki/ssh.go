@@ -0,0 +1,54 @@
+package pki
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHKey is an ed25519 SSH key pair
+type SSHKey struct {
+	Key    ed25519.PrivateKey
+	Signer ssh.Signer
+}
+
+// NewSSHKey returns a new ed25519 SSH key pair
+func NewSSHKey() (*SSHKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SSH key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &SSHKey{Key: key, Signer: signer}, nil
+}
+
+// PrivateKeyPEM returns the private key in the OpenSSH format, e.g. the
+// ssh-privatekey of a kubernetes.io/ssh-auth secret
+func (k *SSHKey) PrivateKeyPEM() ([]byte, error) {
+	block, err := ssh.MarshalPrivateKey(k.Key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SSH key: %v", err)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// AuthorizedKey returns the public key as a line of authorized_keys
+func (k *SSHKey) AuthorizedKey() string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(k.Signer.PublicKey())))
+}
+
+// KnownHost returns the line of known_hosts of the host presenting the key
+// on the port
+func (k *SSHKey) KnownHost(host string, port int) string {
+	return knownhosts.Line([]string{net.JoinHostPort(host, strconv.Itoa(port))}, k.Signer.PublicKey())
+}
@@ -0,0 +1,153 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// capabilityGroups are the API groups served when an OpenShift capability is
+// enabled, they are looked up by API discovery on other platforms
+var capabilityGroups = map[string]string{
+	"Build":                    "build.openshift.io",
+	"Console":                  "console.openshift.io",
+	"DeploymentConfig":         "apps.openshift.io",
+	"ImageRegistry":            "imageregistry.operator.openshift.io",
+	"OperatorLifecycleManager": "operators.coreos.com",
+}
+
+// sslPassthroughAnnotation makes ingress-nginx pass TLS connections through to the service
+const sslPassthroughAnnotation = "nginx.ingress.kubernetes.io/ssl-passthrough"
+
+// kubernetes uses plain Namespaces and Ingresses, e.g. on kind
+type kubernetes struct{}
+
+// Kubernetes returns the platform of plain Kubernetes clusters
+func Kubernetes() Platform {
+	return kubernetes{}
+}
+
+func (kubernetes) Name() string {
+	return config.TargetKubernetes
+}
+
+func (kubernetes) Supports(Feature) bool {
+	return false
+}
+
+func (kubernetes) CreateNamespace(c *clients.Clients, name string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if _, err := c.KubeClient.Kube.CoreV1().Namespaces().Create(c.Ctx, ns, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create namespace %s: %v", name, err)
+	}
+	log.Printf("Created namespace %s", name)
+	return nil
+}
+
+func (kubernetes) NamespaceExists(c *clients.Clients, name string) (bool, error) {
+	_, err := c.KubeClient.Kube.CoreV1().Namespaces().Get(c.Ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (kubernetes) DeleteNamespace(c *clients.Clients, name string) {
+	if err := c.KubeClient.Kube.CoreV1().Namespaces().Delete(c.Ctx, name, metav1.DeleteOptions{}); err != nil {
+		log.Printf("Warning: could not delete namespace %s: %v", name, err)
+		return
+	}
+	log.Printf("Deleted namespace %s", name)
+}
+
+// IsCapabilityEnabled tells whether the API group of the capability is served
+func (kubernetes) IsCapabilityEnabled(c *clients.Clients, name string) (bool, error) {
+	group, ok := capabilityGroups[name]
+	if !ok {
+		return false, nil
+	}
+	groups, err := c.KubeClient.Kube.Discovery().ServerGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to discover the API groups: %v", err)
+	}
+	for _, g := range groups.Groups {
+		if g.Name == group {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ExposeService creates an Ingress named as the service, with the host
+// <service>-<namespace>.<ingress domain>. TLS connections are passed through
+// with the ssl-passthrough annotation of ingress-nginx, which must be started
+// with --enable-ssl-passthrough.
+func (kubernetes) ExposeService(c *clients.Clients, namespace, service, port string, tls bool) (string, error) {
+	backend := networkingv1.IngressServiceBackend{Name: service}
+	if number, err := strconv.Atoi(port); err == nil {
+		backend.Port.Number = int32(number)
+	} else if port != "" {
+		backend.Port.Name = port
+	} else {
+		svc, err := c.KubeClient.Kube.CoreV1().Services(namespace).Get(c.Ctx, service, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get service %s in namespace %s: %v", service, namespace, err)
+		}
+		if len(svc.Spec.Ports) == 0 {
+			return "", fmt.Errorf("service %s in namespace %s has no port", service, namespace)
+		}
+		backend.Port.Number = svc.Spec.Ports[0].Port
+	}
+
+	host := fmt.Sprintf("%s-%s.%s", service, namespace, config.Flags.IngressDomain)
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: service, Namespace: namespace},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend:  networkingv1.IngressBackend{Service: &backend},
+					}},
+				}},
+			}},
+		},
+	}
+	scheme := "http"
+	if tls {
+		ingress.Annotations = map[string]string{sslPassthroughAnnotation: "true"}
+		scheme = "https"
+	}
+	if _, err := c.KubeClient.Kube.NetworkingV1().Ingresses(namespace).Create(c.Ctx, ingress, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("failed to create ingress %s in namespace %s: %v", service, namespace, err)
+	}
+	url := scheme + "://" + host
+	log.Printf("Ingress url: %s", url)
+	return url, nil
+}
+
+func (kubernetes) UnexposeService(c *clients.Clients, namespace, service string) error {
+	ingresses := c.KubeClient.Kube.NetworkingV1().Ingresses(namespace)
+	if err := ingresses.Delete(c.Ctx, service, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ingress %s in namespace %s: %v", service, namespace, err)
+	}
+	return wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		_, err := ingresses.Get(c.Ctx, service, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
@@ -0,0 +1,170 @@
+package platform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestKubernetesNamespaces(t *testing.T) {
+	c, _ := testutil.NewFakeClients("test-ns")
+	p := Kubernetes()
+
+	if err := p.CreateNamespace(c, "releasetest-a"); err != nil {
+		t.Fatalf("CreateNamespace() failed: %v", err)
+	}
+	if err := p.CreateNamespace(c, "releasetest-a"); err == nil {
+		t.Error("CreateNamespace() of an existing namespace succeeded")
+	}
+	if exists, err := p.NamespaceExists(c, "releasetest-a"); err != nil || !exists {
+		t.Errorf("NamespaceExists() = %v, %v, want true", exists, err)
+	}
+
+	p.DeleteNamespace(c, "releasetest-a")
+	if exists, err := p.NamespaceExists(c, "releasetest-a"); err != nil || exists {
+		t.Errorf("NamespaceExists() = %v, %v after deletion, want false", exists, err)
+	}
+	// cleanups do not fail on missing namespaces
+	p.DeleteNamespace(c, "releasetest-a")
+}
+
+func TestKubernetesIsCapabilityEnabled(t *testing.T) {
+	tests := []struct {
+		name       string
+		capability string
+		want       bool
+	}{
+		{name: "group served", capability: "Console", want: true},
+		{name: "group not served", capability: "Build"},
+		{name: "unknown capability", capability: "Insights"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fcs := testutil.NewFakeClients("test-ns")
+			fcs.Kube.Resources = []*metav1.APIResourceList{
+				{GroupVersion: "v1"},
+				{GroupVersion: "console.openshift.io/v1"},
+			}
+			got, err := Kubernetes().IsCapabilityEnabled(c, tt.capability)
+			if err != nil {
+				t.Fatalf("IsCapabilityEnabled() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsCapabilityEnabled(%s) = %v, want %v", tt.capability, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubernetesExposeService(t *testing.T) {
+	domain := config.Flags.IngressDomain
+	defer func() { config.Flags.IngressDomain = domain }()
+	config.Flags.IngressDomain = "127.0.0.1.nip.io"
+
+	service := func(ports ...corev1.ServicePort) runtime.Object {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"},
+			Spec:       corev1.ServiceSpec{Ports: ports},
+		}
+	}
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		port     string
+		tls      bool
+		want     string
+		wantPort networkingv1.ServiceBackendPort
+		wantErr  string
+	}{{
+		name:     "port number",
+		port:     "8080",
+		want:     "http://sink-test-ns.127.0.0.1.nip.io",
+		wantPort: networkingv1.ServiceBackendPort{Number: 8080},
+	}, {
+		name:     "port name",
+		port:     "http-listener",
+		want:     "http://sink-test-ns.127.0.0.1.nip.io",
+		wantPort: networkingv1.ServiceBackendPort{Name: "http-listener"},
+	}, {
+		name:     "first port of the service",
+		objects:  []runtime.Object{service(corev1.ServicePort{Port: 8443}, corev1.ServicePort{Port: 9000})},
+		want:     "http://sink-test-ns.127.0.0.1.nip.io",
+		wantPort: networkingv1.ServiceBackendPort{Number: 8443},
+	}, {
+		name:     "tls passthrough",
+		port:     "8443",
+		tls:      true,
+		want:     "https://sink-test-ns.127.0.0.1.nip.io",
+		wantPort: networkingv1.ServiceBackendPort{Number: 8443},
+	}, {
+		name:    "service without port",
+		objects: []runtime.Object{service()},
+		wantErr: "has no port",
+	}, {
+		name:    "missing service",
+		wantErr: "failed to get service sink",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fcs := testutil.NewFakeClients("test-ns", tt.objects...)
+			url, err := Kubernetes().ExposeService(c, "test-ns", "sink", tt.port, tt.tls)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExposeService() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExposeService() failed: %v", err)
+			}
+			if url != tt.want {
+				t.Errorf("ExposeService() = %s, want %s", url, tt.want)
+			}
+
+			ingress, err := fcs.Kube.NetworkingV1().Ingresses("test-ns").Get(context.Background(), "sink", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the ingress: %v", err)
+			}
+			rule := ingress.Spec.Rules[0]
+			if rule.Host != "sink-test-ns.127.0.0.1.nip.io" {
+				t.Errorf("host = %s, want sink-test-ns.127.0.0.1.nip.io", rule.Host)
+			}
+			backend := rule.HTTP.Paths[0].Backend.Service
+			if backend.Name != "sink" || backend.Port != tt.wantPort {
+				t.Errorf("backend = %+v, want service sink port %+v", backend, tt.wantPort)
+			}
+			if got := ingress.Annotations[sslPassthroughAnnotation] == "true"; got != tt.tls {
+				t.Errorf("ssl passthrough = %v, want %v", got, tt.tls)
+			}
+
+			// exposing twice reuses the ingress
+			if again, err := Kubernetes().ExposeService(c, "test-ns", "sink", tt.port, tt.tls); err != nil || again != url {
+				t.Errorf("second ExposeService() = %s, %v, want %s", again, err, url)
+			}
+		})
+	}
+}
+
+func TestKubernetesUnexposeService(t *testing.T) {
+	shortTimeouts(t)
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"}}
+	c, fcs := testutil.NewFakeClients("test-ns", ingress)
+
+	if err := Kubernetes().UnexposeService(c, "test-ns", "sink"); err != nil {
+		t.Fatalf("UnexposeService() failed: %v", err)
+	}
+	if _, err := fcs.Kube.NetworkingV1().Ingresses("test-ns").Get(context.Background(), "sink", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("ingress still exists after UnexposeService(): %v", err)
+	}
+	if err := Kubernetes().UnexposeService(c, "test-ns", "sink"); err != nil {
+		t.Errorf("UnexposeService() of a service not exposed failed: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// openShift uses Projects, Routes and the ClusterVersion
+type openShift struct{}
+
+// OpenShift returns the platform of OpenShift clusters
+func OpenShift() Platform {
+	return openShift{}
+}
+
+func (openShift) Name() string {
+	return config.TargetOpenShift
+}
+
+func (openShift) Supports(Feature) bool {
+	return true
+}
+
+func (openShift) CreateNamespace(c *clients.Clients, name string) error {
+	oc.CreateNewProject(name)
+	return nil
+}
+
+func (openShift) NamespaceExists(c *clients.Clients, name string) (bool, error) {
+	return oc.CheckProjectExists(name), nil
+}
+
+func (openShift) DeleteNamespace(c *clients.Clients, name string) {
+	oc.DeleteProjectIgnoreErors(name)
+}
+
+func (openShift) IsCapabilityEnabled(c *clients.Clients, name string) (bool, error) {
+	cv, err := c.ClusterVersion.Get(c.Ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, capability := range cv.Status.Capabilities.EnabledCapabilities {
+		if string(capability) == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ExposeService creates a Route named as the service, like `oc expose service`
+// (or `oc create route passthrough` with tls), and waits for the router to admit it
+func (openShift) ExposeService(c *clients.Clients, namespace, service, port string, tls bool) (string, error) {
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: service, Namespace: namespace},
+		Spec: routev1.RouteSpec{
+			To: routev1.RouteTargetReference{Kind: "Service", Name: service},
+		},
+	}
+	if port != "" {
+		route.Spec.Port = &routev1.RoutePort{TargetPort: intstr.Parse(port)}
+	}
+	scheme := "http"
+	if tls {
+		route.Spec.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
+		scheme = "https"
+	}
+	if _, err := c.Route.Routes(namespace).Create(c.Ctx, route, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("failed to create route %s in namespace %s: %v", service, namespace, err)
+	}
+
+	var host string
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		r, err := c.Route.Routes(namespace).Get(c.Ctx, service, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, ingress := range r.Status.Ingress {
+			for _, condition := range ingress.Conditions {
+				if condition.Type == routev1.RouteAdmitted && condition.Status == corev1.ConditionTrue {
+					host = ingress.Host
+					return true, nil
+				}
+			}
+		}
+		log.Printf("Waiting for route %s to be admitted", service)
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("route %s in namespace %s was not admitted: %v", service, namespace, err)
+	}
+	url := scheme + "://" + host
+	log.Printf("Route url: %s", url)
+	return url, nil
+}
+
+func (openShift) UnexposeService(c *clients.Clients, namespace, service string) error {
+	err := c.Route.Routes(namespace).Delete(c.Ctx, service, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete route %s in namespace %s: %v", service, namespace, err)
+	}
+	return wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		_, err := c.Route.Routes(namespace).Get(c.Ctx, service, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
@@ -0,0 +1,141 @@
+package platform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOpenShiftIsCapabilityEnabled(t *testing.T) {
+	version := &configv1.ClusterVersion{ObjectMeta: metav1.ObjectMeta{Name: "version"}}
+	version.Status.Capabilities.EnabledCapabilities = []configv1.ClusterVersionCapability{"Console", "Build"}
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		capability string
+		want       bool
+		wantErr    bool
+	}{
+		{name: "enabled", objects: []runtime.Object{version}, capability: "Console", want: true},
+		{name: "disabled", objects: []runtime.Object{version}, capability: "DeploymentConfig"},
+		{name: "no cluster version", capability: "Console", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testutil.NewFakeClients("test-ns", tt.objects...)
+			got, err := OpenShift().IsCapabilityEnabled(c, tt.capability)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsCapabilityEnabled() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("IsCapabilityEnabled(%s) = %v, want %v", tt.capability, got, tt.want)
+			}
+		})
+	}
+}
+
+// admitRoutes makes the router admit the created Routes with host
+func admitRoutes(fcs *testutil.FakeClientsets, host string) {
+	fcs.Route.PrependReactor("create", "routes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		route := action.(k8stesting.CreateAction).GetObject().(*routev1.Route)
+		route.Status.Ingress = []routev1.RouteIngress{{
+			Host:       host,
+			Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue}},
+		}}
+		return false, nil, nil
+	})
+}
+
+func TestOpenShiftExposeService(t *testing.T) {
+	shortTimeouts(t)
+	tests := []struct {
+		name    string
+		port    string
+		tls     bool
+		admit   bool
+		want    string
+		wantErr string
+	}{{
+		name:  "http",
+		port:  "8080",
+		admit: true,
+		want:  "http://sink-test-ns.apps.example.com",
+	}, {
+		name:  "default port",
+		admit: true,
+		want:  "http://sink-test-ns.apps.example.com",
+	}, {
+		name:  "tls passthrough",
+		port:  "https",
+		tls:   true,
+		admit: true,
+		want:  "https://sink-test-ns.apps.example.com",
+	}, {
+		name:    "not admitted",
+		port:    "8080",
+		wantErr: "was not admitted",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fcs := testutil.NewFakeClients("test-ns")
+			if tt.admit {
+				admitRoutes(fcs, "sink-test-ns.apps.example.com")
+			}
+			url, err := OpenShift().ExposeService(c, "test-ns", "sink", tt.port, tt.tls)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExposeService() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExposeService() failed: %v", err)
+			}
+			if url != tt.want {
+				t.Errorf("ExposeService() = %s, want %s", url, tt.want)
+			}
+
+			route, err := fcs.Route.RouteV1().Routes("test-ns").Get(context.Background(), "sink", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the route: %v", err)
+			}
+			if route.Spec.To.Kind != "Service" || route.Spec.To.Name != "sink" {
+				t.Errorf("route to %+v, want service sink", route.Spec.To)
+			}
+			switch {
+			case tt.port == "" && route.Spec.Port != nil:
+				t.Errorf("route port = %+v, want none", route.Spec.Port)
+			case tt.port != "" && (route.Spec.Port == nil || route.Spec.Port.TargetPort.String() != tt.port):
+				t.Errorf("route port = %+v, want %s", route.Spec.Port, tt.port)
+			}
+			if got := route.Spec.TLS != nil && route.Spec.TLS.Termination == routev1.TLSTerminationPassthrough; got != tt.tls {
+				t.Errorf("tls passthrough = %v, want %v", got, tt.tls)
+			}
+		})
+	}
+}
+
+func TestOpenShiftUnexposeService(t *testing.T) {
+	shortTimeouts(t)
+	route := &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "sink", Namespace: "test-ns"}}
+	c, fcs := testutil.NewFakeClients("test-ns", route)
+
+	if err := OpenShift().UnexposeService(c, "test-ns", "sink"); err != nil {
+		t.Fatalf("UnexposeService() failed: %v", err)
+	}
+	if _, err := fcs.Route.RouteV1().Routes("test-ns").Get(context.Background(), "sink", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("route still exists after UnexposeService(): %v", err)
+	}
+	if err := OpenShift().UnexposeService(c, "test-ns", "sink"); err != nil {
+		t.Errorf("UnexposeService() of a service not exposed failed: %v", err)
+	}
+}
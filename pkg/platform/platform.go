@@ -0,0 +1,96 @@
+// Package platform abstracts the primitives which differ between OpenShift
+// and plain Kubernetes clusters (e.g. kind running the upstream Tekton
+// operator): namespaces, exposure of services, SecurityContextConstraints and
+// capability detection. The platform is selected with the target flag (TARGET
+// environment variable), or set with Set.
+package platform
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+)
+
+// Feature is a part of the cluster which only exists on some platforms
+type Feature string
+
+const (
+	// SecurityContextConstraints are the OpenShift pod admission policies
+	SecurityContextConstraints Feature = "SecurityContextConstraints"
+	// PipelineServiceAccount is the "pipeline" ServiceAccount the operator
+	// creates in every namespace on OpenShift
+	PipelineServiceAccount Feature = "PipelineServiceAccount"
+	// Routes expose services through the OpenShift router
+	Routes Feature = "Routes"
+	// ClusterMonitoring is the Prometheus of openshift-monitoring
+	ClusterMonitoring Feature = "ClusterMonitoring"
+)
+
+// Platform implements the operations whose implementation depends on the
+// platform of the cluster
+type Platform interface {
+	// Name is the name of the platform, as given to the target flag
+	Name() string
+	// Supports tells whether the feature exists on the platform
+	Supports(f Feature) bool
+	// CreateNamespace creates a namespace the current user can work in
+	CreateNamespace(c *clients.Clients, name string) error
+	// NamespaceExists tells whether the namespace exists
+	NamespaceExists(c *clients.Clients, name string) (bool, error)
+	// DeleteNamespace deletes the namespace, logging errors as it is used for cleanups
+	DeleteNamespace(c *clients.Clients, name string)
+	// IsCapabilityEnabled tells whether an optional capability of the
+	// cluster, e.g. Console, is enabled
+	IsCapabilityEnabled(c *clients.Clients, name string) (bool, error)
+	// ExposeService makes the port (name or number) of the service reachable
+	// from the tests and returns its base URL. When tls is set the service
+	// serves HTTPS on the port and the TLS connection is passed through.
+	ExposeService(c *clients.Clients, namespace, service, port string, tls bool) (string, error)
+	// UnexposeService deletes what ExposeService created for the service
+	UnexposeService(c *clients.Clients, namespace, service string) error
+}
+
+var (
+	platformMu      sync.Mutex
+	currentPlatform Platform
+)
+
+// Set replaces the platform returned by Current and returns the previous one
+// (nil if none was selected yet)
+func Set(p Platform) Platform {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	prev := currentPlatform
+	currentPlatform = p
+	return prev
+}
+
+// Current returns the platform selected with the target flag
+func Current() Platform {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	if currentPlatform == nil {
+		p, err := New(config.Flags.Target)
+		if err != nil {
+			reporter.Default().Fail(err)
+			p = OpenShift()
+		}
+		currentPlatform = p
+	}
+	return currentPlatform
+}
+
+// New returns the platform called name
+func New(name string) (Platform, error) {
+	switch name {
+	case "", config.TargetOpenShift:
+		return OpenShift(), nil
+	case config.TargetKubernetes:
+		return Kubernetes(), nil
+	default:
+		return nil, fmt.Errorf("unknown target platform %q, expected %q or %q", name, config.TargetOpenShift, config.TargetKubernetes)
+	}
+}
@@ -0,0 +1,88 @@
+package platform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/testutil"
+)
+
+// shortTimeouts makes the waits for Routes and Ingresses give up quickly
+func shortTimeouts(t *testing.T) {
+	t.Helper()
+	timeouts := config.CurrentTimeouts()
+	t.Cleanup(func() { config.SetTimeouts(timeouts) })
+	short := timeouts
+	short.APIRetry, short.APITimeout = 10*time.Millisecond, 100*time.Millisecond
+	config.SetTimeouts(short)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		want     string
+		features bool
+		wantErr  string
+	}{
+		{name: "default", target: "", want: config.TargetOpenShift, features: true},
+		{name: "openshift", target: config.TargetOpenShift, want: config.TargetOpenShift, features: true},
+		{name: "kubernetes", target: config.TargetKubernetes, want: config.TargetKubernetes},
+		{name: "unknown", target: "nomad", wantErr: `unknown target platform "nomad"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New(%q) error = %v, want %q", tt.target, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(%q) failed: %v", tt.target, err)
+			}
+			if p.Name() != tt.want {
+				t.Errorf("Name() = %q, want %q", p.Name(), tt.want)
+			}
+			for _, f := range []Feature{SecurityContextConstraints, PipelineServiceAccount, Routes, ClusterMonitoring} {
+				if got := p.Supports(f); got != tt.features {
+					t.Errorf("Supports(%s) = %v, want %v", f, got, tt.features)
+				}
+			}
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	prev := Set(nil)
+	defer Set(prev)
+	target := config.Flags.Target
+	defer func() { config.Flags.Target = target }()
+
+	config.Flags.Target = config.TargetKubernetes
+	if got := Current().Name(); got != config.TargetKubernetes {
+		t.Errorf("Current() = %s, want the platform of the target flag", got)
+	}
+	// the platform is selected once
+	config.Flags.Target = config.TargetOpenShift
+	if got := Current().Name(); got != config.TargetKubernetes {
+		t.Errorf("Current() = %s after the target changed, want %s", got, config.TargetKubernetes)
+	}
+
+	if got := Set(OpenShift()); got == nil || got.Name() != config.TargetKubernetes {
+		t.Errorf("Set() returned %v, want the previous platform", got)
+	}
+	if got := Current().Name(); got != config.TargetOpenShift {
+		t.Errorf("Current() = %s after Set, want %s", got, config.TargetOpenShift)
+	}
+
+	Set(nil)
+	config.Flags.Target = "nomad"
+	err := testutil.CaptureFailures(func() { Current() })
+	if err == nil || !strings.Contains(err.Error(), "unknown target platform") {
+		t.Errorf("Current() with an unknown target reported %v", err)
+	}
+}
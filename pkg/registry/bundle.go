@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// annotations of the layers of a Tekton bundle, read by the bundles resolver
+const (
+	bundleAPIVersion = "dev.tekton.image.apiVersion"
+	bundleKind       = "dev.tekton.image.kind"
+	bundleName       = "dev.tekton.image.name"
+)
+
+// PublishBundle publishes the Tekton resources of the files (paths relative
+// to the repository) as a bundle standing in for reference, e.g.
+// quay.io/openshift-pipeline/resolver-test-bundles:1.0, and returns its
+// reference on the registry. The repository of reference is mirrored, and the
+// resources are rewritten like the manifests given to Rewrite, so bundles
+// referring to each other resolve from the registry.
+func (r *Registry) PublishBundle(c *clients.Clients, reference string, files []string) (string, error) {
+	tag, err := name.NewTag(reference, name.StrictValidation)
+	if err != nil {
+		return "", fmt.Errorf("invalid bundle reference %q, expected <registry>/<repository>:<tag>: %v", reference, err)
+	}
+	r.Mirror(tag.Context().Name())
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	var resources []string
+	for _, file := range files {
+		manifests, err := os.ReadFile(config.Path(file))
+		if err != nil {
+			return "", fmt.Errorf("could not load test data from file %s: %v", file, err)
+		}
+		if manifests, err = r.Rewrite(manifests); err != nil {
+			return "", fmt.Errorf("failed to rewrite %s for the registry: %v", file, err)
+		}
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+		for {
+			doc, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to read %s: %v", file, err)
+			}
+			var obj metav1.PartialObjectMetadata
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return "", fmt.Errorf("failed to read %s: %v", file, err)
+			}
+			if obj.Kind == "" {
+				continue
+			}
+			kind := strings.ToLower(obj.Kind)
+			layer, err := tarLayer(obj.Name, doc)
+			if err != nil {
+				return "", err
+			}
+			img, err = mutate.Append(img, mutate.Addendum{
+				Layer: layer,
+				Annotations: map[string]string{
+					bundleAPIVersion: obj.APIVersion,
+					bundleKind:       kind,
+					bundleName:       obj.Name,
+				},
+				MediaType: types.OCILayer,
+			})
+			if err != nil {
+				return "", err
+			}
+			resources = append(resources, kind+"/"+obj.Name)
+		}
+	}
+	if len(resources) == 0 {
+		return "", fmt.Errorf("no Tekton resources in %s for bundle %s", strings.Join(files, ", "), reference)
+	}
+
+	if err := r.push(c, tag, img); err != nil {
+		return "", fmt.Errorf("failed to publish bundle %s: %v", reference, err)
+	}
+	published := r.Reference(reference)
+	log.Printf("Published bundle %s with %s", published, strings.Join(resources, ", "))
+	return published, nil
+}
+
+// tarLayer returns a layer holding the resource as the only file of a tar,
+// the layout of the layers of tkn bundle push
+func tarLayer(name string, resource []byte) (v1.Layer, error) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(resource)), Typeflag: tar.TypeReg}); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(resource); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}, tarball.WithMediaType(types.OCILayer))
+}
+
+// push pushes the image, as the repository and tag of tag, to the registry
+// exposed to the tests
+func (r *Registry) push(c *clients.Clients, tag name.Tag, img v1.Image) error {
+	host, err := r.Exposed(c)
+	if err != nil {
+		return err
+	}
+	var opts []name.Option
+	if r.CA == nil {
+		opts = append(opts, name.Insecure)
+	}
+	dst, err := name.NewTag(host+"/"+tag.RepositoryStr()+":"+tag.TagStr(), opts...)
+	if err != nil {
+		return err
+	}
+	transport := remote.DefaultTransport.(*http.Transport).Clone()
+	if r.CA != nil {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    r.CA.Pool(),
+			ServerName: r.ServerName(),
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	auth := authn.Anonymous
+	if r.Password != "" {
+		auth = &authn.Basic{Username: Username, Password: r.Password}
+	}
+	return remote.Write(dst, img, remote.WithAuth(auth), remote.WithTransport(transport), remote.WithContext(c.Ctx))
+}
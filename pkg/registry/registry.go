@@ -0,0 +1,279 @@
+// Package registry deploys an OCI registry in the namespace of a scenario, so
+// the bundles resolver, chains and the image building tasks pull and push
+// images with no external registry nor its credentials, e.g. in disconnected
+// labs.
+//
+// The registry is the distribution image of the registryimage flag
+// (REGISTRY_IMAGE environment variable), optionally with htpasswd
+// authentication and TLS from a CA generated for the scenario. It is addressed
+// by the cluster IP of its service, a private address for which
+// go-containerregistry, hence the bundles resolver and chains, falls back to
+// plain HTTP without TLS. With TLS, the tasks in the cluster do not verify its
+// certificate, only the tests do with its CA. The tests reach it through
+// pkg/expose.
+package registry
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
+	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	"github.com/openshift-pipelines/release-tests/pkg/pki"
+	"golang.org/x/crypto/bcrypt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Name is the name of the deployment, service and secret of the registry
+	Name = "registry"
+	port = 5000
+	// Username is the user of the registry when its authentication is enabled
+	Username = "release-tests"
+
+	// CredentialsSecret is the kubernetes.io/dockerconfigjson secret with the
+	// credentials of the registry, created when its authentication is enabled.
+	// Link it to the service account of the runs, or give it to the secret
+	// param of the bundles resolver.
+	CredentialsSecret = "registry-credentials"
+
+	secretPath = "/etc/registry"
+)
+
+// Options are the optional features of the registry
+type Options struct {
+	// Auth requires the credentials of Username
+	Auth bool
+	// TLS serves HTTPS with a certificate of a CA generated for the registry
+	TLS bool
+}
+
+// Registry is an OCI registry deployed in a namespace
+type Registry struct {
+	Namespace string
+	// Host is the address of the registry in the cluster, <cluster IP>:<port>
+	Host string
+	// Password of Username, empty unless the authentication is enabled
+	Password string
+	// CA signed the certificate of the registry, nil unless TLS is enabled
+	CA *pki.Certificate
+
+	svc expose.Service
+	// mirrors are the repositories (or registries) the registry stands in
+	// for, mapped to their repository on the registry
+	mirrors map[string]string
+}
+
+// Deploy runs the registry in the namespace and waits for it to be ready,
+// then creates the secret of its credentials when its authentication is
+// enabled
+func Deploy(c *clients.Clients, namespace string, opts Options) (*Registry, error) {
+	r := &Registry{
+		Namespace: namespace,
+		svc:       expose.Service{Namespace: namespace, Name: Name, Port: "registry", TLS: opts.TLS},
+		mirrors:   map[string]string{},
+	}
+	labels := map[string]string{"app": Name}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Name: "registry", Port: port, TargetPort: intstr.FromString("registry")}},
+		},
+	}
+	created, err := c.KubeClient.Kube.CoreV1().Services(namespace).Create(c.Ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("a registry is already deployed in namespace %s", namespace)
+		}
+		return nil, fmt.Errorf("failed to create service %s in namespace %s: %v", Name, namespace, err)
+	}
+	r.Host = net.JoinHostPort(created.Spec.ClusterIP, strconv.Itoa(port))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace, Labels: labels},
+		Data:       map[string][]byte{},
+	}
+	if opts.Auth {
+		r.Password = rand.Text()
+		hash, err := bcrypt.GenerateFromPassword([]byte(r.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data["htpasswd"] = []byte(Username + ":" + string(hash) + "\n")
+	}
+	if opts.TLS {
+		if r.CA, err = pki.NewCA(Name + "." + namespace + " CA"); err != nil {
+			return nil, err
+		}
+		cert, err := r.CA.NewServer(Name, append(r.hostnames(), created.Spec.ClusterIP, "localhost", "127.0.0.1")...)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data["tls.crt"] = cert.CertPEM()
+		secret.Data["tls.key"] = cert.KeyPEM()
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create secret %s in namespace %s: %v", Name, namespace, err)
+	}
+	if _, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Create(c.Ctx, r.deployment(labels, opts), metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create deployment %s in namespace %s: %v", Name, namespace, err)
+	}
+	if err := k8s.WaitForDeployment(c.Ctx, c.KubeClient.Kube, namespace, Name, 1, config.APIRetry, config.APITimeout); err != nil {
+		return nil, fmt.Errorf("registry in namespace %s is not ready: %v", namespace, err)
+	}
+
+	if opts.Auth {
+		if err := r.createCredentials(c); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("Registry deployed at %s (authentication: %t, TLS: %t)", r.Host, opts.Auth, opts.TLS)
+	return r, nil
+}
+
+// Exposed returns the address of the registry exposed to the tests,
+// <host>:<port>
+func (r *Registry) Exposed(c *clients.Clients) (string, error) {
+	base, err := expose.Current().Expose(c, r.svc)
+	if err != nil {
+		return "", fmt.Errorf("failed to expose the registry: %v", err)
+	}
+	return expose.HostPort(base)
+}
+
+// ServerName is the host the certificate of the registry is verified for
+// when it is reached at its exposed address, which is not one of its hosts
+func (r *Registry) ServerName() string {
+	return Name + "." + r.Namespace + ".svc"
+}
+
+// hostnames are the DNS names of the service of the registry
+func (r *Registry) hostnames() []string {
+	return []string{
+		Name,
+		Name + "." + r.Namespace,
+		Name + "." + r.Namespace + ".svc",
+		Name + "." + r.Namespace + ".svc.cluster.local",
+	}
+}
+
+// deployment returns the deployment of the registry, configured through the
+// environment of the distribution image
+func (r *Registry) deployment(labels map[string]string, opts Options) *appsv1.Deployment {
+	env := []corev1.EnvVar{
+		{Name: "REGISTRY_HTTP_ADDR", Value: ":" + strconv.Itoa(port)},
+		{Name: "REGISTRY_STORAGE_DELETE_ENABLED", Value: "true"},
+		{Name: "REGISTRY_LOG_LEVEL", Value: "warn"},
+	}
+	scheme := corev1.URISchemeHTTP
+	if opts.Auth {
+		env = append(env,
+			corev1.EnvVar{Name: "REGISTRY_AUTH", Value: "htpasswd"},
+			corev1.EnvVar{Name: "REGISTRY_AUTH_HTPASSWD_REALM", Value: Name},
+			corev1.EnvVar{Name: "REGISTRY_AUTH_HTPASSWD_PATH", Value: secretPath + "/htpasswd"},
+		)
+	}
+	if opts.TLS {
+		scheme = corev1.URISchemeHTTPS
+		env = append(env,
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_CERTIFICATE", Value: secretPath + "/tls.crt"},
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_KEY", Value: secretPath + "/tls.key"},
+		)
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: r.Namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "registry",
+						Image: config.Flags.RegistryImage,
+						Env:   env,
+						Ports: []corev1.ContainerPort{{Name: "registry", ContainerPort: port}},
+						ReadinessProbe: &corev1.Probe{
+							// the root answers 200 without authentication
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromString("registry"), Scheme: scheme},
+							},
+							PeriodSeconds: 2,
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "data", MountPath: "/var/lib/registry"},
+							{Name: "secret", MountPath: secretPath, ReadOnly: true},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							// the image has no user, OpenShift runs it with the
+							// random UID of the namespace
+							AllowPrivilegeEscalation: ptr.To(false),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						{Name: "secret", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: Name}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// DockerConfigJSON returns the .dockerconfigjson of the credentials of the
+// registry, for its cluster IP and the DNS names of its service
+func (r *Registry) DockerConfigJSON() ([]byte, error) {
+	type entry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	auth := entry{
+		Username: Username,
+		Password: r.Password,
+		Auth:     base64.StdEncoding.EncodeToString([]byte(Username + ":" + r.Password)),
+	}
+	auths := map[string]entry{r.Host: auth}
+	for _, host := range r.hostnames() {
+		auths[net.JoinHostPort(host, strconv.Itoa(port))] = auth
+	}
+	return json.Marshal(map[string]interface{}{"auths": auths})
+}
+
+// createCredentials creates the secret of the credentials of the registry
+func (r *Registry) createCredentials(c *clients.Clients) error {
+	dockerConfig, err := r.DockerConfigJSON()
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: CredentialsSecret, Namespace: r.Namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig},
+	}
+	if _, err := c.KubeClient.Kube.CoreV1().Secrets(r.Namespace).Create(c.Ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create secret %s in namespace %s: %v", CredentialsSecret, r.Namespace, err)
+	}
+	return nil
+}
+
+// Close stops exposing the registry, which is deleted with its namespace
+func (r *Registry) Close(c *clients.Clients) error {
+	return expose.Current().Unexpose(c, r.svc)
+}
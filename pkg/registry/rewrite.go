@@ -0,0 +1,196 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Mirror makes the registry stand in for the repository, or every repository
+// of the registry, e.g. quay.io/openshift-pipeline/chainstest or
+// image-registry.openshift-image-registry.svc:5000, in the manifests given to
+// Rewrite, and returns its repository on the registry
+func (r *Registry) Mirror(prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	mirror := r.Host
+	if _, path, ok := strings.Cut(prefix, "/"); ok {
+		mirror += "/" + path
+	}
+	r.mirrors[prefix] = mirror
+	return mirror
+}
+
+// Reference returns the reference of the image on the registry if its
+// repository is mirrored, else the reference unchanged
+func (r *Registry) Reference(reference string) string {
+	return r.rewriteReferences(reference)
+}
+
+// Rewrite points the manifests, YAML documents, at the registry:
+//   - the references of the mirrored repositories in any string become their
+//     references on the registry, e.g. the bundle param of the bundles
+//     resolver or the IMAGE param of buildah
+//   - the param lists with such a reference get TLSVERIFY false and
+//     INSECUREREGISTRY true if they have them, the registry serving plain
+//     HTTP or a certificate of its own CA
+//   - bundles resolver refs to the registry get the secret of its credentials
+//     when its authentication is enabled
+func (r *Registry) Rewrite(manifests []byte) ([]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifests)))
+	var out bytes.Buffer
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var obj interface{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			continue
+		}
+		rewritten, err := yaml.Marshal(r.rewrite(obj))
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(rewritten)
+	}
+}
+
+// RewriteFile rewrites the manifests of the file (path relative to the
+// repository) and writes them to dir, returning the path of the rewritten file
+func (r *Registry) RewriteFile(file, dir string) (string, error) {
+	manifests, err := os.ReadFile(config.Path(file))
+	if err != nil {
+		return "", fmt.Errorf("could not load test data from file %s: %v", file, err)
+	}
+	rewritten, err := r.Rewrite(manifests)
+	if err != nil {
+		return "", fmt.Errorf("failed to rewrite %s for the registry: %v", file, err)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "registry-"+filepath.Base(file))
+	return path, os.WriteFile(path, rewritten, 0600)
+}
+
+func (r *Registry) rewrite(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return r.rewriteReferences(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.rewrite(v[i])
+		}
+		return r.rewriteParams(v)
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = r.rewrite(v[key])
+		}
+		if v["resolver"] == "bundles" && r.Password != "" {
+			if params, ok := v["params"].([]interface{}); ok {
+				v["params"] = r.rewriteBundleParams(params)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// rewriteReferences replaces the references of the mirrored repositories in
+// the string
+func (r *Registry) rewriteReferences(value string) string {
+	prefixes := make([]string, 0, len(r.mirrors))
+	for prefix := range r.mirrors {
+		prefixes = append(prefixes, prefix)
+	}
+	// longest first, so a repository mirrored on its own is not taken for
+	// its mirrored registry
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	for _, prefix := range prefixes {
+		ref := regexp.MustCompile(`(^|[^\w.\-/])` + regexp.QuoteMeta(prefix) + `([^\w.\-]|$)`)
+		value = ref.ReplaceAllString(value, "${1}"+r.mirrors[prefix]+"${2}")
+	}
+	return value
+}
+
+// params returns the values of the list if it is a list of params
+func params(list []interface{}) (map[string]string, bool) {
+	values := map[string]string{}
+	for _, p := range list {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := param["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		value, _ := param["value"].(string)
+		values[name] = value
+	}
+	return values, len(values) > 0
+}
+
+// onRegistry tells whether the value refers to an image of the registry
+func (r *Registry) onRegistry(value string) bool {
+	return value == r.Host || strings.HasPrefix(value, r.Host+"/")
+}
+
+// setParams sets the values of the params of the list which have one
+func setParams(list []interface{}, set map[string]string) {
+	for _, p := range list {
+		param := p.(map[string]interface{})
+		if value, ok := set[param["name"].(string)]; ok {
+			param["value"] = value
+		}
+	}
+}
+
+// rewriteParams lets the tasks of the params referring to the registry push
+// to it without verifying its certificate
+func (r *Registry) rewriteParams(list []interface{}) []interface{} {
+	values, ok := params(list)
+	if !ok {
+		return list
+	}
+	for _, value := range values {
+		if r.onRegistry(value) {
+			setParams(list, map[string]string{"TLSVERIFY": "false", "INSECUREREGISTRY": "true"})
+			break
+		}
+	}
+	return list
+}
+
+// rewriteBundleParams gives the secret of the credentials of the registry to
+// a bundles resolver ref to the registry
+func (r *Registry) rewriteBundleParams(list []interface{}) []interface{} {
+	values, ok := params(list)
+	if !ok || !r.onRegistry(values["bundle"]) {
+		return list
+	}
+	if _, ok := values["secret"]; ok {
+		setParams(list, map[string]string{"secret": CredentialsSecret})
+		return list
+	}
+	return append(list, map[string]interface{}{"name": "secret", "value": CredentialsSecret})
+}
@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Collector records failures in memory so that they can be returned as an error,
+// e.g. when helpers are driven from a standalone command instead of a test runner.
+type Collector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+var _ Reporter = (*Collector)(nil)
+
+// failNow is the panic value used by Collector.Fail to unwind the helper up to Collector.Run
+type failNow struct {
+	err error
+}
+
+func (c *Collector) Errorf(format string, args ...interface{}) {
+	c.add(fmt.Errorf(format, args...))
+}
+
+// Fail records err and stops the helper, which must be running under Run
+func (c *Collector) Fail(err error) {
+	c.add(err)
+	panic(failNow{err: err})
+}
+
+func (c *Collector) add(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// Errors returns the failures recorded so far
+func (c *Collector) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error{}, c.errs...)
+}
+
+// Err returns the failures recorded so far joined in a single error, or nil if there are none
+func (c *Collector) Err() error {
+	return errors.Join(c.Errors()...)
+}
+
+// Run executes fn, stopping it at the first Fail, and returns Err.
+// Panics not raised by Fail are propagated.
+func (c *Collector) Run(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(failNow); !ok {
+				panic(r)
+			}
+		}
+		err = c.Err()
+	}()
+	fn()
+	return nil
+}
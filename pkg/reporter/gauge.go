@@ -0,0 +1,18 @@
+package reporter
+
+import (
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+)
+
+// Gauge reports failures to the currently executing Gauge step through testsuit.T
+type Gauge struct{}
+
+var _ Reporter = Gauge{}
+
+func (Gauge) Errorf(format string, args ...interface{}) {
+	testsuit.T.Errorf(format, args...)
+}
+
+func (Gauge) Fail(err error) {
+	testsuit.T.Fail(err)
+}
@@ -0,0 +1,54 @@
+package reporter
+
+import (
+	"context"
+	"sync"
+)
+
+// Reporter receives the assertion failures raised by the helpers in pkg.
+type Reporter interface {
+	// Errorf records a failure, the helper keeps running
+	Errorf(format string, args ...interface{})
+	// Fail records a failure and stops the helper
+	Fail(err error)
+}
+
+type contextKey struct{}
+
+var (
+	mu              sync.RWMutex
+	defaultReporter Reporter = Gauge{}
+)
+
+// SetDefault replaces the Reporter used when none is attached to a context.
+// It returns the previous default so that callers can restore it.
+func SetDefault(r Reporter) Reporter {
+	mu.Lock()
+	defer mu.Unlock()
+	previous := defaultReporter
+	defaultReporter = r
+	return previous
+}
+
+// Default returns the Reporter used when none is attached to a context, Gauge unless changed with SetDefault.
+func Default() Reporter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultReporter
+}
+
+// WithReporter returns a copy of ctx carrying r. Attach it to clients.Clients.Ctx
+// to make every helper called with those clients report to r.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Reporter attached to ctx, or Default if there is none.
+func FromContext(ctx context.Context) Reporter {
+	if ctx != nil {
+		if r, ok := ctx.Value(contextKey{}).(Reporter); ok {
+			return r
+		}
+	}
+	return Default()
+}
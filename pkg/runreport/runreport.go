@@ -0,0 +1,293 @@
+// Package runreport records what happens while the suite runs (scenario and
+// step outcomes, wait durations, command invocations and failure diagnostics)
+// and writes it as JUnit XML and as a JSON summary keyed by test case ID.
+package runreport
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scenario statuses
+const (
+	StatusPassed = "passed"
+	StatusFailed = "failed"
+)
+
+// scenarioIDPattern matches the test case ID ending scenario headings, e.g. PIPELINES-03-TC01
+var scenarioIDPattern = regexp.MustCompile(`PIPELINES-\d+-TC\d+`)
+
+// Scenario is the record of a single scenario execution
+type Scenario struct {
+	ID          string       `json:"id,omitempty"`
+	Name        string       `json:"name"`
+	Spec        string       `json:"spec"`
+	SpecFile    string       `json:"specFile"`
+	Tags        []string     `json:"tags,omitempty"`
+	Status      string       `json:"status"`
+	Started     time.Time    `json:"started"`
+	Duration    Duration     `json:"duration"`
+	Steps       []Step       `json:"steps,omitempty"`
+	Waits       []Timing     `json:"waits,omitempty"`
+	Commands    []Command    `json:"commands,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Step is the record of a single step execution
+type Step struct {
+	Text     string   `json:"text"`
+	Status   string   `json:"status"`
+	Duration Duration `json:"duration"`
+	Error    string   `json:"error,omitempty"`
+
+	started time.Time
+}
+
+// Timing is the duration of a named operation, e.g. a wait for a PipelineRun state
+type Timing struct {
+	Name     string    `json:"name"`
+	Started  time.Time `json:"started"`
+	Duration Duration  `json:"duration"`
+}
+
+// Command is the record of a command executed through pkg/cmd
+type Command struct {
+	Args     []string  `json:"args"`
+	ExitCode int       `json:"exitCode"`
+	Started  time.Time `json:"started"`
+	Duration Duration  `json:"duration"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Diagnostic is additional information collected to investigate a failure,
+// e.g. PipelineRun logs or Warning events
+type Diagnostic struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Duration is a time.Duration serialized as seconds
+type Duration time.Duration
+
+func (d Duration) Seconds() float64 {
+	return time.Duration(d).Seconds()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%.3f", d.Seconds())), nil
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	seconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*d = Duration(math.Round(seconds*1000) * float64(time.Millisecond))
+	return nil
+}
+
+// Recorder collects the records of the scenarios run by a Gauge process.
+// They run one at a time in a process (gauge run -p starting a process per
+// stream), so waits, commands and diagnostics are attached to the scenario
+// in progress and dropped outside of one.
+type Recorder struct {
+	mu        sync.Mutex
+	started   time.Time
+	scenarios []*Scenario
+	current   *Scenario
+	step      *Step
+}
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{started: time.Now()}
+}
+
+var defaultRecorder = NewRecorder()
+
+// Default returns the Recorder used by the suite
+func Default() *Recorder {
+	return defaultRecorder
+}
+
+// BeginScenario starts recording a scenario, its ID is taken from its name
+// and specFile is made relative to the working directory (the project root)
+func (r *Recorder) BeginScenario(spec, specFile, name string, tags []string) {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, specFile); err == nil && !strings.HasPrefix(rel, "..") {
+			specFile = rel
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = &Scenario{
+		ID:       scenarioIDPattern.FindString(name),
+		Name:     name,
+		Spec:     spec,
+		SpecFile: specFile,
+		Tags:     tags,
+		Status:   StatusPassed,
+		Started:  time.Now(),
+	}
+	r.scenarios = append(r.scenarios, r.current)
+}
+
+// EndScenario completes the scenario in progress
+func (r *Recorder) EndScenario(failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	if failed {
+		r.current.Status = StatusFailed
+	}
+	r.current.Duration = Duration(time.Since(r.current.Started))
+	r.current = nil
+	r.step = nil
+}
+
+// BeginStep starts recording a step of the scenario in progress
+func (r *Recorder) BeginStep(text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	r.step = &Step{Text: text, Status: StatusPassed, started: time.Now()}
+}
+
+// EndStep completes the step in progress
+func (r *Recorder) EndStep(failed bool, errorMessage string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil || r.step == nil {
+		return
+	}
+	r.step.Duration = Duration(time.Since(r.step.started))
+	if failed {
+		r.step.Status = StatusFailed
+		r.step.Error = errorMessage
+	}
+	r.current.Steps = append(r.current.Steps, *r.step)
+	r.step = nil
+}
+
+// RecordWait records how long a wait took
+func (r *Recorder) RecordWait(name string, started, ended time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	r.current.Waits = append(r.current.Waits, Timing{Name: name, Started: started, Duration: Duration(ended.Sub(started))})
+}
+
+// RecordCommand records a command invocation, redacting literal values,
+// passwords, tokens and shell scripts which may hold credentials
+func (r *Recorder) RecordCommand(args []string, exitCode int, started time.Time, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	c := Command{Args: redact(args), ExitCode: exitCode, Started: started, Duration: Duration(time.Since(started))}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	r.current.Commands = append(r.current.Commands, c)
+}
+
+// AddDiagnostic attaches diagnostic information to the scenario in progress
+func (r *Recorder) AddDiagnostic(name, content string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	r.current.Diagnostics = append(r.current.Diagnostics, Diagnostic{Name: name, Content: content})
+}
+
+// Scenarios returns a copy of the scenarios recorded so far
+func (r *Recorder) Scenarios() []Scenario {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scenarios := make([]Scenario, 0, len(r.scenarios))
+	for _, s := range r.scenarios {
+		scenarios = append(scenarios, *s)
+	}
+	return scenarios
+}
+
+// shells run the script passed with -c, which may embed credentials
+var shells = []string{"bash", "sh"}
+
+// credentialFlags are the flags whose value is a credential, -p being the
+// password of oc login only (it is the patch of oc patch)
+var credentialFlags = []string{"--password", "--token"}
+
+func redact(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if i > 0 && args[i-1] == "-c" && slices.Contains(shells, filepath.Base(args[0])) {
+			redacted[i] = "<redacted>"
+		}
+		if i > 0 && (slices.Contains(credentialFlags, args[i-1]) || args[i-1] == "-p" && slices.Contains(args, "login")) {
+			redacted[i] = "<redacted>"
+		}
+		if flag, _, ok := strings.Cut(arg, "="); ok && slices.Contains(credentialFlags, flag) {
+			redacted[i] = flag + "=<redacted>"
+		}
+		if i > 0 && args[i-1] == "--from-literal" {
+			redacted[i] = redactValue(arg)
+		}
+		if strings.HasPrefix(arg, "--from-literal=") {
+			redacted[i] = "--from-literal=" + redactValue(strings.TrimPrefix(arg, "--from-literal="))
+		}
+	}
+	return redacted
+}
+
+func redactValue(literal string) string {
+	key, _, _ := strings.Cut(literal, "=")
+	return key + "=<redacted>"
+}
+
+// BeginScenario starts recording a scenario with the default Recorder
+func BeginScenario(spec, specFile, name string, tags []string) {
+	defaultRecorder.BeginScenario(spec, specFile, name, tags)
+}
+
+// EndScenario completes the scenario in progress of the default Recorder
+func EndScenario(failed bool) {
+	defaultRecorder.EndScenario(failed)
+}
+
+// BeginStep starts recording a step with the default Recorder
+func BeginStep(text string) {
+	defaultRecorder.BeginStep(text)
+}
+
+// EndStep completes the step in progress of the default Recorder
+func EndStep(failed bool, errorMessage string) {
+	defaultRecorder.EndStep(failed, errorMessage)
+}
+
+// RecordCommand records a command invocation with the default Recorder
+func RecordCommand(args []string, exitCode int, started time.Time, err error) {
+	defaultRecorder.RecordCommand(args, exitCode, started, err)
+}
+
+// AddDiagnostic attaches diagnostic information to the scenario in progress of the default Recorder
+func AddDiagnostic(name, content string) {
+	defaultRecorder.AddDiagnostic(name, content)
+}
@@ -0,0 +1,53 @@
+package runreport
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{{
+		name: "literal flag and value",
+		args: []string{"oc", "create", "secret", "generic", "s", "--from-literal", "token=abc"},
+		want: []string{"oc", "create", "secret", "generic", "s", "--from-literal", "token=<redacted>"},
+	}, {
+		name: "literal flag with value",
+		args: []string{"oc", "create", "secret", "generic", "s", "--from-literal=token=abc"},
+		want: []string{"oc", "create", "secret", "generic", "s", "--from-literal=token=<redacted>"},
+	}, {
+		name: "shell script",
+		args: []string{"bash", "-c", "echo '{\"data\":{\"token\":\"abc\"}}' | kubectl apply -f -"},
+		want: []string{"bash", "-c", "<redacted>"},
+	}, {
+		name: "shell path",
+		args: []string{"/bin/sh", "-c", "echo abc"},
+		want: []string{"/bin/sh", "-c", "<redacted>"},
+	}, {
+		name: "login password",
+		args: []string{"oc", "login", "https://api:6443", "-u", "user", "-p", "secret"},
+		want: []string{"oc", "login", "https://api:6443", "-u", "user", "-p", "<redacted>"},
+	}, {
+		name: "token",
+		args: []string{"oc", "login", "--token=secret", "--server", "https://api:6443"},
+		want: []string{"oc", "login", "--token=<redacted>", "--server", "https://api:6443"},
+	}, {
+		name: "patch",
+		args: []string{"oc", "patch", "tektonconfig", "config", "-p", `{"spec":{}}`, "--type=merge"},
+		want: []string{"oc", "patch", "tektonconfig", "config", "-p", `{"spec":{}}`, "--type=merge"},
+	}, {
+		name: "other commands",
+		args: []string{"oc", "get", "secret", "s", "-o", "json"},
+		want: []string{"oc", "get", "secret", "s", "-o", "json"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redact(tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("redact(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
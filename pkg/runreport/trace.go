@@ -0,0 +1,61 @@
+package runreport
+
+import (
+	"strings"
+	"sync"
+
+	"go.opencensus.io/trace"
+)
+
+// emitableSpanPrefix is prepended to span names by knative.dev/pkg/test/logging.GetEmitableSpan
+const emitableSpanPrefix = "emitspan-"
+
+// waitSpanPrefixes are the prefixes of the spans started by the wait helpers
+// of pkg/wait and pkg/operator, e.g. WaitForPipelineRunState/<name>/<desc>
+var waitSpanPrefixes = []string{
+	"WaitForTaskRunState/",
+	"WaitForPipelineRunState/",
+	"WaitForDeploymentState/",
+	"WaitForPodState/",
+	"WaitForServiceExternalIPState/",
+	"WaitForEventListenerReady/",
+	"WaitForTektonConfigState/",
+	"WaitForTektonPipelineState/",
+	"WaitForTektonTriggerState/",
+	"WaitForTektonAddonState/",
+}
+
+// spanExporter records the spans started by the wait helpers as wait
+// durations, other spans (e.g. those of client libraries) are ignored
+type spanExporter struct {
+	r *Recorder
+}
+
+func (e spanExporter) ExportSpan(s *trace.SpanData) {
+	if name, ok := waitSpanName(s.Name); ok {
+		e.r.RecordWait(name, s.StartTime, s.EndTime)
+	}
+}
+
+// waitSpanName returns the name of the span without the prefix added to
+// emitable spans, and whether it was started by a wait helper
+func waitSpanName(span string) (string, bool) {
+	name := strings.TrimPrefix(span, emitableSpanPrefix)
+	for _, prefix := range waitSpanPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+var registerOnce sync.Once
+
+// RegisterTraceExporter makes every opencensus span of a wait helper ended
+// from now on recorded as a wait duration by the default Recorder
+func RegisterTraceExporter() {
+	registerOnce.Do(func() {
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+		trace.RegisterExporter(spanExporter{r: defaultRecorder})
+	})
+}
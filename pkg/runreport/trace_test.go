@@ -0,0 +1,25 @@
+package runreport
+
+import "testing"
+
+func TestWaitSpanName(t *testing.T) {
+	tests := []struct {
+		span string
+		want string
+		ok   bool
+	}{
+		{span: "WaitForPipelineRunState/pr/PipelineRunCompleted", want: "WaitForPipelineRunState/pr/PipelineRunCompleted", ok: true},
+		{span: "WaitForEventListenerReady/listener", want: "WaitForEventListenerReady/listener", ok: true},
+		{span: "emitspan-WaitForTektonConfigState/config/TektonConfigIsReady", want: "WaitForTektonConfigState/config/TektonConfigIsReady", ok: true},
+		{span: "HTTP GET /apis/tekton.dev/v1/pipelineruns"},
+		{span: "emitspan-other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.span, func(t *testing.T) {
+			got, ok := waitSpanName(tt.span)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("waitSpanName(%q) = %q, %v, want %q, %v", tt.span, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package runreport
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// JUnitFile is the name of the JUnit XML report written by Write
+	JUnitFile = "junit.xml"
+	// SummaryFile is the name of the JSON summary written by Write
+	SummaryFile = "summary.json"
+	// PartsDir is the directory of the report directory holding the summary
+	// written by each Gauge process of the run, one per stream of gauge run -p
+	PartsDir = "parts"
+
+	// lockFile exists in the report directory while a process merges the parts
+	lockFile = ".lock"
+	// lockTimeout is the age from which a lock is considered left by a
+	// process which did not complete the merge
+	lockTimeout = time.Minute
+)
+
+// Summary is the JSON summary of a run, scenarios are keyed by their test
+// case ID (or by spec file and name when the heading has none)
+type Summary struct {
+	Started   time.Time           `json:"started"`
+	Duration  Duration            `json:"duration"`
+	Total     int                 `json:"total"`
+	Passed    int                 `json:"passed"`
+	Failed    int                 `json:"failed"`
+	Scenarios map[string]Scenario `json:"scenarios"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	File      string          `xml:"file,attr,omitempty"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// Write writes the summary of the scenarios recorded by this process to
+// dir/parts, then merges the summaries of the processes of the run in the
+// JUnit XML report and the JSON summary of dir, creating it if needed. The
+// parts of previous runs, which ended before this process started, are removed.
+func (r *Recorder) Write(dir string) error {
+	parts := filepath.Join(dir, PartsDir)
+	if err := os.MkdirAll(parts, 0750); err != nil {
+		return fmt.Errorf("failed to create report directory %s: %v", parts, err)
+	}
+	part, err := json.MarshalIndent(summarize(r.started, time.Now(), r.Scenarios()), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(parts, fmt.Sprintf("summary-%d-%d.json", os.Getpid(), r.started.UnixNano())), part); err != nil {
+		return err
+	}
+
+	unlock, err := lock(filepath.Join(dir, lockFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	started, ended, scenarios, err := readParts(parts, r.started)
+	if err != nil {
+		return err
+	}
+
+	summary, err := json.MarshalIndent(summarize(started, ended, scenarios), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(dir, SummaryFile), summary); err != nil {
+		return err
+	}
+
+	junit, err := xml.MarshalIndent(junitReport(ended.Sub(started), scenarios), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, JUnitFile), append([]byte(xml.Header), junit...))
+}
+
+// Write writes the reports of the default Recorder to dir
+func Write(dir string) error {
+	return defaultRecorder.Write(dir)
+}
+
+// readParts returns the time span and the scenarios, ordered by start, of
+// the parts of the run in dir, removing the parts which ended before started
+func readParts(dir string, started time.Time) (time.Time, time.Time, []Scenario, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "summary-*.json"))
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	first, last := started, started
+	var scenarios []Scenario
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, err
+		}
+		var part Summary
+		if err := json.Unmarshal(content, &part); err != nil {
+			return time.Time{}, time.Time{}, nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		ended := part.Started.Add(time.Duration(part.Duration))
+		// durations are written in milliseconds
+		if ended.Before(started.Add(-time.Second)) {
+			if err := os.Remove(path); err != nil {
+				return time.Time{}, time.Time{}, nil, err
+			}
+			continue
+		}
+		if part.Started.Before(first) {
+			first = part.Started
+		}
+		if ended.After(last) {
+			last = ended
+		}
+		for _, scenario := range part.Scenarios {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	slices.SortStableFunc(scenarios, func(a, b Scenario) int {
+		return a.Started.Compare(b.Started)
+	})
+	return first, last, scenarios, nil
+}
+
+// lock creates path, waiting for the process holding it to remove it, and
+// returns the function removing it. A lock older than lockTimeout is removed.
+func lock(path string) (func(), error) {
+	deadline := time.Now().Add(2 * lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to lock %s: %v", path, err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockTimeout {
+			log.Printf("Warning: removing the lock %s left by another process", path)
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s", path)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// writeFile replaces the content of path at once, so that it is never read
+// partially written
+func writeFile(path string, content []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func summarize(started, ended time.Time, scenarios []Scenario) Summary {
+	s := Summary{
+		Started:   started,
+		Duration:  Duration(ended.Sub(started)),
+		Total:     len(scenarios),
+		Scenarios: make(map[string]Scenario, len(scenarios)),
+	}
+	for _, scenario := range scenarios {
+		if scenario.Status == StatusFailed {
+			s.Failed++
+		} else {
+			s.Passed++
+		}
+		key := scenario.ID
+		if key == "" {
+			key = scenario.SpecFile + ":" + scenario.Name
+		}
+		// data driven scenarios run once per table row
+		unique := key
+		for i := 2; ; i++ {
+			if _, ok := s.Scenarios[unique]; !ok {
+				break
+			}
+			unique = fmt.Sprintf("%s#%d", key, i)
+		}
+		s.Scenarios[unique] = scenario
+	}
+	return s
+}
+
+func junitReport(duration time.Duration, scenarios []Scenario) junitTestSuites {
+	suites := junitTestSuites{Name: "release-tests", Time: seconds(Duration(duration))}
+	index := map[string]int{}
+	var durations []time.Duration
+	for _, scenario := range scenarios {
+		i, ok := index[scenario.SpecFile]
+		if !ok {
+			i = len(suites.Suites)
+			index[scenario.SpecFile] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{
+				Name:      scenario.Spec,
+				File:      scenario.SpecFile,
+				Timestamp: scenario.Started.Format(time.RFC3339),
+			})
+			durations = append(durations, 0)
+		}
+		suite := &suites.Suites[i]
+
+		tc := junitTestCase{
+			Name:      scenario.Name,
+			Classname: scenario.SpecFile,
+			Time:      seconds(scenario.Duration),
+			SystemOut: scenarioOutput(scenario),
+		}
+		if scenario.Status == StatusFailed {
+			tc.Failure = scenarioFailure(scenario)
+			suite.Failures++
+			suites.Failures++
+		}
+		var diagnostics []string
+		for _, d := range scenario.Diagnostics {
+			diagnostics = append(diagnostics, fmt.Sprintf("=== %s\n%s", d.Name, d.Content))
+		}
+		tc.SystemErr = strings.Join(diagnostics, "\n")
+
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		durations[i] += time.Duration(scenario.Duration)
+		suite.Time = seconds(Duration(durations[i]))
+		suites.Tests++
+	}
+	return suites
+}
+
+func scenarioFailure(s Scenario) *junitFailure {
+	failure := &junitFailure{Message: "scenario failed"}
+	var errs []string
+	for _, step := range s.Steps {
+		if step.Status != StatusFailed {
+			continue
+		}
+		if len(errs) == 0 {
+			failure.Message, _, _ = strings.Cut(step.Error, "\n")
+		}
+		errs = append(errs, fmt.Sprintf("%s\n%s", step.Text, step.Error))
+	}
+	failure.Content = strings.Join(errs, "\n\n")
+	return failure
+}
+
+func scenarioOutput(s Scenario) string {
+	var b strings.Builder
+	for _, step := range s.Steps {
+		fmt.Fprintf(&b, "step %s %ss %s\n", step.Status, seconds(step.Duration), step.Text)
+	}
+	for _, w := range s.Waits {
+		fmt.Fprintf(&b, "wait %ss %s\n", seconds(w.Duration), w.Name)
+	}
+	for _, c := range s.Commands {
+		fmt.Fprintf(&b, "command exit=%d %ss %s\n", c.ExitCode, seconds(c.Duration), strings.Join(c.Args, " "))
+	}
+	return b.String()
+}
+
+func seconds(d Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,99 @@
+package runreport
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteMergesParts(t *testing.T) {
+	dir := t.TempDir()
+	parts := filepath.Join(dir, PartsDir)
+	if err := os.MkdirAll(parts, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// the part of a previous run
+	stale := filepath.Join(parts, "summary-1-1.json")
+	previous, _ := json.Marshal(summarize(time.Now().Add(-time.Hour), time.Now().Add(-time.Minute), []Scenario{{ID: "PIPELINES-01-TC01"}}))
+	if err := os.WriteFile(stale, previous, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the recorders of the processes running the streams of gauge run -p
+	streams := []struct {
+		specFile  string
+		scenarios []string
+		failed    bool
+	}{
+		{specFile: "specs/a.spec", scenarios: []string{"First: PIPELINES-02-TC01", "Second: PIPELINES-02-TC02"}},
+		{specFile: "specs/b.spec", scenarios: []string{"Third: PIPELINES-03-TC01"}, failed: true},
+		{specFile: "specs/c.spec", scenarios: []string{"Fourth"}},
+	}
+	var recorders []*Recorder
+	for _, s := range streams {
+		r := NewRecorder()
+		for _, name := range s.scenarios {
+			r.BeginScenario("spec", s.specFile, name, nil)
+			r.BeginStep("step")
+			r.EndStep(s.failed, "")
+			r.EndScenario(s.failed)
+		}
+		recorders = append(recorders, r)
+	}
+	var wg sync.WaitGroup
+	for _, r := range recorders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.Write(dir); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	content, err := os.ReadFile(filepath.Join(dir, SummaryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary Summary
+	if err := json.Unmarshal(content, &summary); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for key := range summary.Scenarios {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	want := []string{"PIPELINES-02-TC01", "PIPELINES-02-TC02", "PIPELINES-03-TC01", "specs/c.spec:Fourth"}
+	if !slices.Equal(keys, want) {
+		t.Errorf("expected the scenarios %q in %s, got %q", want, SummaryFile, keys)
+	}
+	if summary.Total != 4 || summary.Passed != 3 || summary.Failed != 1 {
+		t.Errorf("expected 4 scenarios, 3 passed and 1 failed, got %d, %d and %d", summary.Total, summary.Passed, summary.Failed)
+	}
+
+	content, err = os.ReadFile(filepath.Join(dir, JUnitFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var junit junitTestSuites
+	if err := xml.Unmarshal(content, &junit); err != nil {
+		t.Fatal(err)
+	}
+	if junit.Tests != 4 || junit.Failures != 1 || len(junit.Suites) != 3 {
+		t.Errorf("expected 4 test cases, 1 failure and 3 suites in %s, got %d, %d and %d", JUnitFile, junit.Tests, junit.Failures, len(junit.Suites))
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected the part of the previous run to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, lockFile)); !os.IsNotExist(err) {
+		t.Errorf("expected the lock to be removed, got %v", err)
+	}
+}
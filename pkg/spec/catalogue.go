@@ -0,0 +1,297 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importanceLevels are the values of the Importance metadata, most important first
+var importanceLevels = []string{"Critical", "High", "Medium", "Low"}
+
+// noComponent groups the scenarios without Component metadata
+const noComponent = "(none)"
+
+// Entry is a scenario of the catalogue
+type Entry struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Spec             string   `json:"spec"`
+	Line             int      `json:"line"`
+	Tags             []string `json:"tags"`
+	Component        string   `json:"component"`
+	Level            string   `json:"level"`
+	Type             string   `json:"type"`
+	Importance       string   `json:"importance"`
+	CustomerScenario bool     `json:"customerScenario"`
+}
+
+// Location returns the position of the scenario as spec:line
+func (e Entry) Location() string {
+	return fmt.Sprintf("%s:%d", e.Spec, e.Line)
+}
+
+// Catalogue lists the scenarios of the specs with their metadata
+type Catalogue struct {
+	Entries []Entry
+	// specs are the spec files by the number of their header
+	specs map[string]string
+}
+
+// NewCatalogue parses the specs under root/specs into a catalogue sorted by test case ID
+func NewCatalogue(root string) (*Catalogue, error) {
+	specFiles, _, err := Files(filepath.Join(root, "specs"))
+	if err != nil {
+		return nil, err
+	}
+	c := &Catalogue{specs: map[string]string{}}
+	for _, path := range specFiles {
+		s, err := ParseSpec(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spec %s: %v", path, err)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := c.specs[s.Number]; s.Number != "" && !ok {
+			c.specs[s.Number] = filepath.ToSlash(rel)
+		}
+		for _, scenario := range s.Scenarios {
+			c.Entries = append(c.Entries, newEntry(filepath.ToSlash(rel), scenario))
+		}
+	}
+	sort.SliceStable(c.Entries, func(i, j int) bool {
+		a, b := c.Entries[i], c.Entries[j]
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Location() < b.Location()
+	})
+	return c, nil
+}
+
+func newEntry(spec string, s Scenario) Entry {
+	title := s.Heading
+	if s.ID != "" {
+		title = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(strings.TrimSuffix(title, s.ID)), ":"))
+	}
+	component := s.Metadata["Component"]
+	if component == "" {
+		component = noComponent
+	}
+	customer := false
+	switch strings.ToLower(s.Metadata["CustomerScenario"]) {
+	case "yes", "true":
+		customer = true
+	}
+	return Entry{
+		ID:               s.ID,
+		Title:            title,
+		Spec:             spec,
+		Line:             s.Line,
+		Tags:             s.Tags,
+		Component:        component,
+		Level:            s.Metadata["Level"],
+		Type:             s.Metadata["Type"],
+		Importance:       s.Metadata["Importance"],
+		CustomerScenario: customer,
+	}
+}
+
+// TCSpecMap returns the map of spec numbers (e.g. PIPELINES-03) to the spec
+// numbered so, or holding their scenarios for the specs without a number
+func (c *Catalogue) TCSpecMap() map[string]string {
+	m := map[string]string{}
+	for number, spec := range c.specs {
+		m[number] = spec
+	}
+	for _, e := range c.Entries {
+		if number := SpecNumber(e.ID); number != "" {
+			if _, ok := m[number]; !ok {
+				m[number] = e.Spec
+			}
+		}
+	}
+	return m
+}
+
+// WriteTCSpecMap writes m to path in the format of tc_spec_map.json
+func WriteTCSpecMap(path string, m map[string]string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// WriteJSON writes the entries of the catalogue as a JSON array
+func (c *Catalogue) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Entries)
+}
+
+// WriteCSV writes one row per scenario of the catalogue
+func (c *Catalogue) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"ID", "Title", "Location", "Component", "Level", "Type", "Importance", "CustomerScenario", "Tags"})
+	for _, e := range c.Entries {
+		cw.Write([]string{e.ID, e.Title, e.Location(), e.Component, e.Level, e.Type, e.Importance,
+			strconv.FormatBool(e.CustomerScenario), strings.Join(e.Tags, ", ")})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ComponentCoverage counts the scenarios of a component
+type ComponentCoverage struct {
+	Component    string
+	Scenarios    int
+	ByImportance map[string]int
+	Customer     int
+}
+
+// Coverage returns the coverage of every component, sorted by name
+func (c *Catalogue) Coverage() []ComponentCoverage {
+	byComponent := map[string]*ComponentCoverage{}
+	for _, e := range c.Entries {
+		cov, ok := byComponent[e.Component]
+		if !ok {
+			cov = &ComponentCoverage{Component: e.Component, ByImportance: map[string]int{}}
+			byComponent[e.Component] = cov
+		}
+		cov.Scenarios++
+		cov.ByImportance[e.Importance]++
+		if e.CustomerScenario {
+			cov.Customer++
+		}
+	}
+	coverage := make([]ComponentCoverage, 0, len(byComponent))
+	for _, cov := range byComponent {
+		coverage = append(coverage, *cov)
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].Component < coverage[j].Component
+	})
+	return coverage
+}
+
+// importances returns importanceLevels followed by the other values of the
+// Importance metadata found in the catalogue ("" when missing)
+func (c *Catalogue) importances() []string {
+	known := map[string]bool{}
+	for _, level := range importanceLevels {
+		known[level] = true
+	}
+	var others []string
+	for _, e := range c.Entries {
+		if !known[e.Importance] {
+			known[e.Importance] = true
+			others = append(others, e.Importance)
+		}
+	}
+	sort.Strings(others)
+	return append(append([]string{}, importanceLevels...), others...)
+}
+
+func importanceHeader(importance string) string {
+	if importance == "" {
+		return "No importance"
+	}
+	return importance
+}
+
+// WriteCoverageCSV writes the coverage matrix: one row per component with its
+// number of scenarios per importance and of customer scenarios
+func (c *Catalogue) WriteCoverageCSV(w io.Writer) error {
+	importances := c.importances()
+	cw := csv.NewWriter(w)
+	header := []string{"Component", "Scenarios"}
+	for _, importance := range importances {
+		header = append(header, importanceHeader(importance))
+	}
+	cw.Write(append(header, "CustomerScenarios"))
+	for _, cov := range c.Coverage() {
+		row := []string{cov.Component, strconv.Itoa(cov.Scenarios)}
+		for _, importance := range importances {
+			row = append(row, strconv.Itoa(cov.ByImportance[importance]))
+		}
+		cw.Write(append(row, strconv.Itoa(cov.Customer)))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCoverageMarkdown writes the coverage matrix, the components lacking
+// Critical or customer scenarios and the scenarios of every component
+func (c *Catalogue) WriteCoverageMarkdown(w io.Writer) error {
+	importances := c.importances()
+	coverage := c.Coverage()
+	var b strings.Builder
+
+	b.WriteString("# Test coverage per component\n\n")
+	b.WriteString("| Component | Scenarios |")
+	for _, importance := range importances {
+		fmt.Fprintf(&b, " %s |", importanceHeader(importance))
+	}
+	b.WriteString(" Customer scenarios |\n|---|---|")
+	b.WriteString(strings.Repeat("---|", len(importances)))
+	b.WriteString("---|\n")
+	var noCritical, noCustomer []string
+	for _, cov := range coverage {
+		fmt.Fprintf(&b, "| %s | %d |", markdownCell(cov.Component), cov.Scenarios)
+		for _, importance := range importances {
+			fmt.Fprintf(&b, " %d |", cov.ByImportance[importance])
+		}
+		fmt.Fprintf(&b, " %d |\n", cov.Customer)
+		if cov.ByImportance["Critical"] == 0 {
+			noCritical = append(noCritical, cov.Component)
+		}
+		if cov.Customer == 0 {
+			noCustomer = append(noCustomer, cov.Component)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nComponents without Critical scenarios: %s\n", listOrNone(noCritical))
+	fmt.Fprintf(&b, "\nComponents without customer scenarios: %s\n", listOrNone(noCustomer))
+
+	for _, cov := range coverage {
+		fmt.Fprintf(&b, "\n## %s\n\n", cov.Component)
+		b.WriteString("| ID | Title | Importance | Customer scenario | Location |\n|---|---|---|---|---|\n")
+		for _, e := range c.Entries {
+			if e.Component != cov.Component {
+				continue
+			}
+			customer := ""
+			if e.CustomerScenario {
+				customer = "yes"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", e.ID, markdownCell(e.Title), e.Importance, customer, e.Location())
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
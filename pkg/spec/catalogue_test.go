@@ -0,0 +1,274 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// catalogueSpecs are the specs of the catalogue tests, by path relative to the repository
+var catalogueSpecs = map[string]string{
+	"specs/pipelines/run.spec": `PIPELINES-03
+# Run pipelines
+
+## Run a pipeline: PIPELINES-03-TC01
+Tags: e2e, sanity
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: Critical
+CustomerScenario: yes
+
+Steps:
+  * Run
+
+## Run a failing pipeline: PIPELINES-03-TC-02
+Component: Pipelines
+Importance: Medium
+CustomerScenario: no
+`,
+	"specs/triggers/cron.spec": `PIPELINES-04
+# Cron
+
+## Trigger | schedule: PIPELINES-04-TC01
+Component: Triggers
+Importance: Low
+CustomerScenario: True
+
+## Not catalogued yet
+`,
+	"specs/operator/roles.spec": `PIPELINES-34
+# Roles
+
+## Verify roles: PIPELINES-03-TC03
+Component: Operator
+Importance: Blocker
+`,
+	"specs/pipelines/hub.spec": `# Hub resolvers
+
+## Hub resolver: PIPELINES-23-TC01
+Component: Resolvers
+Importance: High
+`,
+	"specs/concepts/run.cpt": `# Run
+* Sleep
+`,
+}
+
+func newTestCatalogue(t *testing.T) *Catalogue {
+	t.Helper()
+	root := t.TempDir()
+	writeFiles(t, root, catalogueSpecs)
+	c, err := NewCatalogue(root)
+	if err != nil {
+		t.Fatalf("NewCatalogue() failed: %v", err)
+	}
+	return c
+}
+
+func TestNewCatalogue(t *testing.T) {
+	c := newTestCatalogue(t)
+	want := []Entry{{
+		Title:     "Not catalogued yet",
+		Spec:      "specs/triggers/cron.spec",
+		Line:      9,
+		Component: noComponent,
+	}, {
+		ID:               "PIPELINES-03-TC-02",
+		Title:            "Run a failing pipeline",
+		Spec:             "specs/pipelines/run.spec",
+		Line:             15,
+		Component:        "Pipelines",
+		Importance:       "Medium",
+		CustomerScenario: false,
+	}, {
+		ID:               "PIPELINES-03-TC01",
+		Title:            "Run a pipeline",
+		Spec:             "specs/pipelines/run.spec",
+		Line:             4,
+		Tags:             []string{"e2e", "sanity"},
+		Component:        "Pipelines",
+		Level:            "Integration",
+		Type:             "Functional",
+		Importance:       "Critical",
+		CustomerScenario: true,
+	}, {
+		ID:         "PIPELINES-03-TC03",
+		Title:      "Verify roles",
+		Spec:       "specs/operator/roles.spec",
+		Line:       4,
+		Component:  "Operator",
+		Importance: "Blocker",
+	}, {
+		ID:               "PIPELINES-04-TC01",
+		Title:            "Trigger | schedule",
+		Spec:             "specs/triggers/cron.spec",
+		Line:             4,
+		Component:        "Triggers",
+		Importance:       "Low",
+		CustomerScenario: true,
+	}, {
+		ID:         "PIPELINES-23-TC01",
+		Title:      "Hub resolver",
+		Spec:       "specs/pipelines/hub.spec",
+		Line:       3,
+		Component:  "Resolvers",
+		Importance: "High",
+	}}
+	if !reflect.DeepEqual(c.Entries, want) {
+		t.Errorf("Entries =\n%+v\nwant\n%+v", c.Entries, want)
+	}
+}
+
+func TestCatalogueTCSpecMap(t *testing.T) {
+	want := map[string]string{
+		"PIPELINES-03": "specs/pipelines/run.spec",
+		"PIPELINES-04": "specs/triggers/cron.spec",
+		"PIPELINES-23": "specs/pipelines/hub.spec",
+		"PIPELINES-34": "specs/operator/roles.spec",
+	}
+	if got := newTestCatalogue(t).TCSpecMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TCSpecMap() = %v, want %v", got, want)
+	}
+}
+
+func TestCatalogueCoverage(t *testing.T) {
+	want := []ComponentCoverage{
+		{Component: noComponent, Scenarios: 1, ByImportance: map[string]int{"": 1}},
+		{Component: "Operator", Scenarios: 1, ByImportance: map[string]int{"Blocker": 1}},
+		{Component: "Pipelines", Scenarios: 2, ByImportance: map[string]int{"Critical": 1, "Medium": 1}, Customer: 1},
+		{Component: "Resolvers", Scenarios: 1, ByImportance: map[string]int{"High": 1}},
+		{Component: "Triggers", Scenarios: 1, ByImportance: map[string]int{"Low": 1}, Customer: 1},
+	}
+	if got := newTestCatalogue(t).Coverage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Coverage() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCatalogueWrite(t *testing.T) {
+	c := newTestCatalogue(t)
+	tests := []struct {
+		name  string
+		write func(io.Writer) error
+		want  string
+	}{{
+		name:  "catalogue.csv",
+		write: c.WriteCSV,
+		want: `ID,Title,Location,Component,Level,Type,Importance,CustomerScenario,Tags
+,Not catalogued yet,specs/triggers/cron.spec:9,(none),,,,false,
+PIPELINES-03-TC-02,Run a failing pipeline,specs/pipelines/run.spec:15,Pipelines,,,Medium,false,
+PIPELINES-03-TC01,Run a pipeline,specs/pipelines/run.spec:4,Pipelines,Integration,Functional,Critical,true,"e2e, sanity"
+PIPELINES-03-TC03,Verify roles,specs/operator/roles.spec:4,Operator,,,Blocker,false,
+PIPELINES-04-TC01,Trigger | schedule,specs/triggers/cron.spec:4,Triggers,,,Low,true,
+PIPELINES-23-TC01,Hub resolver,specs/pipelines/hub.spec:3,Resolvers,,,High,false,
+`,
+	}, {
+		name:  "coverage.csv",
+		write: c.WriteCoverageCSV,
+		want: `Component,Scenarios,Critical,High,Medium,Low,No importance,Blocker,CustomerScenarios
+(none),1,0,0,0,0,1,0,0
+Operator,1,0,0,0,0,0,1,0
+Pipelines,2,1,0,1,0,0,0,1
+Resolvers,1,0,1,0,0,0,0,0
+Triggers,1,0,0,0,1,0,0,1
+`,
+	}, {
+		name:  "coverage.md",
+		write: c.WriteCoverageMarkdown,
+		want: `# Test coverage per component
+
+| Component | Scenarios | Critical | High | Medium | Low | No importance | Blocker | Customer scenarios |
+|---|---|---|---|---|---|---|---|---|
+| (none) | 1 | 0 | 0 | 0 | 0 | 1 | 0 | 0 |
+| Operator | 1 | 0 | 0 | 0 | 0 | 0 | 1 | 0 |
+| Pipelines | 2 | 1 | 0 | 1 | 0 | 0 | 0 | 1 |
+| Resolvers | 1 | 0 | 1 | 0 | 0 | 0 | 0 | 0 |
+| Triggers | 1 | 0 | 0 | 0 | 1 | 0 | 0 | 1 |
+
+Components without Critical scenarios: (none), Operator, Resolvers, Triggers
+
+Components without customer scenarios: (none), Operator, Resolvers
+
+## (none)
+
+| ID | Title | Importance | Customer scenario | Location |
+|---|---|---|---|---|
+|  | Not catalogued yet |  |  | specs/triggers/cron.spec:9 |
+
+## Operator
+
+| ID | Title | Importance | Customer scenario | Location |
+|---|---|---|---|---|
+| PIPELINES-03-TC03 | Verify roles | Blocker |  | specs/operator/roles.spec:4 |
+
+## Pipelines
+
+| ID | Title | Importance | Customer scenario | Location |
+|---|---|---|---|---|
+| PIPELINES-03-TC-02 | Run a failing pipeline | Medium |  | specs/pipelines/run.spec:15 |
+| PIPELINES-03-TC01 | Run a pipeline | Critical | yes | specs/pipelines/run.spec:4 |
+
+## Resolvers
+
+| ID | Title | Importance | Customer scenario | Location |
+|---|---|---|---|---|
+| PIPELINES-23-TC01 | Hub resolver | High |  | specs/pipelines/hub.spec:3 |
+
+## Triggers
+
+| ID | Title | Importance | Customer scenario | Location |
+|---|---|---|---|---|
+| PIPELINES-04-TC01 | Trigger \| schedule | Low | yes | specs/triggers/cron.spec:4 |
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf); err != nil {
+				t.Fatalf("failed to write %s: %v", tt.name, err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("%s =\n%s\nwant\n%s", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogueWriteJSON(t *testing.T) {
+	c := newTestCatalogue(t)
+	var buf bytes.Buffer
+	if err := c.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	var got []Entry
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, c.Entries) {
+		t.Errorf("WriteJSON() = %+v, want %+v", got, c.Entries)
+	}
+}
+
+func TestWriteTCSpecMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TCSpecMapFile)
+	m := map[string]string{"PIPELINES-10": "specs/b.spec", "PIPELINES-02": "specs/a&b.spec"}
+	if err := WriteTCSpecMap(path, m); err != nil {
+		t.Fatalf("WriteTCSpecMap() failed: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+    "PIPELINES-02": "specs/a&b.spec",
+    "PIPELINES-10": "specs/b.spec"
+}
+`
+	if string(got) != want {
+		t.Errorf("WriteTCSpecMap() wrote\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,368 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kinds of lint findings
+const (
+	UnimplementedStep = "unimplemented-step"
+	UnusedStep        = "unused-step"
+	MissingTestdata   = "missing-testdata"
+	StaleTestCase     = "stale-test-case"
+	MissingTestCaseID = "missing-test-case-id"
+	ForeignTestCaseID = "foreign-test-case-id"
+)
+
+// warnings are the kinds of findings which do not break a run of the suite
+var warnings = map[string]bool{
+	UnusedStep:        true,
+	MissingTestCaseID: true,
+	ForeignTestCaseID: true,
+}
+
+// pendingTags mark the specs and scenarios which are not run by the suite
+// (not automated yet or manual only): their steps are not checked
+var pendingTags = map[string]bool{
+	"to-do":      true,
+	"manualonly": true,
+}
+
+// TCSpecMapFile is the map of spec numbers to spec files, relative to the repository
+const TCSpecMapFile = "tc_spec_map.json"
+
+// Finding is a problem found by Lint in File, at Line when it is not 0
+type Finding struct {
+	File    string
+	Line    int
+	Kind    string
+	Message string
+}
+
+// Warning tells whether the finding does not break a run of the suite
+func (f Finding) Warning() bool {
+	return warnings[f.Kind]
+}
+
+func (f Finding) String() string {
+	pos := f.File
+	if f.Line > 0 {
+		pos = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	if f.Warning() {
+		return fmt.Sprintf("%s: warning: %s: %s", pos, f.Kind, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", pos, f.Kind, f.Message)
+}
+
+// Registration is a step implementation registered with gauge.Step
+type Registration struct {
+	Text string
+	File string
+	Line int
+}
+
+// Registrations returns the steps registered with gauge.Step in the Go files under dir
+func Registrations(dir string) ([]Registration, error) {
+	var registrations []Registration
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || !isGaugeStep(call.Fun) || len(call.Args) == 0 {
+				return true
+			}
+			text, ok := stringValue(call.Args[0])
+			if !ok {
+				err = fmt.Errorf("%s: step text is not a string constant", fset.Position(call.Pos()))
+				return false
+			}
+			pos := fset.Position(call.Pos())
+			registrations = append(registrations, Registration{Text: text, File: pos.Filename, Line: pos.Line})
+			return true
+		})
+		return err
+	})
+	return registrations, err
+}
+
+// Lint cross-checks the specs and concepts under root/specs with the steps
+// registered under root/steps, the files under root/testdata and
+// root/tc_spec_map.json. It reports steps without implementation, step
+// implementations used by no spec nor concept, testdata files referenced by
+// steps but missing, and test case IDs out of sync with tc_spec_map.json.
+// The steps of specs and scenarios tagged to-do or manualonly, and of the
+// concepts they use, are not checked.
+func Lint(root string) ([]Finding, error) {
+	specFiles, conceptFiles, err := Files(filepath.Join(root, "specs"))
+	if err != nil {
+		return nil, err
+	}
+	var specs []*Spec
+	for _, path := range specFiles {
+		s, err := ParseSpec(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spec %s: %v", path, err)
+		}
+		specs = append(specs, s)
+	}
+	var concepts []Concept
+	for _, path := range conceptFiles {
+		c, err := ParseConcepts(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse concepts %s: %v", path, err)
+		}
+		concepts = append(concepts, c...)
+	}
+	registrations, err := Registrations(filepath.Join(root, "steps"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read step implementations: %v", err)
+	}
+	tcSpecMap, err := ReadTCSpecMap(filepath.Join(root, TCSpecMapFile))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		root:        root,
+		implemented: map[string]bool{},
+		concepts:    map[string]Concept{},
+		checked:     map[string]bool{},
+		used:        map[string]bool{},
+	}
+	for _, r := range registrations {
+		l.implemented[StepPattern(r.Text)] = true
+	}
+	for _, c := range concepts {
+		l.concepts[c.Pattern()] = c
+		l.use(c.Steps)
+	}
+	for _, s := range specs {
+		l.use(s.Steps)
+		if pending(s.Tags) {
+			for _, scenario := range s.Scenarios {
+				l.use(scenario.Steps)
+			}
+			continue
+		}
+		l.checkSteps(s.Path, s.Steps)
+		for _, scenario := range s.Scenarios {
+			l.use(scenario.Steps)
+			if !pending(scenario.Tags) {
+				l.checkSteps(s.Path, scenario.Steps)
+			}
+		}
+	}
+	for _, r := range registrations {
+		if !l.used[StepPattern(r.Text)] {
+			l.report(l.rel(r.File), r.Line, UnusedStep, "step %q is not used by any spec or concept", r.Text)
+		}
+	}
+	l.checkTestCases(specs, tcSpecMap)
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return l.findings, nil
+}
+
+type linter struct {
+	root        string
+	implemented map[string]bool
+	// concepts by pattern, checkSteps checks the ones used by checked steps
+	concepts map[string]Concept
+	checked  map[string]bool
+	used     map[string]bool
+	findings []Finding
+}
+
+func (l *linter) report(file string, line int, kind, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{File: file, Line: line, Kind: kind, Message: fmt.Sprintf(format, args...)})
+}
+
+// use records the step implementations used by steps
+func (l *linter) use(steps []Step) {
+	for _, step := range steps {
+		l.used[step.Pattern()] = true
+	}
+}
+
+// checkSteps reports the steps of the file at path which are not implemented
+// or reference missing testdata files, checking the concepts they use too
+func (l *linter) checkSteps(path string, steps []Step) {
+	for _, step := range steps {
+		file := l.rel(path)
+		pattern := step.Pattern()
+		if c, ok := l.concepts[pattern]; ok {
+			if !l.checked[pattern] {
+				l.checked[pattern] = true
+				l.checkSteps(c.Path, c.Steps)
+			}
+		} else if !l.implemented[pattern] {
+			l.report(file, step.Line, UnimplementedStep, "no step implementation nor concept matches %q", step.Text)
+		}
+		values := append([]string{}, step.Params...)
+		for _, row := range step.Table {
+			values = append(values, row...)
+		}
+		for _, value := range values {
+			if data, ok := testdataPath(value); ok {
+				if _, err := os.Stat(filepath.Join(l.root, data)); err != nil {
+					l.report(file, step.Line, MissingTestdata, "%s does not exist", data)
+				}
+			}
+		}
+	}
+}
+
+func pending(tags []string) bool {
+	for _, tag := range tags {
+		if pendingTags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTestCases reports the tc_spec_map.json entries whose spec does not
+// exist or is neither numbered after them nor has a scenario with their ID,
+// and the specs whose number is not mapped to them. The scenarios whose ID
+// is not of the number of their spec are reported as warnings.
+func (l *linter) checkTestCases(specs []*Spec, tcSpecMap map[string]string) {
+	numbersBySpec := map[string]map[string]bool{}
+	for _, s := range specs {
+		path := filepath.ToSlash(l.rel(s.Path))
+		file := l.rel(s.Path)
+		numbers := map[string]bool{}
+		numbersBySpec[path] = numbers
+		if s.Number != "" {
+			numbers[s.Number] = true
+			l.checkMapped(file, 0, s.Number, path, tcSpecMap)
+		}
+		for _, scenario := range s.Scenarios {
+			if scenario.ID == "" {
+				l.report(file, scenario.Line, MissingTestCaseID, "scenario %q has no test case ID", scenario.Heading)
+				continue
+			}
+			number := SpecNumber(scenario.ID)
+			switch {
+			case s.Number != "" && number != s.Number:
+				l.report(file, scenario.Line, ForeignTestCaseID, "%s is not a test case of %s", scenario.ID, s.Number)
+			case s.Number == "" && !numbers[number]:
+				numbers[number] = true
+				l.checkMapped(file, scenario.Line, number, path, tcSpecMap)
+			}
+		}
+	}
+
+	numbers := make([]string, 0, len(tcSpecMap))
+	for number := range tcSpecMap {
+		numbers = append(numbers, number)
+	}
+	sort.Strings(numbers)
+	for _, number := range numbers {
+		path := tcSpecMap[number]
+		if _, err := os.Stat(filepath.Join(l.root, path)); err != nil {
+			l.report(TCSpecMapFile, 0, StaleTestCase, "%s is mapped to %s which does not exist", number, path)
+			continue
+		}
+		if !numbersBySpec[path][number] {
+			l.report(TCSpecMapFile, 0, StaleTestCase, "%s is mapped to %s which is neither numbered %s nor has %s-TCxx scenarios", number, path, number, number)
+		}
+	}
+}
+
+// checkMapped reports the spec number of the spec at path which is not
+// mapped to it by tc_spec_map.json
+func (l *linter) checkMapped(file string, line int, number, path string, tcSpecMap map[string]string) {
+	switch mapped, ok := tcSpecMap[number]; {
+	case !ok:
+		l.report(file, line, StaleTestCase, "%s is not in %s", number, TCSpecMapFile)
+	case mapped != path:
+		l.report(file, line, StaleTestCase, "%s is mapped to %s in %s", number, mapped, TCSpecMapFile)
+	}
+}
+
+func (l *linter) rel(path string) string {
+	if rel, err := filepath.Rel(l.root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// testdataPath returns the path relative to the repository of a parameter
+// referencing a file under testdata/
+func testdataPath(value string) (string, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "./")
+	if !strings.HasPrefix(value, "testdata/") || strings.ContainsAny(value, " \t") {
+		return "", false
+	}
+	return value, true
+}
+
+// ReadTCSpecMap reads the map of spec numbers (e.g. PIPELINES-03) to spec files at path
+func ReadTCSpecMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tcSpecMap := map[string]string{}
+	if err := json.Unmarshal(data, &tcSpecMap); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return tcSpecMap, nil
+}
+
+func isGaugeStep(fun ast.Expr) bool {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Step" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "gauge"
+}
+
+// stringValue evaluates a string literal or a concatenation of string literals
+func stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := stringValue(e.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := stringValue(e.Y)
+		return x + y, ok
+	case *ast.ParenExpr:
+		return stringValue(e.X)
+	}
+	return "", false
+}
@@ -0,0 +1,239 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lintRepository returns the files of a repository Lint finds nothing in
+func lintRepository() map[string]string {
+	return map[string]string{
+		"specs/run.spec": `PIPELINES-03
+# Run pipelines
+
+Pre condition:
+  * Validate Operator should be installed
+
+## Run a pipeline: PIPELINES-03-TC01
+Steps:
+  * Create "testdata/pipeline.yaml"
+  * Run and wait
+`,
+		"specs/concepts/run.cpt": `# Run and wait
+* Verify pipelinerun "run" is "successful"
+`,
+		"steps/steps.go": `package steps
+
+import "github.com/getgauge-contrib/gauge-go/gauge"
+
+var _ = gauge.Step("Validate Operator should be installed", func() {})
+
+var _ = gauge.Step("Create <file>", func(file string) {})
+
+var _ = gauge.Step("Verify pipelinerun <name> "+"is <status>", func(name, status string) {})
+`,
+		"steps/steps_test.go":    `package steps`,
+		"testdata/pipeline.yaml": "",
+		TCSpecMapFile:            `{"PIPELINES-03": "specs/run.spec"}`,
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		// files override the ones of lintRepository
+		files  map[string]string
+		remove []string
+		want   []string
+	}{{
+		name: "clean",
+	}, {
+		name: "unimplemented step",
+		files: map[string]string{"specs/concepts/run.cpt": `# Run and wait
+* Wait for pipelinerun "run"
+`},
+		want: []string{
+			`specs/concepts/run.cpt:2: unimplemented-step: no step implementation nor concept matches "Wait for pipelinerun \"run\""`,
+			`steps/steps.go:9: warning: unused-step: step "Verify pipelinerun <name> is <status>" is not used by any spec or concept`,
+		},
+	}, {
+		name:   "missing testdata",
+		remove: []string{"testdata/pipeline.yaml"},
+		want:   []string{"specs/run.spec:9: missing-testdata: testdata/pipeline.yaml does not exist"},
+	}, {
+		name: "pending scenario not checked",
+		files: map[string]string{"specs/todo.spec": `PIPELINES-04
+# Not automated yet
+Tags: to-do
+
+## Todo: PIPELINES-04-TC01
+  * Create "testdata/missing.yaml"
+  * Not implemented
+`},
+		want: []string{"specs/todo.spec: stale-test-case: PIPELINES-04 is not in tc_spec_map.json"},
+	}, {
+		name:  "number mapped to another spec",
+		files: map[string]string{TCSpecMapFile: `{"PIPELINES-03": "specs/other.spec"}`},
+		want: []string{
+			"specs/run.spec: stale-test-case: PIPELINES-03 is mapped to specs/other.spec in tc_spec_map.json",
+			"tc_spec_map.json: stale-test-case: PIPELINES-03 is mapped to specs/other.spec which does not exist",
+		},
+	}, {
+		name:  "number mapped to a spec numbered otherwise",
+		files: map[string]string{TCSpecMapFile: `{"PIPELINES-03": "specs/run.spec", "PIPELINES-05": "specs/run.spec"}`},
+		want:  []string{"tc_spec_map.json: stale-test-case: PIPELINES-05 is mapped to specs/run.spec which is neither numbered PIPELINES-05 nor has PIPELINES-05-TCxx scenarios"},
+	}, {
+		name: "scenario IDs of a spec without number",
+		files: map[string]string{
+			"specs/hub.spec": `# Hub resolvers
+## Hub: PIPELINES-23-TC01
+## Other hub: PIPELINES-23-TC-02
+## Unmapped: PIPELINES-24-TC01
+`,
+			TCSpecMapFile: `{"PIPELINES-03": "specs/run.spec", "PIPELINES-23": "specs/hub.spec"}`,
+		},
+		want: []string{"specs/hub.spec:4: stale-test-case: PIPELINES-24 is not in tc_spec_map.json"},
+	}, {
+		name: "scenario IDs of other specs",
+		files: map[string]string{"specs/roles.spec": `PIPELINES-34
+# Roles
+## Roles: PIPELINES-03-TC02
+## No ID
+`,
+			TCSpecMapFile: `{"PIPELINES-03": "specs/run.spec", "PIPELINES-34": "specs/roles.spec"}`,
+		},
+		want: []string{
+			"specs/roles.spec:3: warning: foreign-test-case-id: PIPELINES-03-TC02 is not a test case of PIPELINES-34",
+			`specs/roles.spec:4: warning: missing-test-case-id: scenario "No ID" has no test case ID`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			files := lintRepository()
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			for _, name := range tt.remove {
+				delete(files, name)
+			}
+			writeFiles(t, root, files)
+
+			findings, err := Lint(root)
+			if err != nil {
+				t.Fatalf("Lint() failed: %v", err)
+			}
+			var got []string
+			for _, f := range findings {
+				got = append(got, filepath.ToSlash(f.String()))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{{
+		name:    "invalid tc_spec_map.json",
+		files:   map[string]string{TCSpecMapFile: `["PIPELINES-03"]`},
+		wantErr: "failed to parse",
+	}, {
+		name: "step text not constant",
+		files: map[string]string{"steps/dynamic.go": `package steps
+
+var _ = gauge.Step(text, func() {})
+`},
+		wantErr: "step text is not a string constant",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			files := lintRepository()
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			writeFiles(t, root, files)
+			if _, err := Lint(root); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Lint() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Lint(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Lint() of a missing repository succeeded")
+	}
+}
+
+func TestRegistrations(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, lintRepository())
+
+	registrations, err := Registrations(filepath.Join(root, "steps"))
+	if err != nil {
+		t.Fatalf("Registrations() failed: %v", err)
+	}
+	path := filepath.Join(root, "steps", "steps.go")
+	want := []Registration{
+		{Text: "Validate Operator should be installed", File: path, Line: 5},
+		{Text: "Create <file>", File: path, Line: 7},
+		{Text: "Verify pipelinerun <name> is <status>", File: path, Line: 9},
+	}
+	if !reflect.DeepEqual(registrations, want) {
+		t.Errorf("Registrations() = %+v, want %+v", registrations, want)
+	}
+}
+
+func TestFinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		finding Finding
+		want    string
+		warning bool
+	}{{
+		name:    "error",
+		finding: Finding{File: "specs/a.spec", Line: 3, Kind: UnimplementedStep, Message: "no step"},
+		want:    "specs/a.spec:3: unimplemented-step: no step",
+	}, {
+		name:    "warning",
+		finding: Finding{File: "specs/a.spec", Line: 3, Kind: ForeignTestCaseID, Message: "other spec"},
+		want:    "specs/a.spec:3: warning: foreign-test-case-id: other spec",
+		warning: true,
+	}, {
+		name:    "no line",
+		finding: Finding{File: TCSpecMapFile, Kind: StaleTestCase, Message: "stale"},
+		want:    "tc_spec_map.json: stale-test-case: stale",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.finding.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := tt.finding.Warning(); got != tt.warning {
+				t.Errorf("Warning() = %v, want %v", got, tt.warning)
+			}
+		})
+	}
+}
+
+func TestReadTCSpecMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TCSpecMapFile)
+	if err := os.WriteFile(path, []byte(`{"PIPELINES-03": "specs/run.spec"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadTCSpecMap(path)
+	if err != nil {
+		t.Fatalf("ReadTCSpecMap() failed: %v", err)
+	}
+	if want := map[string]string{"PIPELINES-03": "specs/run.spec"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTCSpecMap() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,277 @@
+// Package spec parses the Gauge specifications and concepts of the suite
+// without running Gauge, so that they can be checked offline (see Lint) and
+// catalogued for traceability (see NewCatalogue).
+package spec
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// scenarioIDPattern matches the test case ID ending scenario headings, e.g.
+// PIPELINES-03-TC01 or PIPELINES-36-TC-01
+var scenarioIDPattern = regexp.MustCompile(`PIPELINES-(\d+)-TC-?\d+`)
+
+// specNumberPattern matches the line numbering a spec above its heading, e.g. PIPELINES-03
+var specNumberPattern = regexp.MustCompile(`^PIPELINES-\d+$`)
+
+// staticParamPattern matches the "quoted" parameters of a step
+var staticParamPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// metadataPattern matches the metadata lines of a scenario, e.g. "Importance: Critical"
+var metadataPattern = regexp.MustCompile(`^([A-Za-z]+):\s*(\S.*)$`)
+
+// dynamicParamPattern matches the <dynamic> parameters of a step, also used
+// for the parameters of step registrations and concept headings
+var dynamicParamPattern = regexp.MustCompile(`<[^<>]*>`)
+
+// Step is a step of a spec or of a concept
+type Step struct {
+	Text string
+	Line int
+	// Params are the values of the "quoted" parameters
+	Params []string
+	// Table is the table parameter of the step, header row included
+	Table [][]string
+}
+
+// Pattern returns the step text with its parameters replaced by {}, the
+// table parameter included, to be compared with a StepPattern
+func (s Step) Pattern() string {
+	pattern := StepPattern(staticParamPattern.ReplaceAllString(s.Text, "<>"))
+	if s.Table != nil {
+		pattern = strings.TrimSpace(pattern + " {}")
+	}
+	return pattern
+}
+
+// Scenario is a scenario of a spec
+type Scenario struct {
+	Heading string
+	// ID is the test case ID ending the heading, e.g. PIPELINES-03-TC01
+	ID   string
+	Line int
+	Tags []string
+	// Metadata are the "Key: value" lines preceding the steps, e.g. Component
+	// or Importance
+	Metadata map[string]string
+	Steps    []Step
+}
+
+// Spec is a parsed .spec file
+type Spec struct {
+	Path string
+	// Number is the spec number of the line above the heading, e.g.
+	// PIPELINES-03, which its scenario IDs start with but for a few ones
+	// numbered after another spec
+	Number  string
+	Heading string
+	Tags    []string
+	// Steps are the context and teardown steps run around every scenario
+	Steps     []Step
+	Scenarios []Scenario
+}
+
+// Concept is a concept of a .cpt file
+type Concept struct {
+	Path    string
+	Heading string
+	Line    int
+	Steps   []Step
+}
+
+// Pattern returns the concept heading with its parameters replaced by {}
+func (c Concept) Pattern() string {
+	return StepPattern(c.Heading)
+}
+
+// StepPattern replaces the <parameters> of a step text by {} and normalizes
+// its whitespace, e.g. "Create {}" for "Create <table>"
+func StepPattern(text string) string {
+	return strings.Join(strings.Fields(dynamicParamPattern.ReplaceAllString(text, " {} ")), " ")
+}
+
+// SpecNumber returns the spec part of a test case ID, e.g. PIPELINES-03 for PIPELINES-03-TC01
+func SpecNumber(id string) string {
+	if m := scenarioIDPattern.FindStringSubmatch(id); m != nil {
+		return "PIPELINES-" + m[1]
+	}
+	return ""
+}
+
+// ParseSpec parses the .spec file at path
+func ParseSpec(path string) (*Spec, error) {
+	s := &Spec{Path: path}
+	var scenario *Scenario
+	err := parse(path, func(line int, kind lineKind, text string) {
+		switch kind {
+		case specNumber:
+			if s.Heading == "" && s.Number == "" {
+				s.Number = text
+			}
+		case specHeading:
+			s.Heading = text
+		case scenarioHeading:
+			s.Scenarios = append(s.Scenarios, Scenario{Heading: text, ID: scenarioIDPattern.FindString(text), Line: line, Metadata: map[string]string{}})
+			scenario = &s.Scenarios[len(s.Scenarios)-1]
+		case tags:
+			if scenario != nil {
+				scenario.Tags = append(scenario.Tags, text)
+			} else {
+				s.Tags = append(s.Tags, text)
+			}
+		case metadata:
+			if scenario != nil && len(scenario.Steps) == 0 {
+				m := metadataPattern.FindStringSubmatch(text)
+				scenario.Metadata[m[1]] = strings.TrimSpace(m[2])
+			}
+		}
+	}, func(step Step) {
+		if scenario != nil {
+			scenario.Steps = append(scenario.Steps, step)
+		} else {
+			s.Steps = append(s.Steps, step)
+		}
+	}, func(table [][]string) {
+		switch {
+		case scenario != nil && len(scenario.Steps) > 0:
+			scenario.Steps[len(scenario.Steps)-1].Table = table
+		case scenario == nil && len(s.Steps) > 0:
+			s.Steps[len(s.Steps)-1].Table = table
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseConcepts parses the concepts of the .cpt file at path
+func ParseConcepts(path string) ([]Concept, error) {
+	var concepts []Concept
+	err := parse(path, func(line int, kind lineKind, text string) {
+		if kind == specHeading {
+			concepts = append(concepts, Concept{Path: path, Heading: text, Line: line})
+		}
+	}, func(step Step) {
+		if len(concepts) > 0 {
+			concepts[len(concepts)-1].Steps = append(concepts[len(concepts)-1].Steps, step)
+		}
+	}, func(table [][]string) {
+		if c := len(concepts) - 1; c >= 0 && len(concepts[c].Steps) > 0 {
+			concepts[c].Steps[len(concepts[c].Steps)-1].Table = table
+		}
+	})
+	return concepts, err
+}
+
+// Files returns the .spec and .cpt files found under dir
+func Files(dir string) (specs, concepts []string, err error) {
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".spec":
+			specs = append(specs, path)
+		case ".cpt":
+			concepts = append(concepts, path)
+		}
+		return nil
+	})
+	return specs, concepts, err
+}
+
+type lineKind int
+
+const (
+	specNumber lineKind = iota
+	specHeading
+	scenarioHeading
+	tags
+	metadata
+)
+
+// parse reads the Gauge markdown at path, calling onLine for spec numbers,
+// headings, tags and metadata, onStep for every step and onTable for the table following a step
+func parse(path string, onLine func(int, lineKind, string), onStep func(Step), onTable func([][]string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var table [][]string
+	afterStep := false
+	flushTable := func() {
+		if table != nil && afterStep {
+			onTable(table)
+		}
+		table = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "|") {
+			if cells := tableRow(text); !isSeparator(cells) {
+				table = append(table, cells)
+			}
+			continue
+		}
+		flushTable()
+		switch {
+		case strings.HasPrefix(text, "##"):
+			onLine(line, scenarioHeading, strings.TrimSpace(strings.TrimLeft(text, "#")))
+			afterStep = false
+		case strings.HasPrefix(text, "#"):
+			onLine(line, specHeading, strings.TrimSpace(strings.TrimLeft(text, "#")))
+			afterStep = false
+		case specNumberPattern.MatchString(text):
+			onLine(line, specNumber, text)
+			afterStep = false
+		case strings.HasPrefix(text, "* "):
+			stepText := strings.TrimSpace(strings.TrimPrefix(text, "* "))
+			step := Step{Text: stepText, Line: line}
+			for _, m := range staticParamPattern.FindAllStringSubmatch(stepText, -1) {
+				step.Params = append(step.Params, strings.ReplaceAll(m[1], `\"`, `"`))
+			}
+			onStep(step)
+			afterStep = true
+		case strings.HasPrefix(strings.ToLower(text), "tags:"):
+			for _, tag := range strings.Split(text[len("tags:"):], ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					onLine(line, tags, tag)
+				}
+			}
+			afterStep = false
+		case metadataPattern.MatchString(text):
+			onLine(line, metadata, text)
+			afterStep = false
+		case text != "":
+			afterStep = false
+		}
+	}
+	flushTable()
+	return scanner.Err()
+}
+
+func tableRow(text string) []string {
+	cells := strings.Split(strings.Trim(text, "|"), "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+func isSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFiles writes files, by path relative to root, under root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const parsedSpec = `PIPELINES-03
+
+# Run pipelines
+Tags: e2e, pipelines
+
+Pre condition:
+  * Validate Operator should be installed
+
+## Run a pipeline: PIPELINES-03-TC01
+Tags: sanity, admin
+Component: Pipelines
+Importance: Critical
+
+Steps:
+  * Create
+      |S.NO|resource_dir                   |
+      |----|-------------------------------|
+      |1   |testdata/v1/pipeline.yaml      |
+  * Verify pipelinerun "output-pipeline-run" is "successful" with "a \"quoted\" value"
+
+## Run another pipeline: PIPELINES-03-TC-02
+Steps:
+  * Verify pipelinerun "other" is "failed"
+
+## Not numbered
+Tags: to-do
+`
+
+func TestParseSpec(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"run.spec": parsedSpec})
+	path := filepath.Join(root, "run.spec")
+
+	got, err := ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() failed: %v", err)
+	}
+	want := &Spec{
+		Path:    path,
+		Number:  "PIPELINES-03",
+		Heading: "Run pipelines",
+		Tags:    []string{"e2e", "pipelines"},
+		Steps:   []Step{{Text: "Validate Operator should be installed", Line: 7}},
+		Scenarios: []Scenario{{
+			Heading:  "Run a pipeline: PIPELINES-03-TC01",
+			ID:       "PIPELINES-03-TC01",
+			Line:     9,
+			Tags:     []string{"sanity", "admin"},
+			Metadata: map[string]string{"Component": "Pipelines", "Importance": "Critical"},
+			Steps: []Step{{
+				Text:  "Create",
+				Line:  15,
+				Table: [][]string{{"S.NO", "resource_dir"}, {"1", "testdata/v1/pipeline.yaml"}},
+			}, {
+				Text:   `Verify pipelinerun "output-pipeline-run" is "successful" with "a \"quoted\" value"`,
+				Line:   19,
+				Params: []string{"output-pipeline-run", "successful", `a "quoted" value`},
+			}},
+		}, {
+			Heading:  "Run another pipeline: PIPELINES-03-TC-02",
+			ID:       "PIPELINES-03-TC-02",
+			Line:     21,
+			Metadata: map[string]string{},
+			Steps:    []Step{{Text: `Verify pipelinerun "other" is "failed"`, Line: 23, Params: []string{"other", "failed"}}},
+		}, {
+			Heading:  "Not numbered",
+			Line:     25,
+			Tags:     []string{"to-do"},
+			Metadata: map[string]string{},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSpec() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseSpecNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{{
+		name:    "above the heading",
+		content: "PIPELINES-11\n# RBAC\n",
+		want:    "PIPELINES-11",
+	}, {
+		name:    "no number",
+		content: "# Hub resolvers\n## Scenario: PIPELINES-23-TC01\n",
+	}, {
+		name:    "below the heading",
+		content: "# RBAC\nPIPELINES-11\n",
+	}, {
+		name:    "first one",
+		content: "PIPELINES-11\nPIPELINES-12\n# RBAC\n",
+		want:    "PIPELINES-11",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{"a.spec": tt.content})
+			s, err := ParseSpec(filepath.Join(root, "a.spec"))
+			if err != nil {
+				t.Fatalf("ParseSpec() failed: %v", err)
+			}
+			if s.Number != tt.want {
+				t.Errorf("Number = %q, want %q", s.Number, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConcepts(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.cpt": `# Create <pipeline> and wait
+* Create
+  |S.NO|resource_dir|
+  |----|------------|
+  |1   |<pipeline>  |
+* Wait
+
+# Clean up
+* Delete all
+`})
+	path := filepath.Join(root, "a.cpt")
+
+	got, err := ParseConcepts(path)
+	if err != nil {
+		t.Fatalf("ParseConcepts() failed: %v", err)
+	}
+	want := []Concept{{
+		Path:    path,
+		Heading: "Create <pipeline> and wait",
+		Line:    1,
+		Steps: []Step{
+			{Text: "Create", Line: 2, Table: [][]string{{"S.NO", "resource_dir"}, {"1", "<pipeline>"}}},
+			{Text: "Wait", Line: 6},
+		},
+	}, {
+		Path:    path,
+		Heading: "Clean up",
+		Line:    8,
+		Steps:   []Step{{Text: "Delete all", Line: 9}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseConcepts() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got, want := got[0].Pattern(), "Create {} and wait"; got != want {
+		t.Errorf("Pattern() = %q, want %q", got, want)
+	}
+}
+
+func TestStepPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		step Step
+		want string
+	}{
+		{name: "no parameter", step: Step{Text: "Delete all"}, want: "Delete all"},
+		{name: "static parameters", step: Step{Text: `Verify "a" is "b"`}, want: "Verify {} is {}"},
+		{name: "escaped quote", step: Step{Text: `Run "echo \"hi\""`}, want: "Run {}"},
+		{name: "dynamic parameter", step: Step{Text: "Create <pipeline>"}, want: "Create {}"},
+		{name: "table", step: Step{Text: "Create", Table: [][]string{{"S.NO"}}}, want: "Create {}"},
+		{name: "whitespace", step: Step{Text: `Verify   "a"  is  ok `}, want: "Verify {} is ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.Pattern(); got != tt.want {
+				t.Errorf("Pattern() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// the pattern of a registration matches the one of the steps using it
+	if got, want := StepPattern("Verify <name>  is <status>"), (Step{Text: `Verify "a" is "b"`}).Pattern(); got != want {
+		t.Errorf("StepPattern() = %q, want %q", got, want)
+	}
+}
+
+func TestSpecNumber(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "PIPELINES-03-TC01", want: "PIPELINES-03"},
+		{id: "PIPELINES-36-TC-01", want: "PIPELINES-36"},
+		{id: "Run a pipeline: PIPELINES-11-TC02", want: "PIPELINES-11"},
+		{id: "PIPELINES-03"},
+		{id: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := SpecNumber(tt.id); got != tt.want {
+				t.Errorf("SpecNumber(%q) = %q, want %q", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a.spec":        "",
+		"b/c.spec":      "",
+		"b/concept.cpt": "",
+		"b/README.md":   "",
+	})
+
+	specs, concepts, err := Files(root)
+	if err != nil {
+		t.Fatalf("Files() failed: %v", err)
+	}
+	if want := []string{filepath.Join(root, "a.spec"), filepath.Join(root, "b", "c.spec")}; !reflect.DeepEqual(specs, want) {
+		t.Errorf("specs = %v, want %v", specs, want)
+	}
+	if want := []string{filepath.Join(root, "b", "concept.cpt")}; !reflect.DeepEqual(concepts, want) {
+		t.Errorf("concepts = %v, want %v", concepts, want)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 	appsv1 "k8s.io/api/apps/v1"
@@ -28,16 +27,16 @@ func ValidateStatefulSetDeployment(cs *clients.Clients, deploymentName string) {
 	}
 	listOptions := metav1.ListOptions{LabelSelector: labelSelector}
 
-	log.Printf("Starting validation for StatefulSet deployment: %s in namespace: %s", deploymentName, config.TargetNamespace)
+	log.Printf("Starting validation for StatefulSet deployment: %s in namespace: %s", deploymentName, config.TargetNamespace())
 
 	waitErr := wait.PollUntilContextTimeout(context.TODO(), config.APIRetry, config.APITimeout, true, func(ctx context.Context) (bool, error) {
-		stsList, err := cs.KubeClient.Kube.AppsV1().StatefulSets(config.TargetNamespace).List(context.TODO(), listOptions)
+		stsList, err := cs.KubeClient.Kube.AppsV1().StatefulSets(config.TargetNamespace()).List(context.TODO(), listOptions)
 		if err != nil {
 			log.Printf("Error listing StatefulSets: %v", err)
 			return false, fmt.Errorf("failed to list StatefulSets: %v", err)
 		}
 
-		log.Printf("Found %d StatefulSets in namespace %s", len(stsList.Items), config.TargetNamespace)
+		log.Printf("Found %d StatefulSets in namespace %s", len(stsList.Items), config.TargetNamespace())
 
 		for _, sts := range stsList.Items {
 			if sts.Name == deploymentName {
@@ -61,8 +60,8 @@ func ValidateStatefulSetDeployment(cs *clients.Clients, deploymentName string) {
 	})
 
 	if waitErr != nil {
-		testsuit.T.Fatalf("StatefulSet %s was not found or not available within 5 minutes in the namespace %q: %v",
-			deploymentName, config.TargetNamespace, waitErr)
+		cs.Reporter().Fail(fmt.Errorf("StatefulSet %s was not found or not available within 5 minutes in the namespace %q: %v",
+			deploymentName, config.TargetNamespace(), waitErr))
 	}
 }
 
@@ -2,6 +2,7 @@ package store
 
 import (
 	"net/http"
+	"os"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
@@ -13,6 +14,24 @@ func Namespace() string {
 	return gauge.GetScenarioStore()["namespace"].(string)
 }
 
+// TempDir returns the temporary directory of the scenario, removed after it.
+// Files written there cannot clash with the ones of scenarios running in parallel.
+func TempDir() string {
+	switch dir := gauge.GetScenarioStore()["scenario.tmpdir"].(type) {
+	case string:
+		return dir
+	default:
+		return os.TempDir()
+	}
+}
+
+// ArtifactsDir returns the directory of the artifacts of the scenario, where
+// the artifacts of a failed scenario are collected
+func ArtifactsDir() string {
+	dir, _ := gauge.GetScenarioStore()["scenario.artifactsdir"].(string)
+	return dir
+}
+
 func Clients() *clients.Clients {
 	switch cs := gauge.GetScenarioStore()["clients"].(type) {
 	case *clients.Clients:
@@ -62,6 +81,17 @@ func PutScenarioData(key, value string) {
 	gauge.GetScenarioStore()[key] = value
 }
 
+// PutScenarioValue stores a value of any type, e.g. a struct, in the scenario store
+func PutScenarioValue(key string, value interface{}) {
+	gauge.GetScenarioStore()[key] = value
+}
+
+// GetScenarioValue returns the value of the key in the scenario store, nil if
+// it has none
+func GetScenarioValue(key string) interface{} {
+	return gauge.GetScenarioStore()[key]
+}
+
 func PutScenarioDataSlice(key string, value []string) {
 	gauge.GetScenarioStore()[key] = value
 }
@@ -0,0 +1,122 @@
+package testutil
+
+import (
+	"context"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	pacfake "github.com/openshift-pipelines/pipelines-as-code/pkg/generated/clientset/versioned/fake"
+
+	apfake "github.com/openshift-pipelines/manual-approval-gate/pkg/client/clientset/versioned/fake"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	consolefake "github.com/openshift/client-go/console/clientset/versioned/fake"
+	routefake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	olmfake "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	operatorfake "github.com/tektoncd/operator/pkg/client/clientset/versioned/fake"
+	pipelinefake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+)
+
+// FakeClientsets holds the fake clientsets backing a clients.Clients built by
+// NewFakeClients, so tests can seed objects, add reactors and inspect actions.
+type FakeClientsets struct {
+	Kube         *kubefake.Clientset
+	Dynamic      *dynamicfake.FakeDynamicClient
+	Tekton       *pipelinefake.Clientset
+	Triggers     *triggersfake.Clientset
+	Operator     *operatorfake.Clientset
+	OLM          *olmfake.Clientset
+	Route        *routefake.Clientset
+	Config       *configfake.Clientset
+	Console      *consolefake.Clientset
+	Pac          *pacfake.Clientset
+	ApprovalTask *apfake.Clientset
+}
+
+type fakeScheme struct {
+	scheme  *runtime.Scheme
+	objects []runtime.Object
+}
+
+func newFakeScheme(addToScheme func(*runtime.Scheme) error) *fakeScheme {
+	s := runtime.NewScheme()
+	if err := addToScheme(s); err != nil {
+		panic(err)
+	}
+	return &fakeScheme{scheme: s}
+}
+
+// NewFakeClients instantiates a clients.Clients whose clients are all backed
+// by fake clientsets scoped to namespace. Every object is seeded into the fake
+// clientset whose scheme knows its type, and into the dynamic client.
+func NewFakeClients(namespace string, objects ...runtime.Object) (*clients.Clients, *FakeClientsets) {
+	kube := newFakeScheme(kubefake.AddToScheme)
+	tekton := newFakeScheme(pipelinefake.AddToScheme)
+	triggers := newFakeScheme(triggersfake.AddToScheme)
+	operator := newFakeScheme(operatorfake.AddToScheme)
+	olm := newFakeScheme(olmfake.AddToScheme)
+	route := newFakeScheme(routefake.AddToScheme)
+	config := newFakeScheme(configfake.AddToScheme)
+	console := newFakeScheme(consolefake.AddToScheme)
+	pac := newFakeScheme(pacfake.AddToScheme)
+	approvalTask := newFakeScheme(apfake.AddToScheme)
+	schemes := []*fakeScheme{kube, tekton, triggers, operator, olm, route, config, console, pac, approvalTask}
+
+	dynamicScheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		kubefake.AddToScheme, pipelinefake.AddToScheme, triggersfake.AddToScheme,
+		operatorfake.AddToScheme, olmfake.AddToScheme, routefake.AddToScheme,
+		configfake.AddToScheme, consolefake.AddToScheme, pacfake.AddToScheme, apfake.AddToScheme,
+	} {
+		if err := add(dynamicScheme); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, obj := range objects {
+		for _, s := range schemes {
+			if _, _, err := s.scheme.ObjectKinds(obj); err == nil {
+				s.objects = append(s.objects, obj)
+				break
+			}
+		}
+	}
+
+	fcs := &FakeClientsets{
+		Kube:         kubefake.NewSimpleClientset(kube.objects...),
+		Dynamic:      dynamicfake.NewSimpleDynamicClient(dynamicScheme, objects...),
+		Tekton:       pipelinefake.NewSimpleClientset(tekton.objects...),
+		Triggers:     triggersfake.NewSimpleClientset(triggers.objects...),
+		Operator:     operatorfake.NewSimpleClientset(operator.objects...),
+		OLM:          olmfake.NewSimpleClientset(olm.objects...),
+		Route:        routefake.NewSimpleClientset(route.objects...),
+		Config:       configfake.NewSimpleClientset(config.objects...),
+		Console:      consolefake.NewSimpleClientset(console.objects...),
+		Pac:          pacfake.NewSimpleClientset(pac.objects...),
+		ApprovalTask: apfake.NewSimpleClientset(approvalTask.objects...),
+	}
+
+	cs := &clients.Clients{
+		KubeClient:            &clients.KubeClient{Kube: fcs.Kube},
+		Ctx:                   context.Background(),
+		Dynamic:               fcs.Dynamic,
+		Operator:              fcs.Operator.OperatorV1alpha1(),
+		KubeConfig:            &rest.Config{Host: "https://fake.invalid"},
+		Scheme:                dynamicScheme,
+		OLM:                   fcs.OLM,
+		Route:                 fcs.Route.RouteV1(),
+		ProxyConfig:           fcs.Config.ConfigV1(),
+		ClusterVersion:        fcs.Config.ConfigV1().ClusterVersions(),
+		ConsoleCLIDownload:    fcs.Console.ConsoleV1().ConsoleCLIDownloads(),
+		Tekton:                fcs.Tekton,
+		PacClientset:          fcs.Pac.PipelinesascodeV1alpha1(),
+		TriggersClient:        fcs.Triggers,
+		ApprovalTaskClientset: fcs.ApprovalTask.OpenshiftpipelinesV1alpha1(),
+	}
+	cs.NewClientSet(namespace)
+	return cs, fcs
+}
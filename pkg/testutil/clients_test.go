@@ -0,0 +1,77 @@
+package testutil
+
+import (
+	"testing"
+
+	operatorv1alpha1 "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewFakeClients(t *testing.T) {
+	const namespace = "test-ns"
+	tests := []struct {
+		name     string
+		object   runtime.Object
+		resource schema.GroupVersionResource
+		// get fetches the object through the typed client expected to hold it
+		get func(t *testing.T, c *FakeClientsets) error
+	}{{
+		name:     "kube",
+		object:   &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: namespace}},
+		resource: corev1.SchemeGroupVersion.WithResource("configmaps"),
+		get: func(t *testing.T, c *FakeClientsets) error {
+			_, err := c.Kube.CoreV1().ConfigMaps(namespace).Get(t.Context(), "cm", metav1.GetOptions{})
+			return err
+		},
+	}, {
+		name:     "tekton",
+		object:   &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: namespace}},
+		resource: v1.SchemeGroupVersion.WithResource("pipelineruns"),
+		get: func(t *testing.T, c *FakeClientsets) error {
+			_, err := c.Tekton.TektonV1().PipelineRuns(namespace).Get(t.Context(), "pr", metav1.GetOptions{})
+			return err
+		},
+	}, {
+		name:     "operator",
+		object:   &operatorv1alpha1.TektonConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}},
+		resource: operatorv1alpha1.SchemeGroupVersion.WithResource("tektonconfigs"),
+		get: func(t *testing.T, c *FakeClientsets) error {
+			_, err := c.Operator.OperatorV1alpha1().TektonConfigs().Get(t.Context(), "config", metav1.GetOptions{})
+			return err
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, fcs := NewFakeClients(namespace, tt.object)
+			if err := tt.get(t, fcs); err != nil {
+				t.Errorf("object not seeded in the typed clientset: %v", err)
+			}
+			obj := tt.object.(metav1.Object)
+			if _, err := fcs.Dynamic.Resource(tt.resource).Namespace(obj.GetNamespace()).Get(t.Context(), obj.GetName(), metav1.GetOptions{}); err != nil {
+				t.Errorf("object not seeded in the dynamic client: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewFakeClientsNamespace(t *testing.T) {
+	pr := func(name, namespace string) *v1.PipelineRun {
+		return &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	c, _ := NewFakeClients("test-ns", pr("in", "test-ns"), pr("out", "other-ns"))
+
+	list, err := c.PipelineRunClient.List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "in" {
+		t.Errorf("expected the clients to be scoped to test-ns, got %v", list.Items)
+	}
+	if c.Reporter() == nil {
+		t.Error("expected the clients to have a reporter")
+	}
+}
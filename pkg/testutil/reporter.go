@@ -0,0 +1,26 @@
+package testutil
+
+import (
+	"sync"
+
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+)
+
+// captureMu serialises replacements of the default reporter
+var captureMu sync.Mutex
+
+// CaptureFailures runs fn with a reporter.Collector as the default reporter and
+// returns the failures fn reported, joined in a single error, instead of failing
+// a Gauge step. A Fail call stops fn. The previous default reporter is restored afterwards.
+//
+// Helpers called with clients whose Ctx carries a reporter (see reporter.WithReporter)
+// report there instead.
+func CaptureFailures(fn func()) error {
+	captureMu.Lock()
+	defer captureMu.Unlock()
+
+	c := &reporter.Collector{}
+	previous := reporter.SetDefault(c)
+	defer reporter.SetDefault(previous)
+	return c.Run(fn)
+}
@@ -0,0 +1,58 @@
+package testutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+)
+
+func TestCaptureFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(r reporter.Reporter)
+		want    string
+		stopped bool
+	}{{
+		name: "no failure",
+		fn:   func(r reporter.Reporter) {},
+	}, {
+		name: "errors are joined",
+		fn: func(r reporter.Reporter) {
+			r.Errorf("first %d", 1)
+			r.Errorf("second %d", 2)
+		},
+		want: "first 1\nsecond 2",
+	}, {
+		name: "fail stops the helper",
+		fn: func(r reporter.Reporter) {
+			r.Errorf("error")
+			r.Fail(errors.New("fatal"))
+			r.Errorf("not reached")
+		},
+		want:    "error\nfatal",
+		stopped: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := reporter.Default()
+			returned := false
+			err := CaptureFailures(func() {
+				tt.fn(reporter.Default())
+				returned = true
+			})
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("expected no failure, got %v", err)
+			case tt.want != "" && (err == nil || err.Error() != tt.want):
+				t.Errorf("expected failures %q, got %v", tt.want, err)
+			}
+			if returned == tt.stopped {
+				t.Errorf("expected the helper to be stopped: %v", tt.stopped)
+			}
+			if reporter.Default() != previous {
+				t.Error("the default reporter was not restored")
+			}
+		})
+	}
+}
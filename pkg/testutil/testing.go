@@ -0,0 +1,25 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+)
+
+// Testing reports failures to a Go test
+type Testing struct {
+	T testing.TB
+}
+
+var _ reporter.Reporter = Testing{}
+
+func (t Testing) Errorf(format string, args ...interface{}) {
+	t.T.Helper()
+	t.T.Errorf(format, args...)
+}
+
+// Fail marks the test as failed and stops it, it must be called from the goroutine running the test
+func (t Testing) Fail(err error) {
+	t.T.Helper()
+	t.T.Fatal(err)
+}
@@ -6,14 +6,17 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 )
 
@@ -36,27 +39,39 @@ func CreateHTTPClient() *http.Client {
 	return client
 }
 
-// CreateHTTPSClient for connection re-use
+// certsDir returns the directory holding the TLS certificates of the scenario
+func certsDir() string {
+	return filepath.Join(store.TempDir(), "certs")
+}
+
+// CreateHTTPSClient for connection re-use, trusting the CA of the certs
+// directory of the scenario and presenting its client certificate if any
 func CreateHTTPSClient() *http.Client {
-	// Load client cert
-	cert, err := tls.LoadX509KeyPair(config.Path("testdata/triggers/certs/server.crt"), config.Path("testdata/triggers/certs/server.key"))
+	caCert, err := os.ReadFile(filepath.Join(certsDir(), "ca.crt"))
 	if err != nil {
-		log.Fatal(err)
-	}
-	caCert, err := os.ReadFile(config.Path("testdata/triggers/certs/ca.crt"))
-	if err != nil {
-		log.Fatal(err)
+		reporter.Default().Fail(err)
 	}
 	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		reporter.Default().Fail(errors.New("no certificate found in ca.crt"))
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    caCertPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	// Load client cert
+	clientCrt, clientKey := filepath.Join(certsDir(), "client.crt"), filepath.Join(certsDir(), "client.key")
+	if _, err := os.Stat(clientCrt); err == nil {
+		cert, err := tls.LoadX509KeyPair(clientCrt, clientKey)
+		if err != nil {
+			reporter.Default().Fail(err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
 	client := &http.Client{
 		Transport: &http.Transport{
 			MaxIdleConnsPerHost: MaxIdleConnections,
-			TLSClientConfig: &tls.Config{
-				Certificates: []tls.Certificate{cert},
-				RootCAs:      caCertPool,
-				MinVersion:   tls.VersionTLS12,
-			},
+			TLSClientConfig:     tlsConfig,
 		},
 		Timeout: time.Duration(RequestTimeout) * time.Second,
 	}
@@ -64,39 +79,81 @@ func CreateHTTPSClient() *http.Client {
 	return client
 }
 
+// CreateInsecureHTTPSClient for connection re-use, without verifying the
+// certificate of the server: EventListeners which are not exposed with a Route
+// serve the certificate of their service, e.g. to a port-forward on 127.0.0.1
+func CreateInsecureHTTPSClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConnsPerHost: MaxIdleConnections,
+			// nolint reason: the certificate is issued for the service, not the exposed address
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}, //nolint:gosec
+		},
+		Timeout: time.Duration(RequestTimeout) * time.Second,
+	}
+}
+
 // GetSignature is a HMAC sha256 generator
 func GetSignature(input []byte, key string) string {
 	keyForSign := []byte(key)
 	h := hmac.New(sha256.New, keyForSign)
 	_, err := h.Write(input)
 	if err != nil {
-		testsuit.T.Errorf("could not generate signature \n %v", err)
+		reporter.Default().Errorf("could not generate signature \n %v", err)
 	}
 	return hex.EncodeToString(h.Sum(nil))
 }
 
 func buildHeaders(req *http.Request, interceptor, eventType string) *http.Request {
+	header, err := EventHeaders(interceptor, eventType, store.GetPayload())
+	if err != nil {
+		reporter.Default().Errorf("Error: %s ", err)
+		return req
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	return req
+}
+
+// EventHeaders returns the headers of the event of the git provider checked by
+// the interceptor (github, gitlab or bitbucket), signed with the triggers
+// secret token
+func EventHeaders(interceptor, eventType string, payload []byte) (http.Header, error) {
+	return signedHeaders(interceptor, eventType, payload, config.TriggersSecretToken)
+}
+
+// signedHeaders returns the headers of the event signed with the secret
+func signedHeaders(interceptor, eventType string, payload []byte, secret string) (http.Header, error) {
+	header := http.Header{}
 	switch strings.ToLower(interceptor) {
 	case "github":
 		log.Printf("Building headers for github interceptor..")
-		req.Header.Add("Accept", "application/json")
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-Hub-Signature-256", "sha256="+GetSignature(store.GetPayload(), config.TriggersSecretToken))
-		req.Header.Add("X-GitHub-Event", eventType)
+		header.Add("Accept", "application/json")
+		header.Add("Content-Type", "application/json")
+		header.Add("X-Hub-Signature-256", "sha256="+GetSignature(payload, secret))
+		header.Add("X-GitHub-Event", eventType)
 	case "gitlab":
 		log.Printf("Building headers for gitlab interceptor..")
-		req.Header.Add("Accept", "application/json")
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-GitLab-Token", config.TriggersSecretToken)
-		req.Header.Add("X-Gitlab-Event", eventType)
+		header.Add("Accept", "application/json")
+		header.Add("Content-Type", "application/json")
+		header.Add("X-GitLab-Token", secret)
+		header.Add("X-Gitlab-Event", eventType)
 	case "bitbucket":
 		log.Printf("Building headers for bitbucket interceptor..")
-		req.Header.Add("Accept", "application/json")
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("X-Hub-Signature", "sha256="+GetSignature(store.GetPayload(), config.TriggersSecretToken))
-		req.Header.Add("X-Event-Key", "repo:"+eventType)
+		header.Add("Accept", "application/json")
+		header.Add("Content-Type", "application/json")
+		header.Add("X-Hub-Signature", "sha256="+GetSignature(payload, secret))
+		// repository events are given without their "repo:" prefix, e.g.
+		// refs_changed, pull request events with theirs, e.g. pr:opened
+		if !strings.Contains(eventType, ":") {
+			eventType = "repo:" + eventType
+		}
+		header.Add("X-Event-Key", eventType)
 	default:
-		testsuit.T.Errorf("Error: %s ", "Please provide valid event_listener type eg: (github, gitlab, bitbucket)")
+		return nil, fmt.Errorf("please provide valid event_listener type eg: (github, gitlab, bitbucket)")
 	}
-	return req
+	return header, nil
 }
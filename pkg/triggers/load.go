@@ -0,0 +1,262 @@
+package triggers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	triggersapi "github.com/tektoncd/triggers/pkg/apis/triggers"
+	"github.com/tektoncd/triggers/pkg/sink"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	eventListenerLabel = triggersapi.GroupName + triggersapi.EventListenerLabelKey
+	eventIDLabel       = triggersapi.GroupName + triggersapi.EventIDLabelKey
+)
+
+// Load is a series of signed webhook events posted to an EventListener
+type Load struct {
+	// Interceptor and EventType sign the events like MockPostEvent does, see
+	// EventHeaders
+	Interceptor string
+	EventType   string
+	Payload     []byte
+	TLS         bool
+	// Requests is the number of events posted
+	Requests int
+	// Rate is the number of events posted per second, as fast as the
+	// concurrency allows if 0
+	Rate float64
+	// Concurrency is the maximum number of requests in flight
+	Concurrency int
+}
+
+// LoadResult is what the EventListener made of a load
+type LoadResult struct {
+	Requests int
+	// Latencies of the requests which got a response, sorted
+	Latencies []time.Duration
+	// StatusCodes counts the responses by status code
+	StatusCodes map[int]int
+	// Errors counts the requests which got no response, by error
+	Errors map[string]int
+	// EventIDs are the IDs of the events accepted by the EventListener
+	EventIDs []string
+	Duration time.Duration
+}
+
+// Failed returns the number of requests which failed or got a non 2xx response
+func (r LoadResult) Failed() int {
+	failed := 0
+	for _, n := range r.Errors {
+		failed += n
+	}
+	for code, n := range r.StatusCodes {
+		if code < 200 || code > 299 {
+			failed += n
+		}
+	}
+	return failed
+}
+
+// ErrorRate returns the share of the requests which failed, from 0 to 1
+func (r LoadResult) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Failed()) / float64(r.Requests)
+}
+
+// Percentile returns the latency under which p percent of the responses were
+// received, using the nearest rank
+func (r LoadResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(r.Latencies))))
+	rank = min(max(rank, 1), len(r.Latencies))
+	return r.Latencies[rank-1]
+}
+
+func (r LoadResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d requests in %s (%.1f/s), %d failed (%.2f%%)\n", r.Requests, r.Duration.Round(time.Millisecond),
+		float64(r.Requests)/r.Duration.Seconds(), r.Failed(), 100*r.ErrorRate())
+	fmt.Fprintf(&b, "latency p50 %s, p90 %s, p95 %s, p99 %s, max %s\n", r.Percentile(50), r.Percentile(90),
+		r.Percentile(95), r.Percentile(99), r.Percentile(100))
+	codes := make([]int, 0, len(r.StatusCodes))
+	for code := range r.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "status %d: %d\n", code, r.StatusCodes[code])
+	}
+	for err, n := range r.Errors {
+		fmt.Fprintf(&b, "error %q: %d\n", err, n)
+	}
+	return b.String()
+}
+
+// PostLoad posts the events of the load to the EventListener exposed at
+// routeurl and measures how it responds
+func PostLoad(routeurl string, load Load) (LoadResult, error) {
+	header, err := EventHeaders(load.Interceptor, load.EventType, load.Payload)
+	if err != nil {
+		return LoadResult{}, err
+	}
+	sinkURL, err := url.Parse(routeurl)
+	if err != nil {
+		return LoadResult{}, err
+	}
+	if load.TLS {
+		sinkURL.Scheme = "https"
+	}
+	client := eventClient(load.TLS)
+	concurrency := max(load.Concurrency, 1)
+
+	result := LoadResult{Requests: load.Requests, StatusCodes: map[int]int{}, Errors: map[string]int{}}
+	var mu sync.Mutex
+	requests := make(chan struct{})
+	var workers sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for range requests {
+				latency, code, eventID, err := postEvent(client, sinkURL.String(), header, load.Payload)
+				mu.Lock()
+				if err != nil {
+					result.Errors[err.Error()]++
+				} else {
+					result.Latencies = append(result.Latencies, latency)
+					result.StatusCodes[code]++
+					if eventID != "" {
+						result.EventIDs = append(result.EventIDs, eventID)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var tick <-chan time.Time
+	if load.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / load.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for i := 0; i < load.Requests; i++ {
+		if tick != nil && i > 0 {
+			<-tick
+		}
+		requests <- struct{}{}
+	}
+	close(requests)
+	workers.Wait()
+	result.Duration = time.Since(start)
+	sort.Slice(result.Latencies, func(i, j int) bool { return result.Latencies[i] < result.Latencies[j] })
+	log.Printf("Load of eventlistener %s:\n%s", routeurl, result)
+	return result, nil
+}
+
+// postEvent posts one event and returns the latency of the response, its
+// status code and the ID the EventListener gave to the event
+func postEvent(client *http.Client, sinkURL string, header http.Header, payload []byte) (time.Duration, int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, sinkURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	req.Header = header.Clone()
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	defer resp.Body.Close()
+	var body sink.Response
+	// the body is only read to get the event ID, and to measure the whole response
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return time.Since(start), resp.StatusCode, body.EventID, nil
+}
+
+// CountPipelineRuns waits for the PipelineRuns of the accepted events of the
+// load to be created by the EventListener, up to want, and returns how many
+// were created
+func CountPipelineRuns(c *clients.Clients, result LoadResult, elname, namespace string, want int) int {
+	events := make(map[string]bool, len(result.EventIDs))
+	for _, id := range result.EventIDs {
+		events[id] = true
+	}
+	created := 0
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.APITimeout, true, func(context.Context) (bool, error) {
+		prs, err := c.Tekton.TektonV1().PipelineRuns(namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: eventListenerLabel + "=" + elname})
+		if err != nil {
+			return false, err
+		}
+		created = 0
+		for _, pr := range prs.Items {
+			if events[pr.Labels[eventIDLabel]] {
+				created++
+			}
+		}
+		if created >= want {
+			return true, nil
+		}
+		log.Printf("Waiting for the PipelineRuns of eventlistener %s (%d/%d)", elname, created, want)
+		return false, nil
+	})
+	if err != nil {
+		log.Printf("Eventlistener %s created %d PipelineRuns, expected %d: %v", elname, created, want, err)
+	}
+	return created
+}
+
+// LoadThresholds are the limits a load must stay within
+type LoadThresholds struct {
+	// Percentiles are the maximum latencies by percentile, e.g. 95 for p95
+	Percentiles map[float64]time.Duration
+	// MaxErrorRate is the maximum share of failed requests, from 0 to 1
+	MaxErrorRate float64
+	// PipelineRuns is the minimum number of PipelineRuns the events must
+	// create, not checked if 0
+	PipelineRuns int
+}
+
+// AssertLoad checks that the load of the EventListener stayed within the thresholds
+func AssertLoad(c *clients.Clients, result LoadResult, elname, namespace string, thresholds LoadThresholds) {
+	percentiles := make([]float64, 0, len(thresholds.Percentiles))
+	for p := range thresholds.Percentiles {
+		percentiles = append(percentiles, p)
+	}
+	sort.Float64s(percentiles)
+	for _, p := range percentiles {
+		if got, limit := result.Percentile(p), thresholds.Percentiles[p]; got > limit {
+			c.Reporter().Errorf("p%g latency of eventlistener %s is %s, expected at most %s", p, elname, got, limit)
+		}
+	}
+	if rate := result.ErrorRate(); rate > thresholds.MaxErrorRate {
+		c.Reporter().Errorf("%d of %d requests to eventlistener %s failed (%.2f%%), expected at most %.2f%%:\n%s",
+			result.Failed(), result.Requests, elname, 100*rate, 100*thresholds.MaxErrorRate, result)
+	}
+	if thresholds.PipelineRuns > 0 {
+		if created := CountPipelineRuns(c, result, elname, namespace, thresholds.PipelineRuns); created < thresholds.PipelineRuns {
+			c.Reporter().Errorf("eventlistener %s created %d PipelineRuns for %d accepted events, expected at least %d",
+				elname, created, len(result.EventIDs), thresholds.PipelineRuns)
+		}
+	}
+}
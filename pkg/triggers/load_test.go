@@ -0,0 +1,88 @@
+package triggers
+
+import (
+	"testing"
+	"time"
+)
+
+func latencies(ms ...int) []time.Duration {
+	d := make([]time.Duration, len(ms))
+	for i, m := range ms {
+		d[i] = time.Duration(m) * time.Millisecond
+	}
+	return d
+}
+
+func TestLoadResultPercentile(t *testing.T) {
+	ten := latencies(10, 20, 30, 40, 50, 60, 70, 80, 90, 100)
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		p         float64
+		want      time.Duration
+	}{
+		{name: "no response", p: 50},
+		{name: "single response", latencies: latencies(42), p: 99, want: 42 * time.Millisecond},
+		{name: "p0 is the fastest", latencies: ten, p: 0, want: 10 * time.Millisecond},
+		{name: "p50", latencies: ten, p: 50, want: 50 * time.Millisecond},
+		{name: "p90", latencies: ten, p: 90, want: 90 * time.Millisecond},
+		{name: "p95 rounds up to the next rank", latencies: ten, p: 95, want: 100 * time.Millisecond},
+		{name: "p51 rounds up to the next rank", latencies: ten, p: 51, want: 60 * time.Millisecond},
+		{name: "p100 is the slowest", latencies: ten, p: 100, want: 100 * time.Millisecond},
+		{name: "above p100", latencies: ten, p: 150, want: 100 * time.Millisecond},
+		{name: "odd count", latencies: latencies(1, 2, 3), p: 50, want: 2 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := LoadResult{Latencies: tt.latencies}
+			if got := r.Percentile(tt.p); got != tt.want {
+				t.Errorf("Percentile(%g) = %s, want %s", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadResultFailed(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      LoadResult
+		wantFailed  int
+		wantErrRate float64
+	}{{
+		name:   "no request",
+		result: LoadResult{},
+	}, {
+		name:   "all accepted",
+		result: LoadResult{Requests: 4, StatusCodes: map[int]int{202: 3, 200: 1}},
+	}, {
+		name:        "non 2xx responses",
+		result:      LoadResult{Requests: 10, StatusCodes: map[int]int{202: 6, 400: 1, 503: 2, 101: 1}},
+		wantFailed:  4,
+		wantErrRate: 0.4,
+	}, {
+		name:        "requests without response",
+		result:      LoadResult{Requests: 4, StatusCodes: map[int]int{202: 2}, Errors: map[string]int{"EOF": 1, "connection refused": 1}},
+		wantFailed:  2,
+		wantErrRate: 0.5,
+	}, {
+		name:        "errors and non 2xx responses",
+		result:      LoadResult{Requests: 8, StatusCodes: map[int]int{299: 4, 300: 1}, Errors: map[string]int{"timeout": 3}},
+		wantFailed:  4,
+		wantErrRate: 0.5,
+	}, {
+		name:        "all failed",
+		result:      LoadResult{Requests: 2, Errors: map[string]int{"timeout": 2}},
+		wantFailed:  2,
+		wantErrRate: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Failed(); got != tt.wantFailed {
+				t.Errorf("Failed() = %d, want %d", got, tt.wantFailed)
+			}
+			if got := tt.result.ErrorRate(); got != tt.wantErrRate {
+				t.Errorf("ErrorRate() = %g, want %g", got, tt.wantErrRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/clients"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Cases of NegativeEvent, events the interceptors of an EventListener must
+// reject
+const (
+	// WrongHMAC signs the payload with another secret
+	WrongHMAC = "wrong_hmac"
+	// MissingSignature drops the signature (the token on GitLab)
+	MissingSignature = "missing_signature"
+	// DisallowedEvent signs an event type missing from the eventTypes of the
+	// interceptor, the Value of the case or issues by default
+	DisallowedEvent = "disallowed_event"
+	// TamperedBody keeps the signature of the payload with another body, like
+	// a delivery captured and tampered with. GitLab tokens do not sign the
+	// body, so it does not apply to them.
+	TamperedBody = "tampered_body"
+	// OversizedBody pads the payload to the size of the Value of the case,
+	// 26Mi by default (past the 25MB GitHub delivers), correctly signed so
+	// that only its size can get it rejected
+	OversizedBody = "oversized_body"
+	// MalformedJSON signs a payload truncated to invalid JSON
+	MalformedJSON = "malformed_json"
+	// CELMismatch signs the payload with the field of the Value of the case,
+	// e.g. ref=refs/heads/other, set to a value the CEL filter rejects
+	CELMismatch = "cel_mismatch"
+	// Replay posts the correctly signed payload twice with the same delivery
+	// ID, like a captured delivery sent again. None of the interceptors of
+	// Triggers keeps track of the delivery IDs, so the EventListener accepts
+	// the replay and creates its resources again: the case checks that it
+	// does, until an interceptor rejects replays.
+	Replay = "replay"
+)
+
+// defaultOversizedBody is the size of OversizedBody payloads
+const defaultOversizedBody = "26Mi"
+
+// disallowedEvents are the event types of DisallowedEvent by interceptor
+var disallowedEvents = map[string]string{
+	"github":    "issues",
+	"gitlab":    "Issue Hook",
+	"bitbucket": "pr:opened",
+}
+
+// deliveryHeaders are the headers of the delivery IDs by interceptor
+var deliveryHeaders = map[string]string{
+	"github":    "X-GitHub-Delivery",
+	"gitlab":    "X-Gitlab-Event-UUID",
+	"bitbucket": "X-Request-UUID",
+}
+
+// NegativeEvent is a case of the negative matrix of an interceptor, with the
+// response the EventListener must return for it. The sink of an EventListener
+// accepts every event and runs the interceptors after responding, so Status
+// is 202 unless the event does not reach it.
+type NegativeEvent struct {
+	Case  string
+	Value string
+	// Status is the expected status code of the response
+	Status int
+}
+
+// replayed tells whether the event is expected to create resources, which is
+// the case of the replays no interceptor rejects
+func (e NegativeEvent) replayed() bool {
+	return e.Case == Replay
+}
+
+func (e NegativeEvent) String() string {
+	if e.Value == "" {
+		return e.Case
+	}
+	return e.Case + " (" + e.Value + ")"
+}
+
+// NegativeResult is how the EventListener responded to a negative event
+type NegativeResult struct {
+	Event  NegativeEvent
+	Status int
+	// EventID is the ID the EventListener gave to the event, if it accepted
+	// it, the one of the second delivery of a Replay
+	EventID string
+}
+
+// wrongSecret returns a secret which is not the triggers secret token
+func wrongSecret() string {
+	return "not-" + config.TriggersSecretToken
+}
+
+// NegativeRequest returns the headers and body of the negative event,
+// derived from the payload signed for the interceptor like EventHeaders does
+func NegativeRequest(interceptor, eventType string, payload []byte, e NegativeEvent) (http.Header, []byte, error) {
+	interceptor = strings.ToLower(interceptor)
+	switch e.Case {
+	case WrongHMAC:
+		header, err := signedHeaders(interceptor, eventType, payload, wrongSecret())
+		return header, payload, err
+	case MissingSignature:
+		header, err := EventHeaders(interceptor, eventType, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range []string{"X-Hub-Signature-256", "X-Hub-Signature", "X-GitLab-Token"} {
+			header.Del(key)
+		}
+		return header, payload, nil
+	case DisallowedEvent:
+		disallowed := e.Value
+		if disallowed == "" {
+			disallowed = disallowedEvents[interceptor]
+		}
+		header, err := EventHeaders(interceptor, disallowed, payload)
+		return header, payload, err
+	case TamperedBody:
+		if interceptor == "gitlab" {
+			return nil, nil, fmt.Errorf("%s does not apply to the gitlab interceptor, its token does not sign the payload", e.Case)
+		}
+		header, err := EventHeaders(interceptor, eventType, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		body, err := setPayloadField(payload, "tampered", "true")
+		return header, body, err
+	case OversizedBody:
+		size := e.Value
+		if size == "" {
+			size = defaultOversizedBody
+		}
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid size %q of %s: %v", size, e.Case, err)
+		}
+		body, err := setPayloadField(payload, "padding", strings.Repeat("x", max(int(quantity.Value())-len(payload), 0)))
+		if err != nil {
+			return nil, nil, err
+		}
+		header, err := EventHeaders(interceptor, eventType, body)
+		return header, body, err
+	case MalformedJSON:
+		body := payload[:len(payload)/2]
+		header, err := EventHeaders(interceptor, eventType, body)
+		return header, body, err
+	case CELMismatch:
+		path, value, ok := strings.Cut(e.Value, "=")
+		if !ok {
+			return nil, nil, fmt.Errorf("%s needs the field the CEL filter rejects, e.g. ref=refs/heads/other, got %q", e.Case, e.Value)
+		}
+		body, err := setPayloadField(payload, path, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		header, err := EventHeaders(interceptor, eventType, body)
+		return header, body, err
+	case Replay:
+		header, err := EventHeaders(interceptor, eventType, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		header.Set(deliveryHeaders[interceptor], string(uuid.NewUUID()))
+		return header, payload, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown negative case %q", e.Case)
+	}
+}
+
+// setPayloadField returns the JSON object of the payload with the string
+// field at the dotted path set to value
+func setPayloadField(payload []byte, path, value string) ([]byte, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("payload is not a JSON object: %v", err)
+	}
+	keys := strings.Split(path, ".")
+	object := body
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			object[key] = next
+		}
+		object = next
+	}
+	object[keys[len(keys)-1]] = value
+	return json.Marshal(body)
+}
+
+// PostNegativeEvents posts the negative events derived from the payload to
+// the EventListener exposed at routeurl
+func PostNegativeEvents(routeurl, interceptor, eventType string, payload []byte, isTLS bool, events []NegativeEvent) ([]NegativeResult, error) {
+	sinkURL, err := url.Parse(routeurl)
+	if err != nil {
+		return nil, err
+	}
+	if isTLS {
+		sinkURL.Scheme = "https"
+	}
+	client := eventClient(isTLS)
+	var results []NegativeResult
+	for _, e := range events {
+		header, body, err := NegativeRequest(interceptor, eventType, payload, e)
+		if err != nil {
+			return nil, err
+		}
+		if e.Case == Replay {
+			// the first delivery, the one replayed
+			_, status, eventID, err := postEvent(client, sinkURL.String(), header, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to post the delivery replayed by %s event to eventlistener %s: %v", e, routeurl, err)
+			}
+			log.Printf("Eventlistener responded %d to the delivery replayed by %s event %s", status, e, eventID)
+		}
+		_, status, eventID, err := postEvent(client, sinkURL.String(), header, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to post %s event to eventlistener %s: %v", e, routeurl, err)
+		}
+		log.Printf("Eventlistener responded %d to %s event %s", status, e, eventID)
+		results = append(results, NegativeResult{Event: e, Status: status, EventID: eventID})
+	}
+	return results, nil
+}
+
+// AssertNegativeEvents checks that the EventListener responded to the
+// negative events with their expected status, that the replays created their
+// PipelineRun, and that none of the other events created a PipelineRun or a
+// TaskRun during the resource timeout
+func AssertNegativeEvents(c *clients.Clients, results []NegativeResult, elname, namespace string) {
+	events := map[string]NegativeEvent{}
+	var replays []string
+	for _, r := range results {
+		if r.Status != r.Event.Status {
+			c.Reporter().Errorf("eventlistener %s responded %d to %s event, expected %d", elname, r.Status, r.Event, r.Event.Status)
+		}
+		switch {
+		case r.EventID == "":
+		case r.Event.replayed():
+			replays = append(replays, r.EventID)
+		default:
+			events[r.EventID] = r.Event
+		}
+	}
+	if len(replays) > 0 {
+		if created := CountPipelineRuns(c, LoadResult{EventIDs: replays}, elname, namespace, len(replays)); created < len(replays) {
+			c.Reporter().Errorf("eventlistener %s created %d PipelineRuns for %d replayed deliveries, expected one for each as no interceptor rejects replays",
+				elname, created, len(replays))
+		}
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	var created []string
+	err := wait.PollUntilContextTimeout(c.Ctx, config.APIRetry, config.ResourceTimeout, true, func(context.Context) (bool, error) {
+		opts := metav1.ListOptions{LabelSelector: eventListenerLabel + "=" + elname}
+		prs, err := c.Tekton.TektonV1().PipelineRuns(namespace).List(c.Ctx, opts)
+		if err != nil {
+			return false, err
+		}
+		trs, err := c.Tekton.TektonV1().TaskRuns(namespace).List(c.Ctx, opts)
+		if err != nil {
+			return false, err
+		}
+		for _, pr := range prs.Items {
+			if e, ok := events[pr.Labels[eventIDLabel]]; ok {
+				created = append(created, fmt.Sprintf("PipelineRun %s for %s event", pr.Name, e))
+			}
+		}
+		for _, tr := range trs.Items {
+			if e, ok := events[tr.Labels[eventIDLabel]]; ok {
+				created = append(created, fmt.Sprintf("TaskRun %s for %s event", tr.Name, e))
+			}
+		}
+		return len(created) > 0, nil
+	})
+	switch {
+	case err == nil:
+		c.Reporter().Errorf("eventlistener %s created resources for negative events:\n%s", elname, strings.Join(created, "\n"))
+	case !wait.Interrupted(err):
+		c.Reporter().Errorf("failed to list the resources created by eventlistener %s: %v", elname, err)
+	default:
+		log.Printf("Eventlistener %s created no resources for %d negative events", elname, len(events))
+	}
+}
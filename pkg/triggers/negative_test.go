@@ -0,0 +1,214 @@
+package triggers
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+)
+
+// TestNegativeRequest renders the testdata EventListeners for the negative
+// requests, which their interceptors or bindings must reject while they
+// accept the payload they are derived from. Oversized bodies are correctly
+// signed, only the sink or the router in front of it can reject them, and no
+// interceptor rejects replays, so the interceptors accept both.
+func TestNegativeRequest(t *testing.T) {
+	listeners := []struct {
+		interceptor   string
+		eventListener string
+		manifests     []string
+		eventType     string
+		payload       string
+		cases         []NegativeEvent
+		// accepted are the cases the interceptors let through
+		accepted []NegativeEvent
+	}{{
+		interceptor:   "github",
+		eventListener: "github-listener",
+		manifests:     []string{"testdata/triggers/github/github-push-listener.yaml", "testdata/triggers/github/secret.yaml"},
+		eventType:     "push",
+		payload:       "testdata/triggers/github-ctb/push.json",
+		cases: []NegativeEvent{
+			{Case: WrongHMAC},
+			{Case: MissingSignature},
+			{Case: DisallowedEvent},
+			{Case: TamperedBody},
+			{Case: MalformedJSON},
+			{Case: CELMismatch, Value: "ref=refs/heads/other"},
+		},
+		accepted: []NegativeEvent{{Case: OversizedBody, Value: "1Mi"}, {Case: Replay}},
+	}, {
+		interceptor:   "gitlab",
+		eventListener: "gitlab-listener",
+		manifests:     []string{"testdata/triggers/gitlab/gitlab-push-listener.yaml", "testdata/triggers/gitlab/secret.yaml"},
+		eventType:     "Push Hook",
+		payload:       "testdata/triggers/gitlab/gitlab-push-event.json",
+		cases: []NegativeEvent{
+			{Case: WrongHMAC},
+			{Case: MissingSignature},
+			{Case: DisallowedEvent},
+		},
+		accepted: []NegativeEvent{{Case: OversizedBody, Value: "1Mi"}, {Case: Replay}},
+	}, {
+		interceptor:   "bitbucket",
+		eventListener: "bitbucket-listener",
+		manifests:     []string{"testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml", "testdata/triggers/bitbucket/secret.yaml"},
+		eventType:     "refs_changed",
+		payload:       "testdata/triggers/bitbucket/refs-change-event.json",
+		cases: []NegativeEvent{
+			{Case: WrongHMAC},
+			{Case: MissingSignature},
+			{Case: DisallowedEvent, Value: "pr:opened"},
+			{Case: TamperedBody},
+			{Case: MalformedJSON},
+		},
+		accepted: []NegativeEvent{{Case: OversizedBody, Value: "1Mi"}, {Case: Replay}},
+	}}
+	for _, l := range listeners {
+		paths := make([]string, len(l.manifests))
+		for i, m := range l.manifests {
+			paths[i] = config.Path(m)
+		}
+		resources, err := LoadTriggersResources(paths...)
+		if err != nil {
+			t.Fatalf("LoadTriggersResources() error = %v", err)
+		}
+		payload, err := os.ReadFile(config.Path(l.payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// accepted tells whether a trigger of the EventListener rendered
+		// resources for the request
+		accepted := func(t *testing.T, event TriggerEvent) bool {
+			rendered, err := resources.Render(l.eventListener, event)
+			if err != nil {
+				t.Logf("Render() error = %v", err)
+				return false
+			}
+			for _, rt := range rendered {
+				if rt.Continue {
+					return true
+				}
+				t.Logf("trigger %s stopped: %s", rt.Name, rt.Reason)
+			}
+			return false
+		}
+
+		t.Run(l.interceptor+"/valid", func(t *testing.T) {
+			header, err := EventHeaders(l.interceptor, l.eventType, payload)
+			if err != nil {
+				t.Fatalf("EventHeaders() error = %v", err)
+			}
+			if !accepted(t, TriggerEvent{Body: payload, Header: header}) {
+				t.Errorf("eventlistener %s rejected the payload the negative events are derived from", l.eventListener)
+			}
+		})
+		for _, e := range l.cases {
+			t.Run(l.interceptor+"/"+e.Case, func(t *testing.T) {
+				header, body, err := NegativeRequest(l.interceptor, l.eventType, payload, e)
+				if err != nil {
+					t.Fatalf("NegativeRequest() error = %v", err)
+				}
+				if accepted(t, TriggerEvent{Body: body, Header: header}) {
+					t.Errorf("eventlistener %s accepted %s event", l.eventListener, e)
+				}
+			})
+		}
+		for _, e := range l.accepted {
+			t.Run(l.interceptor+"/"+e.Case, func(t *testing.T) {
+				header, body, err := NegativeRequest(l.interceptor, l.eventType, payload, e)
+				if err != nil {
+					t.Fatalf("NegativeRequest() error = %v", err)
+				}
+				if !accepted(t, TriggerEvent{Body: body, Header: header}) {
+					t.Errorf("eventlistener %s rejected %s event", l.eventListener, e)
+				}
+			})
+		}
+	}
+}
+
+func TestNegativeRequestSize(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/master"}`)
+	header, body, err := NegativeRequest("github", "push", payload, NegativeEvent{Case: OversizedBody, Value: "64Ki"})
+	if err != nil {
+		t.Fatalf("NegativeRequest() error = %v", err)
+	}
+	if len(body) < 64*1024 {
+		t.Errorf("oversized body is %d bytes, want at least %d", len(body), 64*1024)
+	}
+	if got, want := header.Get("X-Hub-Signature-256"), "sha256="+GetSignature(body, config.TriggersSecretToken); got != want {
+		t.Errorf("oversized body signed %s, want %s", got, want)
+	}
+}
+
+func TestNegativeRequestReplay(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/master"}`)
+	for interceptor, delivery := range deliveryHeaders {
+		t.Run(interceptor, func(t *testing.T) {
+			header, body, err := NegativeRequest(interceptor, "push", payload, NegativeEvent{Case: Replay})
+			if err != nil {
+				t.Fatalf("NegativeRequest() error = %v", err)
+			}
+			if string(body) != string(payload) {
+				t.Errorf("replayed body = %s, want the payload", body)
+			}
+			if header.Get(delivery) == "" {
+				t.Errorf("replay has no %s header", delivery)
+			}
+			signed, err := EventHeaders(interceptor, "push", payload)
+			if err != nil {
+				t.Fatalf("EventHeaders() error = %v", err)
+			}
+			for key := range signed {
+				if header.Get(key) != signed.Get(key) {
+					t.Errorf("replay header %s = %s, want %s", key, header.Get(key), signed.Get(key))
+				}
+			}
+		})
+	}
+}
+
+func TestNegativeRequestErrors(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/master"}`)
+	tests := []struct {
+		name        string
+		interceptor string
+		event       NegativeEvent
+		wantErr     string
+	}{{
+		name:        "tampered body on gitlab",
+		interceptor: "gitlab",
+		event:       NegativeEvent{Case: TamperedBody},
+		wantErr:     "does not apply to the gitlab interceptor",
+	}, {
+		name:        "cel mismatch without field",
+		interceptor: "github",
+		event:       NegativeEvent{Case: CELMismatch},
+		wantErr:     "needs the field the CEL filter rejects",
+	}, {
+		name:        "invalid size",
+		interceptor: "github",
+		event:       NegativeEvent{Case: OversizedBody, Value: "big"},
+		wantErr:     `invalid size "big"`,
+	}, {
+		name:        "unknown case",
+		interceptor: "github",
+		event:       NegativeEvent{Case: "expired_delivery"},
+		wantErr:     `unknown negative case "expired_delivery"`,
+	}, {
+		name:        "unknown interceptor",
+		interceptor: "gitea",
+		event:       NegativeEvent{Case: WrongHMAC},
+		wantErr:     "valid event_listener type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NegativeRequest(tt.interceptor, "push", payload, tt.event)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NegativeRequest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package triggers
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // commit ids are sha1, not used for security
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+)
+
+// Webhook events built by BuildPayload
+const (
+	PushEvent        = "push"
+	TagEvent         = "tag"
+	PullRequestEvent = "pull_request"
+)
+
+// zeroSHA is the revision before a ref is created
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// WebhookEvent describes a push, tag or pull request (merge request on
+// GitLab) event of a git provider. Its empty fields get defaults.
+type WebhookEvent struct {
+	// Provider is github, gitlab or bitbucket (Bitbucket Server)
+	Provider string
+	// Event is push, tag or pull_request (or merge_request)
+	Event string
+	// RepoURL is the web URL of the repository, e.g.
+	// https://github.com/<owner>/<repo>
+	RepoURL string
+	// Branch is the pushed branch, or the source branch of a pull request
+	Branch string
+	// BaseBranch is the target branch of a pull request, and the default
+	// branch of the repository
+	BaseBranch string
+	Tag        string
+	// Revision is the pushed commit, or the head of a pull request. It is
+	// derived from the repository, ref and message if empty.
+	Revision string
+	Message  string
+	Author   string
+	// Number, Title and Action describe a pull request
+	Number int
+	Title  string
+	Action string
+}
+
+func (e WebhookEvent) withDefaults() (WebhookEvent, error) {
+	e.Provider = strings.ToLower(e.Provider)
+	switch strings.ToLower(e.Event) {
+	case "push", "push hook":
+		e.Event = PushEvent
+	case "tag", "tag_push", "tag push hook":
+		e.Event = TagEvent
+	case "pull_request", "pull-request", "pr", "merge_request", "merge request hook":
+		e.Event = PullRequestEvent
+	default:
+		return e, fmt.Errorf("unknown event %q, expected push, tag or pull_request", e.Event)
+	}
+	defaults := map[*string]string{
+		&e.RepoURL:    "https://github.com/openshift-pipelines/release-tests",
+		&e.Branch:     "main",
+		&e.BaseBranch: "main",
+		&e.Tag:        "v0.1.0",
+		&e.Message:    "Update README.md",
+		&e.Author:     "release-tests",
+	}
+	for field, value := range defaults {
+		if *field == "" {
+			*field = value
+		}
+	}
+	e.RepoURL = strings.TrimSuffix(e.RepoURL, ".git")
+	if e.Number == 0 {
+		e.Number = 1
+	}
+	if e.Title == "" {
+		e.Title = e.Message
+	}
+	if e.Revision == "" {
+		e.Revision = SHA(e.RepoURL, e.ref(), e.Message)
+	}
+	return e, nil
+}
+
+// ref returns the pushed ref, or the source branch of a pull request
+func (e WebhookEvent) ref() string {
+	if e.Event == TagEvent {
+		return "refs/tags/" + e.Tag
+	}
+	return "refs/heads/" + e.Branch
+}
+
+// HeaderEventType returns the event type to give to MockPostEvent or
+// EventHeaders for the event
+func (e WebhookEvent) HeaderEventType() string {
+	e, err := e.withDefaults()
+	if err != nil {
+		return e.Event
+	}
+	switch e.Provider {
+	case "gitlab":
+		return map[string]string{PushEvent: "Push Hook", TagEvent: "Tag Push Hook", PullRequestEvent: "Merge Request Hook"}[e.Event]
+	case "bitbucket":
+		if e.Event == PullRequestEvent {
+			return "pr:" + e.action("opened")
+		}
+		return "refs_changed"
+	default:
+		if e.Event == PullRequestEvent {
+			return "pull_request"
+		}
+		return "push"
+	}
+}
+
+func (e WebhookEvent) action(def string) string {
+	if e.Action != "" {
+		return e.Action
+	}
+	return def
+}
+
+// BuildPayload returns the JSON payload the provider posts for the event,
+// with the fields read by the bindings of the tests and by the
+// ClusterTriggerBindings installed by the operator
+func BuildPayload(e WebhookEvent) ([]byte, error) {
+	e, err := e.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(e.RepoURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid repository url %q", e.RepoURL)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	repo := repository{url: e.RepoURL, host: u.Host, name: segments[len(segments)-1]}
+	repo.owner = repo.name
+	if len(segments) > 1 {
+		repo.owner = segments[len(segments)-2]
+	}
+	repo.fullName = repo.owner + "/" + repo.name
+
+	var payload map[string]interface{}
+	switch e.Provider {
+	case "github":
+		payload = githubPayload(e, repo)
+	case "gitlab":
+		payload = gitlabPayload(e, repo)
+	case "bitbucket":
+		payload = bitbucketPayload(e, repo)
+	default:
+		return nil, fmt.Errorf("unknown provider %q, expected github, gitlab or bitbucket", e.Provider)
+	}
+	return json.Marshal(payload)
+}
+
+type repository struct {
+	url, host, owner, name, fullName string
+}
+
+func (r repository) cloneURL() string {
+	return r.url + ".git"
+}
+
+func (r repository) sshURL() string {
+	return fmt.Sprintf("git@%s:%s.git", r.host, r.fullName)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func githubPayload(e WebhookEvent, r repository) map[string]interface{} {
+	user := map[string]interface{}{"login": e.Author, "name": e.Author, "type": "User"}
+	repo := map[string]interface{}{
+		"name":           r.name,
+		"full_name":      r.fullName,
+		"private":        false,
+		"owner":          map[string]interface{}{"login": r.owner, "name": r.owner, "type": "Organization", "organizations_url": "https://api.github.com/users/" + r.owner + "/orgs"},
+		"html_url":       r.url,
+		"url":            r.url,
+		"clone_url":      r.cloneURL(),
+		"git_url":        "git://" + r.host + "/" + r.fullName + ".git",
+		"ssh_url":        r.sshURL(),
+		"default_branch": e.BaseBranch,
+	}
+	if e.Event == PullRequestEvent {
+		api := fmt.Sprintf("https://api.github.com/repos/%s", r.fullName)
+		return map[string]interface{}{
+			"action": e.action("opened"),
+			"number": e.Number,
+			"pull_request": map[string]interface{}{
+				"url":       fmt.Sprintf("%s/pulls/%d", api, e.Number),
+				"html_url":  fmt.Sprintf("%s/pull/%d", r.url, e.Number),
+				"issue_url": fmt.Sprintf("%s/issues/%d", api, e.Number),
+				"number":    e.Number,
+				"state":     "open",
+				"title":     e.Title,
+				"body":      e.Message,
+				"user":      user,
+				"head":      map[string]interface{}{"label": r.owner + ":" + e.Branch, "ref": e.Branch, "sha": e.Revision, "repo": repo},
+				"base":      map[string]interface{}{"label": r.owner + ":" + e.BaseBranch, "ref": e.BaseBranch, "sha": SHA(r.url, e.BaseBranch), "repo": repo},
+				"merged":    false,
+			},
+			"repository":   repo,
+			"organization": map[string]interface{}{"login": r.owner, "url": "https://api.github.com/orgs/" + r.owner},
+			"sender":       user,
+		}
+	}
+	commit := map[string]interface{}{
+		"id":        e.Revision,
+		"tree_id":   SHA(e.Revision, "tree"),
+		"distinct":  true,
+		"message":   e.Message,
+		"timestamp": timestamp(),
+		"url":       r.url + "/commit/" + e.Revision,
+		"author":    map[string]interface{}{"name": e.Author, "email": e.Author + "@example.com", "username": e.Author},
+		"committer": map[string]interface{}{"name": e.Author, "email": e.Author + "@example.com", "username": e.Author},
+		"added":     []string{},
+		"removed":   []string{},
+		"modified":  []string{"README.md"},
+	}
+	return map[string]interface{}{
+		"ref":         e.ref(),
+		"before":      zeroSHA,
+		"after":       e.Revision,
+		"created":     e.Event == TagEvent,
+		"deleted":     false,
+		"forced":      false,
+		"base_ref":    nil,
+		"compare":     r.url + "/compare/" + e.Revision,
+		"repository":  repo,
+		"pusher":      map[string]interface{}{"name": e.Author, "email": e.Author + "@example.com"},
+		"sender":      user,
+		"commits":     []interface{}{commit},
+		"head_commit": commit,
+	}
+}
+
+func gitlabPayload(e WebhookEvent, r repository) map[string]interface{} {
+	project := map[string]interface{}{
+		"id":                  1,
+		"name":                r.name,
+		"namespace":           r.owner,
+		"path_with_namespace": r.fullName,
+		"web_url":             r.url,
+		"homepage":            r.url,
+		"git_http_url":        r.cloneURL(),
+		"git_ssh_url":         r.sshURL(),
+		"http_url":            r.cloneURL(),
+		"ssh_url":             r.sshURL(),
+		"url":                 r.sshURL(),
+		"default_branch":      e.BaseBranch,
+	}
+	repo := map[string]interface{}{
+		"name":         r.name,
+		"url":          r.sshURL(),
+		"homepage":     r.url,
+		"git_http_url": r.cloneURL(),
+		"git_ssh_url":  r.sshURL(),
+	}
+	commit := map[string]interface{}{
+		"id":        e.Revision,
+		"message":   e.Message,
+		"title":     e.Title,
+		"timestamp": timestamp(),
+		"url":       r.url + "/-/commit/" + e.Revision,
+		"author":    map[string]interface{}{"name": e.Author, "email": e.Author + "@example.com"},
+		"added":     []string{},
+		"modified":  []string{"README.md"},
+		"removed":   []string{},
+	}
+	if e.Event == PullRequestEvent {
+		return map[string]interface{}{
+			"object_kind": "merge_request",
+			"event_type":  "merge_request",
+			"user":        map[string]interface{}{"name": e.Author, "username": e.Author, "email": e.Author + "@example.com"},
+			"project":     project,
+			"repository":  repo,
+			"object_attributes": map[string]interface{}{
+				"id":            e.Number,
+				"iid":           e.Number,
+				"title":         e.Title,
+				"description":   e.Message,
+				"state":         "opened",
+				"action":        e.action("open"),
+				"source_branch": e.Branch,
+				"target_branch": e.BaseBranch,
+				"url":           fmt.Sprintf("%s/-/merge_requests/%d", r.url, e.Number),
+				"last_commit":   commit,
+				"source":        project,
+				"target":        project,
+			},
+		}
+	}
+	kind := "push"
+	if e.Event == TagEvent {
+		kind = "tag_push"
+	}
+	return map[string]interface{}{
+		"object_kind":         kind,
+		"event_name":          kind,
+		"before":              zeroSHA,
+		"after":               e.Revision,
+		"ref":                 e.ref(),
+		"checkout_sha":        e.Revision,
+		"user_name":           e.Author,
+		"user_username":       e.Author,
+		"user_email":          e.Author + "@example.com",
+		"project_id":          1,
+		"project":             project,
+		"repository":          repo,
+		"commits":             []interface{}{commit},
+		"total_commits_count": 1,
+	}
+}
+
+func bitbucketPayload(e WebhookEvent, r repository) map[string]interface{} {
+	actor := map[string]interface{}{"name": e.Author, "displayName": e.Author, "emailAddress": e.Author + "@example.com"}
+	repo := map[string]interface{}{
+		"slug":    r.name,
+		"name":    r.name,
+		"project": map[string]interface{}{"key": strings.ToUpper(r.owner), "name": r.owner},
+		"links": map[string]interface{}{
+			"clone": []interface{}{
+				map[string]interface{}{"href": r.cloneURL(), "name": "http"},
+				map[string]interface{}{"href": "ssh://git@" + r.host + "/" + r.fullName + ".git", "name": "ssh"},
+			},
+			"self": []interface{}{map[string]interface{}{"href": r.url + "/browse"}},
+		},
+	}
+	if e.Event == PullRequestEvent {
+		ref := func(branch, sha string) map[string]interface{} {
+			return map[string]interface{}{"id": "refs/heads/" + branch, "displayId": branch, "latestCommit": sha, "repository": repo}
+		}
+		return map[string]interface{}{
+			"eventKey": "pr:" + e.action("opened"),
+			"date":     timestamp(),
+			"actor":    actor,
+			"pullRequest": map[string]interface{}{
+				"id":          e.Number,
+				"title":       e.Title,
+				"description": e.Message,
+				"state":       "OPEN",
+				"author":      map[string]interface{}{"user": actor},
+				"fromRef":     ref(e.Branch, e.Revision),
+				"toRef":       ref(e.BaseBranch, SHA(r.url, e.BaseBranch)),
+				"links":       map[string]interface{}{"self": []interface{}{map[string]interface{}{"href": fmt.Sprintf("%s/pull-requests/%d", r.url, e.Number)}}},
+			},
+		}
+	}
+	refType, displayID := "BRANCH", e.Branch
+	if e.Event == TagEvent {
+		refType, displayID = "TAG", e.Tag
+	}
+	return map[string]interface{}{
+		"eventKey":   "repo:refs_changed",
+		"date":       timestamp(),
+		"actor":      actor,
+		"repository": repo,
+		"changes": []interface{}{map[string]interface{}{
+			"ref":      map[string]interface{}{"id": e.ref(), "displayId": displayID, "type": refType},
+			"refId":    e.ref(),
+			"fromHash": zeroSHA,
+			"toHash":   e.Revision,
+			"type":     "UPDATE",
+		}},
+	}
+}
+
+// SHA returns a commit id derived from the values, the same for the same values
+func SHA(values ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(values, "\x00"))) //nolint:gosec // see import
+	return hex.EncodeToString(sum[:])
+}
+
+// RenderPayload executes the Go template of the payload file (path relative to
+// the repository) with the variables, e.g. {{ .branch }} or
+// {{ index . "repo-url" }}, and checks that it renders JSON. Templates also
+// get the functions sha (see SHA) and json, quoting a value as JSON.
+func RenderPayload(file string, vars map[string]interface{}) ([]byte, error) {
+	content, err := os.ReadFile(config.Path(file))
+	if err != nil {
+		return nil, fmt.Errorf("could not load payload template %s: %v", file, err)
+	}
+	tmpl, err := template.New(file).Option("missingkey=error").Funcs(template.FuncMap{
+		"sha": SHA,
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template %s: %v", file, err)
+	}
+	var payload bytes.Buffer
+	if err := tmpl.Execute(&payload, vars); err != nil {
+		return nil, fmt.Errorf("failed to render payload template %s: %v", file, err)
+	}
+	if !json.Valid(payload.Bytes()) {
+		return nil, fmt.Errorf("payload template %s did not render JSON:\n%s", file, payload.String())
+	}
+	return payload.Bytes(), nil
+}
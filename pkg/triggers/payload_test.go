@@ -0,0 +1,234 @@
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// field returns the value at the dotted path of the JSON payload, list
+// elements being selected by their index
+func field(t *testing.T, payload []byte, path string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		t.Fatalf("invalid payload: %v\n%s", err, payload)
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(node) {
+				t.Fatalf("no element %s in %s of the payload", key, path)
+			}
+			v = node[i]
+		default:
+			t.Fatalf("no field %s in %s of the payload", key, path)
+		}
+	}
+	return v
+}
+
+func TestBuildPayload(t *testing.T) {
+	repoURL := "https://github.com/tektoncd/triggers"
+	revision := "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name            string
+		event           WebhookEvent
+		headerEventType string
+		want            map[string]interface{}
+		wantErr         string
+	}{{
+		name:            "github push",
+		event:           WebhookEvent{Provider: "github", Event: "push", RepoURL: repoURL + ".git"},
+		headerEventType: "push",
+		want: map[string]interface{}{
+			"ref":                  "refs/heads/main",
+			"after":                SHA(repoURL, "refs/heads/main", "Update README.md"),
+			"created":              false,
+			"repository.full_name": "tektoncd/triggers",
+			"repository.clone_url": repoURL + ".git",
+			"head_commit.message":  "Update README.md",
+		},
+	}, {
+		name:            "github tag",
+		event:           WebhookEvent{Provider: "github", Event: "tag", RepoURL: repoURL, Tag: "v1.0.0", Revision: revision},
+		headerEventType: "push",
+		want: map[string]interface{}{
+			"ref":     "refs/tags/v1.0.0",
+			"after":   revision,
+			"created": true,
+		},
+	}, {
+		name:            "github pull request",
+		event:           WebhookEvent{Provider: "GitHub", Event: "pr", RepoURL: repoURL, Branch: "feature-1", Revision: revision, Number: 42},
+		headerEventType: "pull_request",
+		want: map[string]interface{}{
+			"action":                         "opened",
+			"number":                         float64(42),
+			"pull_request.head.ref":          "feature-1",
+			"pull_request.head.sha":          revision,
+			"pull_request.base.ref":          "main",
+			"pull_request.title":             "Update README.md",
+			"pull_request.head.repo.ssh_url": "git@github.com:tektoncd/triggers.git",
+		},
+	}, {
+		name:            "gitlab push",
+		event:           WebhookEvent{Provider: "gitlab", Event: "Push Hook", RepoURL: repoURL, Branch: "release-1.0", Revision: revision},
+		headerEventType: "Push Hook",
+		want: map[string]interface{}{
+			"object_kind":                 "push",
+			"ref":                         "refs/heads/release-1.0",
+			"checkout_sha":                revision,
+			"project.git_http_url":        repoURL + ".git",
+			"repository.git_http_url":     repoURL + ".git",
+			"project.path_with_namespace": "tektoncd/triggers",
+		},
+	}, {
+		name:            "gitlab tag",
+		event:           WebhookEvent{Provider: "gitlab", Event: "tag", RepoURL: repoURL},
+		headerEventType: "Tag Push Hook",
+		want: map[string]interface{}{
+			"object_kind": "tag_push",
+			"ref":         "refs/tags/v0.1.0",
+		},
+	}, {
+		name:            "gitlab merge request",
+		event:           WebhookEvent{Provider: "gitlab", Event: "merge_request", RepoURL: repoURL, Branch: "feature-1", Action: "update"},
+		headerEventType: "Merge Request Hook",
+		want: map[string]interface{}{
+			"object_kind":                      "merge_request",
+			"object_attributes.action":         "update",
+			"object_attributes.source_branch":  "feature-1",
+			"object_attributes.target_branch":  "main",
+			"object_attributes.last_commit.id": SHA(repoURL, "refs/heads/feature-1", "Update README.md"),
+		},
+	}, {
+		name:            "bitbucket push",
+		event:           WebhookEvent{Provider: "bitbucket", Event: "push", RepoURL: "http://localhost:7990/scm/~test/helloworld", Branch: "release-1.0"},
+		headerEventType: "refs_changed",
+		want: map[string]interface{}{
+			"eventKey":                      "repo:refs_changed",
+			"changes.0.ref.displayId":       "release-1.0",
+			"changes.0.ref.type":            "BRANCH",
+			"repository.links.clone.0.href": "http://localhost:7990/scm/~test/helloworld.git",
+			"repository.links.clone.1.href": "ssh://git@localhost:7990/~test/helloworld.git",
+			"repository.project.key":        "~TEST",
+		},
+	}, {
+		name:            "bitbucket pull request",
+		event:           WebhookEvent{Provider: "bitbucket", Event: "pull_request", RepoURL: repoURL, Branch: "feature-1", Revision: revision, Action: "modified"},
+		headerEventType: "pr:modified",
+		want: map[string]interface{}{
+			"eventKey":                         "pr:modified",
+			"pullRequest.fromRef.displayId":    "feature-1",
+			"pullRequest.fromRef.latestCommit": revision,
+			"pullRequest.toRef.id":             "refs/heads/main",
+		},
+	}, {
+		name:    "unknown provider",
+		event:   WebhookEvent{Provider: "gitea", Event: "push"},
+		wantErr: `unknown provider "gitea"`,
+	}, {
+		name:    "unknown event",
+		event:   WebhookEvent{Provider: "github", Event: "issue_comment"},
+		wantErr: `unknown event "issue_comment"`,
+	}, {
+		name:    "invalid repository url",
+		event:   WebhookEvent{Provider: "github", Event: "push", RepoURL: "tektoncd/triggers"},
+		wantErr: `invalid repository url "tektoncd/triggers"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := BuildPayload(tt.event)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("BuildPayload() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildPayload() error = %v", err)
+			}
+			for path, want := range tt.want {
+				if got := field(t, payload, path); got != want {
+					t.Errorf("%s = %v, want %v", path, got, want)
+				}
+			}
+			if got := tt.event.HeaderEventType(); got != tt.headerEventType {
+				t.Errorf("HeaderEventType() = %q, want %q", got, tt.headerEventType)
+			}
+		})
+	}
+}
+
+func TestRenderPayload(t *testing.T) {
+	vars := func(omit string) map[string]interface{} {
+		v := map[string]interface{}{
+			"action":   "opened",
+			"number":   "42",
+			"title":    `Fix "quotes"`,
+			"branch":   "feature-1",
+			"repo_url": "https://github.com/openshift-pipelines/release-tests.git",
+		}
+		delete(v, omit)
+		return v
+	}
+	tests := []struct {
+		name    string
+		file    string
+		vars    map[string]interface{}
+		want    map[string]interface{}
+		wantErr string
+	}{{
+		name: "template",
+		file: "testdata/triggers/payloads/github-pull-request.json.tmpl",
+		vars: vars(""),
+		want: map[string]interface{}{
+			"action":                "opened",
+			"number":                float64(42),
+			"pull_request.title":    `Fix "quotes"`,
+			"pull_request.head.ref": "feature-1",
+			"pull_request.head.sha": SHA("https://github.com/openshift-pipelines/release-tests.git", "feature-1"),
+			"repository.clone_url":  "https://github.com/openshift-pipelines/release-tests.git",
+		},
+	}, {
+		name:    "missing variable",
+		file:    "testdata/triggers/payloads/github-pull-request.json.tmpl",
+		vars:    vars("branch"),
+		wantErr: `map has no entry for key "branch"`,
+	}, {
+		name:    "not json",
+		file:    "testdata/pvc.yaml",
+		vars:    vars(""),
+		wantErr: "did not render JSON",
+	}, {
+		name:    "missing template",
+		file:    "testdata/triggers/payloads/missing.json.tmpl",
+		vars:    vars(""),
+		wantErr: "could not load payload template",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := RenderPayload(tt.file, tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderPayload() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderPayload() error = %v", err)
+			}
+			for path, want := range tt.want {
+				if got := field(t, payload, path); fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%s = %v, want %v", path, got, want)
+				}
+			}
+		})
+	}
+}
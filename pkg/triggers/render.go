@@ -0,0 +1,459 @@
+package triggers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/bitbucket"
+	"github.com/tektoncd/triggers/pkg/interceptors/cel"
+	"github.com/tektoncd/triggers/pkg/interceptors/github"
+	"github.com/tektoncd/triggers/pkg/interceptors/gitlab"
+	"github.com/tektoncd/triggers/pkg/interceptors/slack"
+	"github.com/tektoncd/triggers/pkg/template"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// renderNamespace is the namespace of the triggers rendered without a cluster,
+// as seen by the interceptors
+const renderNamespace = "default"
+
+// TriggersResources are the Triggers resources, and the Secrets used by their
+// interceptors, loaded from manifests to render the triggers without a cluster
+type TriggersResources struct {
+	EventListeners         map[string]*triggersv1.EventListener
+	Triggers               map[string]*triggersv1.Trigger
+	TriggerBindings        map[string]*triggersv1.TriggerBinding
+	ClusterTriggerBindings map[string]*triggersv1.ClusterTriggerBinding
+	TriggerTemplates       map[string]*triggersv1.TriggerTemplate
+	Secrets                map[string]*corev1.Secret
+	// files where the resources were loaded from by kind/name
+	files map[string]string
+}
+
+// LoadTriggersResources loads the Triggers resources and Secrets of the
+// manifests, files or directories walked for .yaml files. Other resources are
+// ignored and a resource defined twice is an error.
+func LoadTriggersResources(paths ...string) (*TriggersResources, error) {
+	r := &TriggersResources{
+		EventListeners:         map[string]*triggersv1.EventListener{},
+		Triggers:               map[string]*triggersv1.Trigger{},
+		TriggerBindings:        map[string]*triggersv1.TriggerBinding{},
+		ClusterTriggerBindings: map[string]*triggersv1.ClusterTriggerBinding{},
+		TriggerTemplates:       map[string]*triggersv1.TriggerTemplate{},
+		Secrets:                map[string]*corev1.Secret{},
+		files:                  map[string]string{},
+	}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || (file != path && !strings.HasSuffix(file, ".yaml") && !strings.HasSuffix(file, ".yml")) {
+				return nil
+			}
+			return r.loadFile(file)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *TriggersResources) loadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer f.Close()
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		if err := r.load(file, doc); err != nil {
+			return fmt.Errorf("failed to load %s: %v", file, err)
+		}
+	}
+}
+
+func (r *TriggersResources) load(file string, doc []byte) error {
+	var meta struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		return err
+	}
+	if meta.Kind == "" {
+		return nil
+	}
+	group := strings.Split(meta.APIVersion, "/")[0]
+	if group != "triggers.tekton.dev" && !(meta.APIVersion == "v1" && meta.Kind == "Secret") {
+		return nil
+	}
+
+	key := meta.Kind + "/" + meta.Name
+	if previous, ok := r.files[key]; ok {
+		return fmt.Errorf("%s %s is also defined in %s", meta.Kind, meta.Name, previous)
+	}
+
+	var obj interface{}
+	switch meta.Kind {
+	case "EventListener":
+		el := &triggersv1.EventListener{}
+		r.EventListeners[meta.Name], obj = el, el
+	case "Trigger":
+		t := &triggersv1.Trigger{}
+		r.Triggers[meta.Name], obj = t, t
+	case "TriggerBinding":
+		tb := &triggersv1.TriggerBinding{}
+		r.TriggerBindings[meta.Name], obj = tb, tb
+	case "ClusterTriggerBinding":
+		ctb := &triggersv1.ClusterTriggerBinding{}
+		r.ClusterTriggerBindings[meta.Name], obj = ctb, ctb
+	case "TriggerTemplate":
+		tt := &triggersv1.TriggerTemplate{}
+		r.TriggerTemplates[meta.Name], obj = tt, tt
+	case "Secret":
+		s := &corev1.Secret{}
+		r.Secrets[meta.Name], obj = s, s
+	default:
+		return nil
+	}
+	r.files[key] = file
+	return yaml.Unmarshal(doc, obj)
+}
+
+// TriggerEvent is the request posted to an EventListener
+type TriggerEvent struct {
+	Body   []byte
+	Header http.Header
+}
+
+// RenderedTrigger is what a trigger of an EventListener makes of an event
+type RenderedTrigger struct {
+	Name string
+	// Continue is false when an interceptor stopped processing the event,
+	// Reason is then the message of the interceptor
+	Continue   bool
+	Reason     string
+	Extensions map[string]interface{}
+	Params     []triggersv1.Param
+	Resources  []*unstructured.Unstructured
+}
+
+// Param returns the value of the resolved param of the trigger
+func (t RenderedTrigger) Param(name string) (string, bool) {
+	for _, p := range t.Params {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// Resource returns the rendered resource of the kind called name, or nil
+func (t RenderedTrigger) Resource(kind, name string) *unstructured.Unstructured {
+	for _, res := range t.Resources {
+		if res.GetKind() == kind && (res.GetName() == name || res.GetGenerateName() == name) {
+			return res
+		}
+	}
+	return nil
+}
+
+// Render processes the event like the sink of the EventListener does, through
+// the interceptors, bindings and template of each of its triggers, and returns
+// what each trigger rendered without creating anything. The core interceptors
+// (cel, github, gitlab, bitbucket and slack) run in process, with the Secrets
+// of the resources.
+func (r *TriggersResources) Render(eventListener string, event TriggerEvent) ([]RenderedTrigger, error) {
+	el, ok := r.EventListeners[eventListener]
+	if !ok {
+		return nil, fmt.Errorf("eventlistener %s not found", eventListener)
+	}
+	if len(el.Spec.TriggerGroups) > 0 {
+		return nil, fmt.Errorf("eventlistener %s has trigger groups, which cannot be rendered", eventListener)
+	}
+	triggers, err := r.eventListenerTriggers(el)
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered []RenderedTrigger
+	for _, t := range triggers {
+		rt, err := r.renderTrigger(t, event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render trigger %s of eventlistener %s: %v", t.Name, eventListener, err)
+		}
+		rendered = append(rendered, rt)
+	}
+	return rendered, nil
+}
+
+// eventListenerTriggers returns the triggers of the EventListener, embedded,
+// referenced or selected by labels
+func (r *TriggersResources) eventListenerTriggers(el *triggersv1.EventListener) ([]triggersv1.Trigger, error) {
+	var triggers []triggersv1.Trigger
+	for _, t := range el.Spec.Triggers {
+		switch {
+		case t.Template == nil && t.TriggerRef != "":
+			trigger, ok := r.Triggers[t.TriggerRef]
+			if !ok {
+				return nil, fmt.Errorf("trigger %s not found", t.TriggerRef)
+			}
+			triggers = append(triggers, *trigger)
+		case t.Template != nil:
+			triggers = append(triggers, triggersv1.Trigger{
+				ObjectMeta: metav1.ObjectMeta{Name: t.Name},
+				Spec: triggersv1.TriggerSpec{
+					Bindings:     t.Bindings,
+					Template:     *t.Template,
+					Interceptors: t.Interceptors,
+				},
+			})
+		default:
+			return nil, fmt.Errorf("trigger %s of eventlistener %s has neither a template nor a triggerRef", t.Name, el.Name)
+		}
+	}
+	if el.Spec.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(el.Spec.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range r.Triggers {
+			if selector.Matches(labels.Set(t.Labels)) {
+				triggers = append(triggers, *t)
+			}
+		}
+	}
+	return triggers, nil
+}
+
+func (r *TriggersResources) renderTrigger(t triggersv1.Trigger, event TriggerEvent) (RenderedTrigger, error) {
+	rendered := RenderedTrigger{Name: t.Name}
+	eventID := template.UUID()
+
+	resp, err := r.runInterceptors(t, event, eventID)
+	if err != nil {
+		return rendered, err
+	}
+	rendered.Extensions = resp.Extensions
+	if !resp.Continue {
+		if resp.Status.Message != "" {
+			rendered.Reason = resp.Status.Message
+		} else {
+			rendered.Reason = resp.Status.Code.String()
+		}
+		return rendered, nil
+	}
+	rendered.Continue = true
+
+	resolved, err := template.ResolveTrigger(t, r.getTriggerBinding, r.getClusterTriggerBinding, r.getTriggerTemplate)
+	if err != nil {
+		return rendered, err
+	}
+	rendered.Params, err = template.ResolveParams(resolved, event.Body, event.Header, resp.Extensions, template.NewTriggerContext(eventID))
+	if err != nil {
+		return rendered, err
+	}
+	for _, raw := range template.ResolveResources(resolved.TriggerTemplate, rendered.Params) {
+		res := &unstructured.Unstructured{}
+		if err := json.Unmarshal(raw, &res.Object); err != nil {
+			return rendered, fmt.Errorf("failed to decode rendered resource %s: %v", raw, err)
+		}
+		rendered.Resources = append(rendered.Resources, res)
+	}
+	return rendered, nil
+}
+
+// runInterceptors executes the interceptors of the trigger in order, each
+// getting the extensions added by the previous ones, like the sink does
+func (r *TriggersResources) runInterceptors(t triggersv1.Trigger, event TriggerEvent, eventID string) (*triggersv1.InterceptorResponse, error) {
+	request := triggersv1.InterceptorRequest{
+		Body:       string(event.Body),
+		Header:     event.Header.Clone(),
+		Extensions: map[string]interface{}{},
+		Context: &triggersv1.TriggerContext{
+			EventURL:  "http://localhost",
+			EventID:   eventID,
+			TriggerID: fmt.Sprintf("namespaces/%s/triggers/%s", renderNamespace, t.Name),
+		},
+	}
+	for _, i := range t.Spec.Interceptors {
+		if i.Webhook != nil {
+			return nil, fmt.Errorf("webhook interceptor %s cannot run without a cluster", i.GetName())
+		}
+		interceptor, err := r.coreInterceptor(i.GetName())
+		if err != nil {
+			return nil, err
+		}
+		request.InterceptorParams = interceptors.GetInterceptorParams(i)
+		resp := interceptor.Process(context.Background(), &request)
+		if !resp.Continue {
+			return resp, nil
+		}
+		for k, v := range resp.Extensions {
+			request.Extensions[k] = v
+		}
+	}
+	return &triggersv1.InterceptorResponse{Continue: true, Extensions: request.Extensions}, nil
+}
+
+// coreInterceptor returns the interceptor shipped with Triggers called name
+func (r *TriggersResources) coreInterceptor(name string) (triggersv1.InterceptorInterface, error) {
+	sg := resourcesSecretGetter(r.Secrets)
+	switch name {
+	case "cel":
+		return cel.NewInterceptor(sg), nil
+	case "github":
+		return github.NewInterceptor(sg), nil
+	case "gitlab":
+		return gitlab.NewInterceptor(sg), nil
+	case "bitbucket":
+		return bitbucket.NewInterceptor(sg), nil
+	case "slack":
+		return slack.NewInterceptor(sg), nil
+	default:
+		return nil, fmt.Errorf("interceptor %s is not a core interceptor and cannot run without a cluster", name)
+	}
+}
+
+func (r *TriggersResources) getTriggerBinding(name string) (*triggersv1.TriggerBinding, error) {
+	if tb, ok := r.TriggerBindings[name]; ok {
+		return tb, nil
+	}
+	return nil, fmt.Errorf("triggerbinding %s not found", name)
+}
+
+func (r *TriggersResources) getClusterTriggerBinding(name string) (*triggersv1.ClusterTriggerBinding, error) {
+	if ctb, ok := r.ClusterTriggerBindings[name]; ok {
+		return ctb, nil
+	}
+	return nil, fmt.Errorf("clustertriggerbinding %s not found", name)
+}
+
+func (r *TriggersResources) getTriggerTemplate(name string) (*triggersv1.TriggerTemplate, error) {
+	if tt, ok := r.TriggerTemplates[name]; ok {
+		return tt, nil
+	}
+	return nil, fmt.Errorf("triggertemplate %s not found", name)
+}
+
+// resourcesSecretGetter gets the secrets of the interceptors from the loaded Secrets
+type resourcesSecretGetter map[string]*corev1.Secret
+
+func (s resourcesSecretGetter) Get(_ context.Context, _ string, sr *triggersv1.SecretRef) ([]byte, error) {
+	secret, ok := s[sr.SecretName]
+	if !ok {
+		return nil, fmt.Errorf("secret %s not found", sr.SecretName)
+	}
+	if value, ok := secret.StringData[sr.SecretKey]; ok {
+		return []byte(value), nil
+	}
+	if value, ok := secret.Data[sr.SecretKey]; ok {
+		return value, nil
+	}
+	return nil, fmt.Errorf("key %s not found in secret %s", sr.SecretKey, sr.SecretName)
+}
+
+// RenderEventListener loads the resources of the manifests (paths relative to
+// the repository) and renders the triggers of the EventListener for the payload
+// posted with the headers of the interceptor and event type, see EventHeaders
+func RenderEventListener(eventListener string, manifests []string, interceptor, eventType, payload string) ([]RenderedTrigger, error) {
+	body, err := os.ReadFile(config.Path(payload))
+	if err != nil {
+		return nil, fmt.Errorf("could not load test data from file %s: %v", payload, err)
+	}
+	return RenderEventListenerPayload(eventListener, manifests, interceptor, eventType, body)
+}
+
+// RenderEventListenerPayload is RenderEventListener for a payload built by
+// BuildPayload or RenderPayload
+func RenderEventListenerPayload(eventListener string, manifests []string, interceptor, eventType string, body []byte) ([]RenderedTrigger, error) {
+	paths := make([]string, len(manifests))
+	for i, m := range manifests {
+		paths[i] = config.Path(m)
+	}
+	resources, err := LoadTriggersResources(paths...)
+	if err != nil {
+		return nil, err
+	}
+	header, err := EventHeaders(interceptor, eventType, body)
+	if err != nil {
+		return nil, err
+	}
+	return resources.Render(eventListener, TriggerEvent{Body: body, Header: header})
+}
+
+// AssertRenderedParams checks the params (spec.params) of the resource of the
+// kind called name, e.g. a PipelineRun, rendered by one of the triggers
+func AssertRenderedParams(rendered []RenderedTrigger, kind, name string, want map[string]string) {
+	var res *unstructured.Unstructured
+	for _, t := range rendered {
+		if res = t.Resource(kind, name); res != nil {
+			break
+		}
+	}
+	if res == nil {
+		reporter.Default().Fail(fmt.Errorf("no trigger rendered %s %s", kind, name))
+		return
+	}
+	params, _, err := unstructured.NestedSlice(res.Object, "spec", "params")
+	if err != nil {
+		reporter.Default().Fail(fmt.Errorf("invalid params of rendered %s %s: %v", kind, name, err))
+	}
+	got := map[string]string{}
+	for _, p := range params {
+		if param, ok := p.(map[string]interface{}); ok {
+			got[fmt.Sprint(param["name"])] = fmt.Sprint(param["value"])
+		}
+	}
+	for param, value := range want {
+		if got[param] != value {
+			reporter.Default().Errorf("param %s of rendered %s %s is %q, expected %q", param, kind, name, got[param], value)
+		}
+	}
+}
+
+// AssertTriggerStopped checks that the interceptors of the trigger stopped
+// processing the event
+func AssertTriggerStopped(rendered []RenderedTrigger, trigger string) {
+	for _, t := range rendered {
+		if t.Name != trigger {
+			continue
+		}
+		if t.Continue {
+			reporter.Default().Errorf("interceptors of trigger %s did not stop the event, it rendered %d resources", trigger, len(t.Resources))
+		} else {
+			log.Printf("Interceptors of trigger %s stopped the event: %s", trigger, t.Reason)
+		}
+		return
+	}
+	reporter.Default().Fail(fmt.Errorf("trigger %s not found", trigger))
+}
@@ -0,0 +1,238 @@
+package triggers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLoadTriggersResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		want    map[string][]string
+		wantErr string
+	}{{
+		name:  "directory",
+		paths: []string{"testdata/triggers/triggersCRD"},
+		want: map[string][]string{
+			"EventListener":   {"listener-triggerref"},
+			"Trigger":         {"trigger"},
+			"TriggerBinding":  {"github-pr-binding"},
+			"TriggerTemplate": {"github-template"},
+		},
+	}, {
+		name: "files with several documents and secrets",
+		paths: []string{
+			"testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml",
+			"testdata/triggers/bitbucket/secret.yaml",
+		},
+		want: map[string][]string{
+			"EventListener":   {"bitbucket-listener"},
+			"TriggerBinding":  {"bitbucket-binding"},
+			"TriggerTemplate": {"bitbucket-template"},
+			"Secret":          {"bitbucket-secret"},
+		},
+	}, {
+		name: "defined twice",
+		paths: []string{
+			"testdata/triggers/triggersCRD/trigger.yaml",
+			"testdata/triggers/triggersCRD",
+		},
+		wantErr: "Trigger trigger is also defined in",
+	}, {
+		name:    "missing",
+		paths:   []string{"testdata/triggers/missing.yaml"},
+		wantErr: "no such file or directory",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := make([]string, len(tt.paths))
+			for i, p := range tt.paths {
+				paths[i] = config.Path(p)
+			}
+			r, err := LoadTriggersResources(paths...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadTriggersResources() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTriggersResources() error = %v", err)
+			}
+			got := map[string][]string{}
+			add := func(kind string, names []string) {
+				if len(names) > 0 {
+					got[kind] = slices.Sorted(slices.Values(names))
+				}
+			}
+			add("EventListener", slices.Collect(maps.Keys(r.EventListeners)))
+			add("Trigger", slices.Collect(maps.Keys(r.Triggers)))
+			add("TriggerBinding", slices.Collect(maps.Keys(r.TriggerBindings)))
+			add("ClusterTriggerBinding", slices.Collect(maps.Keys(r.ClusterTriggerBindings)))
+			add("TriggerTemplate", slices.Collect(maps.Keys(r.TriggerTemplates)))
+			add("Secret", slices.Collect(maps.Keys(r.Secrets)))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("LoadTriggersResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// renderedParams returns the params of the resource rendered by one of the
+// triggers
+func renderedParams(t *testing.T, rendered []RenderedTrigger, kind, name string) map[string]string {
+	t.Helper()
+	for _, rt := range rendered {
+		res := rt.Resource(kind, name)
+		if res == nil {
+			continue
+		}
+		params, _, err := unstructured.NestedSlice(res.Object, "spec", "params")
+		if err != nil {
+			t.Fatalf("invalid params of rendered %s %s: %v", kind, name, err)
+		}
+		got := map[string]string{}
+		for _, p := range params {
+			param := p.(map[string]interface{})
+			got[fmt.Sprint(param["name"])] = fmt.Sprint(param["value"])
+		}
+		return got
+	}
+	t.Fatalf("no trigger rendered %s %s", kind, name)
+	return nil
+}
+
+func TestRenderEventListener(t *testing.T) {
+	triggerRef := []string{
+		"testdata/triggers/triggersCRD/eventlistener-triggerref.yaml",
+		"testdata/triggers/triggersCRD/trigger.yaml",
+		"testdata/triggers/triggersCRD/triggerbindings.yaml",
+		"testdata/triggers/triggersCRD/triggertemplate.yaml",
+	}
+	bitbucket := []string{
+		"testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml",
+		"testdata/triggers/bitbucket/secret.yaml",
+	}
+	tests := []struct {
+		name          string
+		eventListener string
+		manifests     []string
+		interceptor   string
+		eventType     string
+		payload       string
+		kind          string
+		resource      string
+		want          map[string]string
+		stopped       string
+		wantErr       string
+	}{{
+		name:          "embedded and referenced bindings",
+		eventListener: "listener-embed-binding",
+		manifests: []string{
+			"testdata/triggers/eventlisteners/eventlistener-embeded-binding.yaml",
+			"testdata/triggers/triggerbindings/triggerbinding.yaml",
+			"testdata/triggers/triggertemplate/triggertemplate.yaml",
+		},
+		interceptor: "github",
+		eventType:   "push",
+		payload:     "testdata/push.json",
+		kind:        "PipelineRun",
+		resource:    "simple-pipeline-run",
+		want: map[string]string{
+			"message":     "Hello from the Triggers EventListener(listener-embed-binding)!",
+			"contenttype": "application/json",
+		},
+	}, {
+		name:          "cel overlays of a trigger",
+		eventListener: "listener-triggerref",
+		manifests:     triggerRef,
+		interceptor:   "github",
+		eventType:     "pull_request",
+		payload:       "testdata/triggers/triggersCRD/pull-request.json",
+		kind:          "PipelineRun",
+		resource:      "parallel-pipelinerun",
+		want:          map[string]string{"message": "28911bb"},
+	}, {
+		name:          "filtered out by cel",
+		eventListener: "listener-triggerref",
+		manifests:     triggerRef,
+		interceptor:   "github",
+		eventType:     "push",
+		payload:       "testdata/triggers/triggersCRD/pull-request.json",
+		stopped:       "trigger",
+	}, {
+		name:          "signed bitbucket event",
+		eventListener: "bitbucket-listener",
+		manifests:     bitbucket,
+		interceptor:   "bitbucket",
+		eventType:     "refs_changed",
+		payload:       "testdata/triggers/bitbucket/refs-change-event.json",
+		kind:          "TaskRun",
+		resource:      "bitbucket-run",
+		want: map[string]string{
+			"url":      "http://localhost:7990/scm/~test/helloworld.git",
+			"revision": "master",
+		},
+	}, {
+		name:          "event type not accepted by bitbucket",
+		eventListener: "bitbucket-listener",
+		manifests:     bitbucket,
+		interceptor:   "bitbucket",
+		eventType:     "pr:opened",
+		payload:       "testdata/triggers/bitbucket/refs-change-event.json",
+		stopped:       "bitbucket-triggers",
+	}, {
+		name:          "unknown eventlistener",
+		eventListener: "missing",
+		manifests:     bitbucket,
+		interceptor:   "bitbucket",
+		eventType:     "refs_changed",
+		payload:       "testdata/triggers/bitbucket/refs-change-event.json",
+		wantErr:       "eventlistener missing not found",
+	}, {
+		name:          "unknown interceptor",
+		eventListener: "bitbucket-listener",
+		manifests:     bitbucket,
+		interceptor:   "gitea",
+		eventType:     "push",
+		payload:       "testdata/triggers/bitbucket/refs-change-event.json",
+		wantErr:       "valid event_listener type",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := RenderEventListener(tt.eventListener, tt.manifests, tt.interceptor, tt.eventType, tt.payload)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderEventListener() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderEventListener() error = %v", err)
+			}
+			if tt.stopped != "" {
+				i := slices.IndexFunc(rendered, func(rt RenderedTrigger) bool { return rt.Name == tt.stopped })
+				if i < 0 {
+					t.Fatalf("trigger %s not rendered", tt.stopped)
+				}
+				if rendered[i].Continue {
+					t.Errorf("trigger %s rendered %d resources, want it stopped", tt.stopped, len(rendered[i].Resources))
+				}
+				return
+			}
+			got := renderedParams(t, rendered, tt.kind, tt.resource)
+			for param, value := range tt.want {
+				if got[param] != value {
+					t.Errorf("param %s of rendered %s %s = %q, want %q", param, tt.kind, tt.resource, got[param], value)
+				}
+			}
+		})
+	}
+}
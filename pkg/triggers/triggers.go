@@ -3,23 +3,27 @@ package triggers
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
 
-	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	resource "github.com/openshift-pipelines/release-tests/pkg/config"
+	"github.com/openshift-pipelines/release-tests/pkg/expose"
 	"github.com/openshift-pipelines/release-tests/pkg/opc"
+	"github.com/openshift-pipelines/release-tests/pkg/pki"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
+	"github.com/openshift-pipelines/release-tests/pkg/reporter"
 	"github.com/openshift-pipelines/release-tests/pkg/wait"
 	"github.com/tektoncd/pipeline/pkg/names"
 	eventReconciler "github.com/tektoncd/triggers/pkg/reconciler/eventlistener"
@@ -31,36 +35,38 @@ import (
 
 func getServiceNameAndPort(c *clients.Clients, elname, namespace string) (string, string) {
 	// Verify the EventListener to be ready
-	err := wait.WaitFor(c.Ctx, wait.EventListenerReady(c, namespace, elname))
+	err := wait.WaitForEventListenerReady(c, namespace, elname)
 	if err != nil {
-		testsuit.T.Errorf("event listener %s in namespace %s not ready \n %v", elname, namespace, err)
+		c.Reporter().Errorf("event listener %s in namespace %s not ready \n %v", elname, namespace, err)
 	}
 
 	labelSelector := fields.SelectorFromSet(resources.GenerateLabels(elname, resources.DefaultStaticResourceLabels)).String()
 	// Grab EventListener sink pods
 	sinkPods, err := c.KubeClient.Kube.CoreV1().Pods(namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
-		testsuit.T.Errorf("failed to list event listener %s sink pods in namespace %s \n %v", elname, namespace, err)
+		c.Reporter().Errorf("failed to list event listener %s sink pods in namespace %s \n %v", elname, namespace, err)
 	}
 
 	log.Printf("sinkpod name: %s", sinkPods.Items[0].Name)
 
 	serviceList, err := c.KubeClient.Kube.CoreV1().Services(namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
-		testsuit.T.Errorf("failed to list services with label selector %s in namespace %s \n %v", labelSelector, namespace, err)
+		c.Reporter().Errorf("failed to list services with label selector %s in namespace %s \n %v", labelSelector, namespace, err)
 	}
 	return serviceList.Items[0].Name, serviceList.Items[0].Spec.Ports[0].Name
 }
 
 func ExposeEventListner(c *clients.Clients, elname, namespace string) string {
 	if _, err := opc.VerifyResourceListMatchesName("eventlistener", elname, namespace); err != nil {
-		testsuit.T.Errorf("%v", err)
+		c.Reporter().Errorf("%v", err)
 	}
 
-	svcName, _ := getServiceNameAndPort(c, elname, namespace)
-	cmd.MustSucceed("oc", "expose", "service", svcName, "-n", namespace)
-
-	return GetRoute(elname, namespace)
+	svcName, portName := getServiceNameAndPort(c, elname, namespace)
+	elURL, err := expose.Current().Expose(c, expose.Service{Namespace: namespace, Name: svcName, Port: portName})
+	if err != nil {
+		c.Reporter().Fail(err)
+	}
+	return elURL
 }
 
 func ExposeDeploymentConfig(c *clients.Clients, elname, port, namespace string) string {
@@ -71,54 +77,31 @@ func ExposeDeploymentConfig(c *clients.Clients, elname, port, namespace string)
 }
 
 func ExposeEventListenerForTLS(c *clients.Clients, elname, namespace string) string {
-	svcName, portName := getServiceNameAndPort(c, elname, namespace)
-	domain := getDomain()
-	cmd.MustSucceed("mkdir", "-p", resource.Path("testdata/triggers/certs")).Stdout()
-
-	caKey := resource.Path("testdata/triggers/certs/ca.key")
-	caCrt := resource.Path("testdata/triggers/certs/ca.crt")
-	serverKey := resource.Path("testdata/triggers/certs/server.key")
-	serverCrt := resource.Path("testdata/triggers/certs/server.crt")
-	serverCsr := resource.Path("testdata/triggers/certs/server.csr")
-	serverExt := resource.Path("testdata/triggers/certs/server.ext")
-
-	// first 3 files can be reused so they are committed in git repository
-	if _, err := os.Stat(caKey); errors.Is(err, os.ErrNotExist) {
-		log.Println("Generating ca.key")
-		cmd.MustSucceed("openssl", "genrsa", "-out", caKey, "4096").Stdout()
-	}
-
-	if _, err := os.Stat(caCrt); errors.Is(err, os.ErrNotExist) {
-		log.Println("Generating ca.crt")
-		cmd.MustSucceed("openssl", "req", "-x509", "-new", "-nodes", "-key", caKey,
-			"-sha256", "-days", "4096", "-out", caCrt, "-subj",
-			"/C=IN/ST=Kar/L=Blr/O=RedHat").Stdout()
+	if expose.Current().Name() != expose.RouteMode {
+		// the TLS connection goes straight to the sink, which serves the
+		// certificate of its service
+		svcName, portName := getServiceNameAndPort(c, elname, namespace)
+		elURL, err := expose.Current().Expose(c, expose.Service{Namespace: namespace, Name: svcName, Port: portName, TLS: true})
+		if err != nil {
+			c.Reporter().Fail(err)
+		}
+		return elURL
 	}
-
-	if _, err := os.Stat(serverKey); errors.Is(err, os.ErrNotExist) {
-		log.Println("Generating server.key")
-		cmd.MustSucceed("openssl", "genrsa", "-out", serverKey, "4096").Stdout()
+	if !platform.Current().Supports(platform.Routes) {
+		c.Reporter().Fail(fmt.Errorf("TLS EventListeners are exposed with reencrypt Routes, which %s does not support", platform.Current().Name()))
+		return ""
 	}
+	svcName, portName := getServiceNameAndPort(c, elname, namespace)
+	domain := getDomain()
 
-	// other files depend on domain name which changes for every test cluster
-	log.Println("Generating server.csr")
-	cmd.MustSucceed("openssl", "req", "-new", "-key", serverKey, "-out", serverCsr,
-		"-subj", fmt.Sprintf("/C=IN/ST=Kar/L=Blr/O=RedHat/CN=%s", domain)).Stdout()
-
-	extData := fmt.Sprintf("authorityKeyIdentifier=keyid,issuer\nbasicConstraints=CA:FALSE\nkeyUsage = digitalSignature, nonRepudiation, keyEncipherment, dataEncipherment\n"+
-		"subjectAltName = @alt_names\n\n\n[alt_names]\nDNS.1 = %s\n", domain)
-
-	log.Println("Generating server.ext")
-	err := os.WriteFile(serverExt, []byte(extData), 0600)
+	// the certificates are issued for every scenario, as the domain changes
+	// for every test cluster, and written to the certs directory of the scenario
+	log.Printf("Issuing the certificate of %s", domain)
+	caCrt, serverCrt, serverKey, err := issueRouteCertificates(certsDir(), domain)
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
 
-	log.Println("Generating server.crt")
-	cmd.MustSucceed("openssl", "x509", "-req", "-in", serverCsr, "-CA", caCrt, "-CAkey",
-		caKey, "-CAcreateserial", "-out", serverCrt,
-		"-days", "4096", "-sha256", "-extfile", serverExt).Stdout()
-
 	log.Println("Creating route")
 	routeName := cmd.MustSucceed("oc", "create", "route", "reencrypt", "--ca-cert="+caCrt,
 		"--cert="+serverCrt, "--key="+serverKey,
@@ -131,6 +114,38 @@ func ExposeEventListenerForTLS(c *clients.Clients, elname, namespace string) str
 	return strings.Trim(route_url, "'")
 }
 
+// issueRouteCertificates writes to dir a new CA (ca.crt), the certificate of
+// the route for domain signed by the CA (server.crt, server.key) and the client
+// certificate presented by CreateHTTPSClient (client.crt, client.key)
+func issueRouteCertificates(dir, domain string) (caCrt, serverCrt, serverKey string, err error) {
+	ca, err := pki.NewCA("release-tests-triggers-ca")
+	if err != nil {
+		return "", "", "", err
+	}
+	server, err := ca.NewServer(domain, domain)
+	if err != nil {
+		return "", "", "", err
+	}
+	client, err := ca.NewClient("release-tests-triggers-client")
+	if err != nil {
+		return "", "", "", err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", "", "", err
+	}
+	caCrt = filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caCrt, ca.CertPEM(), 0600); err != nil {
+		return "", "", "", err
+	}
+	if serverCrt, serverKey, err = server.Write(dir, "server"); err != nil {
+		return "", "", "", err
+	}
+	if _, _, err := client.Write(dir, "client"); err != nil {
+		return "", "", "", err
+	}
+	return caCrt, serverCrt, serverKey, nil
+}
+
 // This function returns the formatted hostname.
 func getDomain() string {
 	// extract cluster's domain from ingress config, e.g. apps.mycluster.example.com
@@ -143,61 +158,81 @@ func MockPostEventWithEmptyPayload(routeurl string) *http.Response {
 	// Send empty POST request to EventListener sink
 	req, err := http.NewRequest("POST", routeurl, bytes.NewBuffer([]byte("{}")))
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	req.Header.Add("Accept", "application/json")
 	resp, err := CreateHTTPClient().Do(req)
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	if resp.StatusCode > http.StatusAccepted {
-		testsuit.T.Errorf("sink did not return 2xx response. Got status code: %d", resp.StatusCode)
+		reporter.Default().Errorf("sink did not return 2xx response. Got status code: %d", resp.StatusCode)
 	}
 	return resp
 }
 
+// MockPostEvent posts the payload file (path relative to the repository) to
+// the EventListener, signed for the interceptor
 func MockPostEvent(routeurl, interceptor, eventType, payload string, isTLS bool) *http.Response {
+	eventBodyJSON, err := os.ReadFile(resource.Path(payload))
+	if err != nil {
+		reporter.Default().Errorf("could not load test data from file %s \n %v", payload, err)
+	}
+	return MockPostPayload(routeurl, interceptor, eventType, eventBodyJSON, isTLS)
+}
+
+// MockPostPayload posts the payload, e.g. built by BuildPayload or
+// RenderPayload, to the EventListener, signed for the interceptor
+func MockPostPayload(routeurl, interceptor, eventType string, eventBodyJSON []byte, isTLS bool) *http.Response {
 	var (
 		req  *http.Request
 		err  error
 		resp *http.Response
 	)
-	eventBodyJSON, err := os.ReadFile(resource.Path(payload))
-	if err != nil {
-		testsuit.T.Errorf("could not load test data from file %s \n %v", payload, err)
-	}
-
 	gauge.GetScenarioStore()["payload"] = eventBodyJSON
 
-	// Send POST request to EventListener sink
+	// Send POST request to EventListener sink, the URL is the Route or the
+	// local address returned when exposing the EventListener
+	sinkURL, err := url.Parse(routeurl)
+	if err != nil {
+		reporter.Default().Fail(err)
+	}
 	if isTLS {
-		req, err = http.NewRequest("POST", "https://"+strings.Split(routeurl, "//")[1], bytes.NewBuffer(eventBodyJSON))
-	} else {
-		req, err = http.NewRequest("POST", routeurl, bytes.NewBuffer(eventBodyJSON))
+		sinkURL.Scheme = "https"
 	}
+	req, err = http.NewRequest("POST", sinkURL.String(), bytes.NewBuffer(eventBodyJSON))
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	req = buildHeaders(req, interceptor, eventType)
 
-	if isTLS {
-		resp, err = CreateHTTPSClient().Do(req)
-	} else {
-		resp, err = CreateHTTPClient().Do(req)
-	}
+	resp, err = eventClient(isTLS).Do(req)
 	if err != nil {
-		testsuit.T.Fail(err)
+		reporter.Default().Fail(err)
 	}
 
 	if resp.StatusCode > http.StatusAccepted {
-		testsuit.T.Errorf("sink did not return 2xx response. Got status code: %d", resp.StatusCode)
+		reporter.Default().Errorf("sink did not return 2xx response. Got status code: %d", resp.StatusCode)
 	}
 	return resp
 }
 
+// eventClient returns the client posting events to EventListeners exposed
+// with the current strategy
+func eventClient(isTLS bool) *http.Client {
+	switch {
+	case !isTLS:
+		return CreateHTTPClient()
+	case expose.Current().Name() == expose.RouteMode:
+		return CreateHTTPSClient()
+	default:
+		return CreateInsecureHTTPSClient()
+	}
+}
+
 func AssertElResponse(c *clients.Clients, resp *http.Response, elname, namespace string) {
 	wantBody := sink.Response{
 		EventListener: elname,
@@ -208,27 +243,27 @@ func AssertElResponse(c *clients.Clients, resp *http.Response, elname, namespace
 	var gotBody sink.Response
 	err := json.NewDecoder(resp.Body).Decode(&gotBody)
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
 
 	if diff := cmp.Diff(wantBody, gotBody, cmpopts.IgnoreFields(sink.Response{}, "EventID", "EventListenerUID")); diff != "" {
-		testsuit.T.Errorf("unexpected sink response -want/+got: %s", diff)
+		c.Reporter().Errorf("unexpected sink response -want/+got: %s", diff)
 	}
 
 	if gotBody.EventID == "" {
-		testsuit.T.Errorf("sink response no eventID")
+		c.Reporter().Errorf("sink response no eventID")
 	}
 
 	labelSelector := fields.SelectorFromSet(resources.GenerateLabels(elname, resources.DefaultStaticResourceLabels)).String()
 	// Grab EventListener sink pods
 	sinkPods, err := c.KubeClient.Kube.CoreV1().Pods(namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
-		testsuit.T.Errorf("failed to list event listener sink pods with label selector %s in namespace %s \n %v", labelSelector, namespace, err)
+		c.Reporter().Errorf("failed to list event listener sink pods with label selector %s in namespace %s \n %v", labelSelector, namespace, err)
 	}
 
 	logs := cmd.MustSucceed("oc", "-n", namespace, "logs", "pods/"+sinkPods.Items[0].Name, "--all-containers", "--tail=2").Stdout()
 	if strings.Contains(logs, "error") {
-		testsuit.T.Errorf("Error: sink logs: \n %s", logs)
+		c.Reporter().Errorf("Error: sink logs: \n %s", logs)
 		gauge.WriteMessage("sink logs: \n %s", logs)
 	}
 }
@@ -237,7 +272,7 @@ func CleanupTriggers(c *clients.Clients, elName, namespace string) {
 	// Delete EventListener
 	err := c.TriggersClient.TriggersV1alpha1().EventListeners(namespace).Delete(c.Ctx, elName, metav1.DeleteOptions{})
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
 
 	log.Println("Deleted EventListener")
@@ -245,7 +280,7 @@ func CleanupTriggers(c *clients.Clients, elName, namespace string) {
 	// Verify the EventListener's Deployment is deleted
 	err = wait.WaitFor(c.Ctx, wait.DeploymentNotExist(c, namespace, fmt.Sprintf("%s-%s", eventReconciler.GeneratedResourcePrefix, elName)))
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
 
 	log.Println("EventListener's Deployment was deleted")
@@ -253,44 +288,37 @@ func CleanupTriggers(c *clients.Clients, elName, namespace string) {
 	// Verify the EventListener's Service is deleted
 	err = wait.WaitFor(c.Ctx, wait.ServiceNotExist(c, namespace, fmt.Sprintf("%s-%s", eventReconciler.GeneratedResourcePrefix, elName)))
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
 
 	log.Println("EventListener's Service was deleted")
 
-	// Delete Route exposed earlier
-	err = c.Route.Routes(namespace).Delete(c.Ctx, fmt.Sprintf("%s-%s", eventReconciler.GeneratedResourcePrefix, elName), metav1.DeleteOptions{})
+	// Delete what exposed the EventListener earlier, e.g. its Route, and verify it is deleted
+	err = expose.Current().Unexpose(c, expose.Service{Namespace: namespace, Name: fmt.Sprintf("%s-%s", eventReconciler.GeneratedResourcePrefix, elName)})
 	if err != nil {
-		testsuit.T.Fail(err)
+		c.Reporter().Fail(err)
 	}
-
-	// Verify the EventListener's Route is deleted
-	err = wait.WaitFor(c.Ctx, wait.RouteNotExist(c, namespace, fmt.Sprintf("%s-%s", eventReconciler.GeneratedResourcePrefix, elName)))
-	if err != nil {
-		testsuit.T.Fail(err)
-	}
-	log.Println("EventListener's Route got deleted successfully...")
+	log.Println("EventListener is not exposed anymore")
 
 	// This is required when EL runs as TLS
-	cmd.MustSucceed("rm", "-rf", os.Getenv("GOPATH")+"/src/github.com/openshift-pipelines/release-tests/testdata/triggers/certs")
+	if err := os.RemoveAll(certsDir()); err != nil {
+		log.Printf("Warning: could not remove certificates of the scenario: %v", err)
+	}
 }
 
 func GetRoute(elname, namespace string) string {
 	route := cmd.MustSucceed("oc", "-n", namespace, "get", "route", "--selector=eventlistener="+elname, "-o", "jsonpath='{range .items[*]}{.metadata.name}'").Stdout()
-	serverCert := cmd.MustSucceed("oc", "-n", namespace, "get", "route", "--selector=eventlistener="+elname, "-o", "jsonpath='{.items[].spec.tls.certificate}'").Stdout()
-	serverCert = strings.Trim(serverCert, "'")
-
-	// event listener is using TLS
-	if serverCert != "" {
-		file, err := os.Create(resource.Path("testdata/triggers/certs/server.crt"))
-		if err != nil {
-			testsuit.T.Fail(err)
+	caCert := cmd.MustSucceed("oc", "-n", namespace, "get", "route", "--selector=eventlistener="+elname, "-o", "jsonpath='{.items[].spec.tls.caCertificate}'").Stdout()
+	caCert = strings.Trim(caCert, "'")
+
+	// event listener is using TLS, trust the CA of the certificate of the
+	// route, which was issued by the scenario exposing the event listener
+	if caCert != "" {
+		if err := os.MkdirAll(certsDir(), 0750); err != nil {
+			reporter.Default().Fail(err)
 		}
-		//nolint:errcheck
-		defer file.Close()
-
-		if _, err := file.WriteString(serverCert); err != nil {
-			testsuit.T.Fail(err)
+		if err := os.WriteFile(filepath.Join(certsDir(), "ca.crt"), []byte(caCert), 0600); err != nil {
+			reporter.Default().Fail(err)
 		}
 	}
 	return GetRouteURL(route, namespace)
@@ -8,6 +8,8 @@ import (
 
 	"github.com/openshift-pipelines/release-tests/pkg/clients"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	triggersv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	"go.opencensus.io/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,8 +33,8 @@ func pollImmediateWithContext(ctx context.Context, fn func() (bool, error)) erro
 	})
 }
 
-// WaitForTaskRunState polls the status of the TaskRun called name from client every
-// interval until inState returns `true` indicating it is done, returns an
+// WaitForTaskRunState watches the status of the TaskRun called name from client
+// until inState returns `true` indicating it is done, returns an
 // error or timeout. desc will be used to name the metric that is emitted to
 // track how long it took for name to get into the state checked by inState.
 func WaitForTaskRunState(c *clients.Clients, name string, inState ConditionAccessorFn, desc string) error {
@@ -40,17 +42,17 @@ func WaitForTaskRunState(c *clients.Clients, name string, inState ConditionAcces
 	_, span := trace.StartSpan(context.Background(), metricName)
 	defer span.End()
 
-	return pollImmediateWithContext(c.Ctx, func() (bool, error) {
-		r, err := c.TaskRunClient.Get(c.Ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return true, err
-		}
-		return inState(&r.Status)
+	return watchUntil(c.Ctx, watchedObject[*v1.TaskRun]{
+		kind:    "TaskRun",
+		name:    name,
+		get:     c.TaskRunClient.Get,
+		watch:   c.TaskRunClient.Watch,
+		inState: func(r *v1.TaskRun) (bool, error) { return inState(&r.Status) },
 	})
 }
 
-// WaitForDeploymentState polls the status of the Deployment called name
-// from client every interval until inState returns `true` indicating it is done,
+// WaitForDeploymentState watches the status of the Deployment called name
+// from client until inState returns `true` indicating it is done,
 // returns an  error or timeout. desc will be used to name the metric that is emitted to
 // track how long it took for name to get into the state checked by inState.
 func WaitForDeploymentState(c *clients.Clients, name string, namespace string, inState func(d *appsv1.Deployment) (bool, error), desc string) error {
@@ -58,17 +60,18 @@ func WaitForDeploymentState(c *clients.Clients, name string, namespace string, i
 	_, span := trace.StartSpan(context.Background(), metricName)
 	defer span.End()
 
-	return pollImmediateWithContext(c.Ctx, func() (bool, error) {
-		d, err := c.KubeClient.Kube.AppsV1().Deployments(namespace).Get(c.Ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return true, err
-		}
-		return inState(d)
+	deployments := c.KubeClient.Kube.AppsV1().Deployments(namespace)
+	return watchUntil(c.Ctx, watchedObject[*appsv1.Deployment]{
+		kind:    "Deployment",
+		name:    name,
+		get:     deployments.Get,
+		watch:   deployments.Watch,
+		inState: inState,
 	})
 }
 
-// WaitForPodState polls the status of the Pod called name from client every
-// interval until inState returns `true` indicating it is done, returns an
+// WaitForPodState watches the status of the Pod called name from client
+// until inState returns `true` indicating it is done, returns an
 // error or timeout. desc will be used to name the metric that is emitted to
 // track how long it took for name to get into the state checked by inState.
 func WaitForPodState(c *clients.Clients, name string, namespace string, inState func(r *corev1.Pod) (bool, error), desc string) error {
@@ -76,17 +79,18 @@ func WaitForPodState(c *clients.Clients, name string, namespace string, inState
 	_, span := trace.StartSpan(context.Background(), metricName)
 	defer span.End()
 
-	return pollImmediateWithContext(c.Ctx, func() (bool, error) {
-		r, err := c.KubeClient.Kube.CoreV1().Pods(namespace).Get(c.Ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return true, err
-		}
-		return inState(r)
+	pods := c.KubeClient.Kube.CoreV1().Pods(namespace)
+	return watchUntil(c.Ctx, watchedObject[*corev1.Pod]{
+		kind:    "Pod",
+		name:    name,
+		get:     pods.Get,
+		watch:   pods.Watch,
+		inState: inState,
 	})
 }
 
-// WaitForPipelineRunState polls the status of the PipelineRun called name from client every
-// interval until inState returns `true` indicating it is done, returns an
+// WaitForPipelineRunState watches the status of the PipelineRun called name from client
+// until inState returns `true` indicating it is done, returns an
 // error or timeout. desc will be used to name the metric that is emitted to
 // track how long it took for name to get into the state checked by inState.
 func WaitForPipelineRunState(c *clients.Clients, name string, inState ConditionAccessorFn, desc string) error {
@@ -94,12 +98,12 @@ func WaitForPipelineRunState(c *clients.Clients, name string, inState ConditionA
 	_, span := trace.StartSpan(context.Background(), metricName)
 	defer span.End()
 
-	return pollImmediateWithContext(c.Ctx, func() (bool, error) {
-		r, err := c.PipelineRunClient.Get(c.Ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return true, err
-		}
-		return inState(&r.Status)
+	return watchUntil(c.Ctx, watchedObject[*v1.PipelineRun]{
+		kind:    "PipelineRun",
+		name:    name,
+		get:     c.PipelineRunClient.Get,
+		watch:   c.PipelineRunClient.Watch,
+		inState: func(r *v1.PipelineRun) (bool, error) { return inState(&r.Status) },
 	})
 }
 
@@ -219,6 +223,15 @@ func Running(name string) ConditionAccessorFn {
 	}
 }
 
+// Done provides a poll condition function that checks if the ConditionAccessor
+// resource has completed, successfully or not.
+func Done(name string) ConditionAccessorFn {
+	return func(ca apis.ConditionAccessor) (bool, error) {
+		c := ca.GetCondition(apis.ConditionSucceeded)
+		return c != nil && (c.Status == corev1.ConditionTrue || c.Status == corev1.ConditionFalse), nil
+	}
+}
+
 // TaskRunSucceed provides a poll condition function that checks if the TaskRun
 // has successfully completed.
 func TaskRunSucceed(name string) ConditionAccessorFn {
@@ -260,21 +273,43 @@ func EventListenerReady(c *clients.Clients, namespace, name string) wait.Conditi
 			log.Printf("EventListener not found")
 			return false, nil
 		}
-		log.Printf("EventListenerStatus: %+v", el.Status)
-		// No conditions have been set yet
-		if len(el.Status.Conditions) == 0 {
-			return false, nil
-		}
-		if el.Status.GetCondition(apis.ConditionType(appsv1.DeploymentAvailable)) == nil {
-			return false, nil
-		}
-		for _, cond := range el.Status.Conditions {
-			if cond.Status != corev1.ConditionTrue {
-				return false, nil
-			}
+		return eventListenerIsReady(el), nil
+	}
+}
+
+// WaitForEventListenerReady watches the EventListener called name until it is
+// ready as checked by EventListenerReady, waiting for it to be created if needed
+func WaitForEventListenerReady(c *clients.Clients, namespace, name string) error {
+	metricName := fmt.Sprintf("WaitForEventListenerReady/%s", name)
+	_, span := trace.StartSpan(context.Background(), metricName)
+	defer span.End()
+
+	eventListeners := c.TriggersClient.TriggersV1alpha1().EventListeners(namespace)
+	return watchUntil(c.Ctx, watchedObject[*triggersv1alpha1.EventListener]{
+		kind:         "EventListener",
+		name:         name,
+		get:          eventListeners.Get,
+		watch:        eventListeners.Watch,
+		inState:      func(el *triggersv1alpha1.EventListener) (bool, error) { return eventListenerIsReady(el), nil },
+		allowMissing: true,
+	})
+}
+
+func eventListenerIsReady(el *triggersv1alpha1.EventListener) bool {
+	log.Printf("EventListenerStatus: %+v", el.Status)
+	// No conditions have been set yet
+	if len(el.Status.Conditions) == 0 {
+		return false
+	}
+	if el.Status.GetCondition(apis.ConditionType(appsv1.DeploymentAvailable)) == nil {
+		return false
+	}
+	for _, cond := range el.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			return false
 		}
-		return true, nil
 	}
+	return true
 }
 
 func WaitForPodsWithLabels(c *clients.Clients, namespace, labels string) wait.ConditionFunc {
@@ -0,0 +1,153 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchedObject describes how to get and watch a single named object until
+// inState returns `true` or an error
+type watchedObject[T metav1.Object] struct {
+	kind    string
+	name    string
+	get     func(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	watch   func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	inState func(T) (bool, error)
+	// allowMissing keeps waiting while the object does not exist instead of failing
+	allowMissing bool
+}
+
+// watchUntil gets the object, then watches it from the resourceVersion of the
+// last version seen until it reaches the expected state or config.APITimeout
+// expires. A closed watch is resumed from that resourceVersion, a failed one
+// after config.APIRetry, an expired one restarts from a fresh get. If the object cannot be watched (e.g. the
+// watch verb is not allowed), it falls back to polling every config.APIRetry.
+func watchUntil[T metav1.Object](ctx context.Context, o watchedObject[T]) error {
+	ctx, cancel := context.WithTimeout(ctx, config.APITimeout)
+	defer cancel()
+
+	resourceVersion := ""
+	synced := false
+	for {
+		if !synced {
+			done, rv, err := o.sync(ctx)
+			if done || err != nil {
+				return err
+			}
+			resourceVersion, synced = rv, true
+		}
+
+		w, err := o.watch(ctx, metav1.ListOptions{
+			FieldSelector:       fields.OneTermEqualSelector("metadata.name", o.name).String(),
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return wait.ErrorInterrupted(ctx.Err())
+			}
+			log.Printf("Warning: could not watch %s %s, polling instead: %v", o.kind, o.name, err)
+			return o.poll(ctx)
+		}
+
+		var done bool
+		done, resourceVersion, synced, err = o.consume(ctx, w, resourceVersion)
+		w.Stop()
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// sync gets the current version of the object, returning its resourceVersion
+// to start watching from ("" when it is missing but allowed to be)
+func (o watchedObject[T]) sync(ctx context.Context) (bool, string, error) {
+	obj, err := o.get(ctx, o.name, metav1.GetOptions{})
+	if err != nil {
+		if o.allowMissing && errors.IsNotFound(err) {
+			log.Printf("%s %s not found", o.kind, o.name)
+			return false, "", nil
+		}
+		return true, "", err
+	}
+	done, err := o.inState(obj)
+	return done, obj.GetResourceVersion(), err
+}
+
+// consume handles the events of w until the object reaches the expected state
+// (done) or the watch ends. It returns the resourceVersion to resume from and
+// whether it is still valid (synced).
+func (o watchedObject[T]) consume(ctx context.Context, w watch.Interface, resourceVersion string) (done bool, rv string, synced bool, err error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return true, resourceVersion, true, wait.ErrorInterrupted(ctx.Err())
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return false, resourceVersion, true, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				status := errors.FromObject(event.Object)
+				if errors.IsResourceExpired(status) || errors.IsGone(status) {
+					return false, "", false, nil
+				}
+				log.Printf("Warning: watch of %s %s failed, resuming in %s: %v", o.kind, o.name, config.APIRetry, status)
+				// back off, the error may persist
+				select {
+				case <-ctx.Done():
+					return true, resourceVersion, true, wait.ErrorInterrupted(ctx.Err())
+				case <-time.After(config.APIRetry):
+				}
+				return false, resourceVersion, true, nil
+			case watch.Bookmark:
+				if accessor, err := meta.Accessor(event.Object); err == nil {
+					resourceVersion = accessor.GetResourceVersion()
+				}
+			case watch.Deleted:
+				if accessor, err := meta.Accessor(event.Object); err == nil && accessor.GetName() != o.name {
+					continue
+				}
+				if !o.allowMissing {
+					return true, resourceVersion, true, fmt.Errorf("%s %q was deleted", o.kind, o.name)
+				}
+			case watch.Added, watch.Modified:
+				obj, ok := event.Object.(T)
+				if !ok {
+					return true, resourceVersion, true, fmt.Errorf("unexpected object %T watching %s %q", event.Object, o.kind, o.name)
+				}
+				resourceVersion = obj.GetResourceVersion()
+				// the field selector is not honoured by every implementation (e.g. fake clients)
+				if obj.GetName() != o.name {
+					continue
+				}
+				if done, err := o.inState(obj); done || err != nil {
+					return true, resourceVersion, true, err
+				}
+			}
+		}
+	}
+}
+
+func (o watchedObject[T]) poll(ctx context.Context) error {
+	return pollImmediateWithContext(ctx, func() (bool, error) {
+		obj, err := o.get(ctx, o.name, metav1.GetOptions{})
+		if err != nil {
+			if o.allowMissing && errors.IsNotFound(err) {
+				return false, nil
+			}
+			return true, err
+		}
+		return o.inState(obj)
+	})
+}
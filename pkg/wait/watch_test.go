@@ -0,0 +1,259 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/release-tests/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var configMaps = schema.GroupResource{Resource: "configmaps"}
+
+func configMap(name, resourceVersion, state string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: resourceVersion},
+		Data:       map[string]string{"state": state},
+	}
+}
+
+// fakeAPI serves the gets and watches of a single config map from sequences,
+// the last get being repeated, and records the resourceVersion of each watch
+type fakeAPI struct {
+	mu       sync.Mutex
+	gets     []func() (*corev1.ConfigMap, error)
+	watches  [][]watch.Event
+	watchErr error
+	getCount int
+	watched  []string
+}
+
+func (f *fakeAPI) get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	get := f.gets[min(f.getCount, len(f.gets)-1)]
+	f.getCount++
+	return get()
+}
+
+// watch returns a watch sending the next sequence of events then closing, the
+// last watch staying open
+func (f *fakeAPI) watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	f.watched = append(f.watched, opts.ResourceVersion)
+	w := watch.NewFakeWithChanSize(10, false)
+	if len(f.watches) == 0 {
+		return w, nil
+	}
+	for _, e := range f.watches[0] {
+		w.Action(e.Type, e.Object)
+	}
+	if len(f.watches) > 1 {
+		w.Stop()
+	}
+	f.watches = f.watches[1:]
+	return w, nil
+}
+
+func (f *fakeAPI) object(allowMissing bool) watchedObject[*corev1.ConfigMap] {
+	return watchedObject[*corev1.ConfigMap]{
+		kind:  "ConfigMap",
+		name:  "cm",
+		get:   f.get,
+		watch: f.watch,
+		inState: func(cm *corev1.ConfigMap) (bool, error) {
+			if cm.Data["state"] == "failed" {
+				return true, errors.New("failed")
+			}
+			return cm.Data["state"] == "done", nil
+		},
+		allowMissing: allowMissing,
+	}
+}
+
+func found(cm *corev1.ConfigMap) func() (*corev1.ConfigMap, error) {
+	return func() (*corev1.ConfigMap, error) { return cm, nil }
+}
+
+func notFound() (*corev1.ConfigMap, error) {
+	return nil, apierrors.NewNotFound(configMaps, "cm")
+}
+
+func TestWatchUntil(t *testing.T) {
+	timeouts := config.CurrentTimeouts()
+	defer config.SetTimeouts(timeouts)
+	short := timeouts
+	short.APIRetry, short.APITimeout = 10*time.Millisecond, 200*time.Millisecond
+	config.SetTimeouts(short)
+
+	expired := &apierrors.NewResourceExpired("too old resource version").ErrStatus
+	internal := &apierrors.NewInternalError(errors.New("etcd unavailable")).ErrStatus
+
+	tests := []struct {
+		name         string
+		api          *fakeAPI
+		allowMissing bool
+		wantErr      string
+		wantGets     int
+		// wantWatched are the resourceVersions watched from
+		wantWatched []string
+	}{{
+		name:     "in state when got",
+		api:      &fakeAPI{gets: []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "done"))}},
+		wantGets: 1,
+	}, {
+		name: "in state when modified",
+		api: &fakeAPI{
+			gets: []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{{
+				{Type: watch.Modified, Object: configMap("other", "2", "done")},
+				{Type: watch.Modified, Object: configMap("cm", "3", "done")},
+			}},
+		},
+		wantGets:    1,
+		wantWatched: []string{"1"},
+	}, {
+		name: "closed watch resumed from the last resourceVersion",
+		api: &fakeAPI{
+			gets: []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{
+				{{Type: watch.Modified, Object: configMap("cm", "2", "pending")}},
+				{{Type: watch.Bookmark, Object: configMap("", "5", "")}},
+				{{Type: watch.Modified, Object: configMap("cm", "6", "done")}},
+			},
+		},
+		wantGets:    1,
+		wantWatched: []string{"1", "2", "5"},
+	}, {
+		name: "expired watch restarted from a get",
+		api: &fakeAPI{
+			gets: []func() (*corev1.ConfigMap, error){
+				found(configMap("cm", "1", "pending")),
+				found(configMap("cm", "7", "pending")),
+			},
+			watches: [][]watch.Event{
+				{{Type: watch.Error, Object: expired}},
+				{{Type: watch.Modified, Object: configMap("cm", "8", "done")}},
+			},
+		},
+		wantGets:    2,
+		wantWatched: []string{"1", "7"},
+	}, {
+		name: "failed watch resumed from the last resourceVersion",
+		api: &fakeAPI{
+			gets: []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{
+				{{Type: watch.Error, Object: internal}},
+				{{Type: watch.Modified, Object: configMap("cm", "2", "done")}},
+			},
+		},
+		wantGets:    1,
+		wantWatched: []string{"1", "1"},
+	}, {
+		name: "error state",
+		api: &fakeAPI{
+			gets:    []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{{{Type: watch.Modified, Object: configMap("cm", "2", "failed")}}},
+		},
+		wantErr:     "failed",
+		wantGets:    1,
+		wantWatched: []string{"1"},
+	}, {
+		name: "deleted",
+		api: &fakeAPI{
+			gets:    []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{{{Type: watch.Deleted, Object: configMap("cm", "2", "pending")}}},
+		},
+		wantErr:     `ConfigMap "cm" was deleted`,
+		wantGets:    1,
+		wantWatched: []string{"1"},
+	}, {
+		name: "missing",
+		api:  &fakeAPI{gets: []func() (*corev1.ConfigMap, error){notFound}},
+		// the get error is returned as is
+		wantErr:  "not found",
+		wantGets: 1,
+	}, {
+		name:         "missing allowed until added",
+		allowMissing: true,
+		api: &fakeAPI{
+			gets:    []func() (*corev1.ConfigMap, error){notFound},
+			watches: [][]watch.Event{{{Type: watch.Added, Object: configMap("cm", "3", "done")}}},
+		},
+		wantGets:    1,
+		wantWatched: []string{""},
+	}, {
+		name: "polled when the watch is not allowed",
+		api: &fakeAPI{
+			gets: []func() (*corev1.ConfigMap, error){
+				found(configMap("cm", "1", "pending")),
+				found(configMap("cm", "2", "pending")),
+				found(configMap("cm", "3", "done")),
+			},
+			watchErr: apierrors.NewForbidden(configMaps, "", errors.New("watch not allowed")),
+		},
+		wantGets: 3,
+	}, {
+		name: "timeout",
+		api: &fakeAPI{
+			gets:    []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))},
+			watches: [][]watch.Event{{}},
+		},
+		wantErr:     "context deadline exceeded",
+		wantGets:    1,
+		wantWatched: []string{"1"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := watchUntil(t.Context(), tt.api.object(tt.allowMissing))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected no error, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+			if tt.api.getCount != tt.wantGets {
+				t.Errorf("expected %d gets, got %d", tt.wantGets, tt.api.getCount)
+			}
+			if !slices.Equal(tt.api.watched, tt.wantWatched) {
+				t.Errorf("expected watches from resourceVersions %q, got %q", tt.wantWatched, tt.api.watched)
+			}
+		})
+	}
+}
+
+func TestWatchUntilBackoff(t *testing.T) {
+	timeouts := config.CurrentTimeouts()
+	defer config.SetTimeouts(timeouts)
+	short := timeouts
+	short.APIRetry, short.APITimeout = 20*time.Millisecond, 200*time.Millisecond
+	config.SetTimeouts(short)
+
+	internal := &apierrors.NewInternalError(errors.New("etcd unavailable")).ErrStatus
+	api := &fakeAPI{gets: []func() (*corev1.ConfigMap, error){found(configMap("cm", "1", "pending"))}}
+	for range 1000 {
+		api.watches = append(api.watches, []watch.Event{{Type: watch.Error, Object: internal}})
+	}
+
+	err := watchUntil(t.Context(), api.object(false))
+	if err == nil || !strings.Contains(err.Error(), "context deadline exceeded") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+	// a watch failing every time is resumed once per APIRetry, not in a tight loop
+	if maxWatches := int(short.APITimeout/short.APIRetry) + 1; len(api.watched) > maxWatches {
+		t.Errorf("expected at most %d watches, got %d", maxWatches, len(api.watched))
+	}
+}
@@ -5,7 +5,7 @@ Precondition:
 * Validate Operator should be installed
 
 ## Using Tekton Chains to create and verify task run signatures: PIPELINES-27-TC01
-Tags: chains, e2e, taskrun, sanity
+Tags: chains, e2e, taskrun, sanity, mutating
 Component: Chains
 Level: Integration
 Type: Functional
@@ -21,7 +21,7 @@ Steps:
     * Verify "taskrun" signature
 
 ## Using Tekton Chains to sign and verify image and provenance : PIPELINES-27-TC02
-Tags: chains, e2e, image
+Tags: chains, e2e, image, mutating
 Component: Chains
 Level: Integration
 Type: Functional
@@ -40,3 +40,26 @@ Steps:
     * Verify image signature
     * Check attestation exists
     * Verify attestation
+
+## Using Tekton Chains to sign and verify image and provenance with the in-cluster registry: PIPELINES-27-TC03
+Tags: chains, e2e, image, mutating, registry
+Component: Chains
+Level: Integration
+Type: Functional
+Importance: Critical
+Steps:
+    * Update the TektonConfig with taskrun format as "in-toto" taskrun storage as "oci" oci storage as "oci" transparency mode as "true"
+    * Store Cosign public key in file
+    * Deploy registry
+    * Use registry repository "chains/kaniko-chains" for chains
+    * Verify that image registry variable is exported
+    * Create secret with image registry credentials for SA
+    * Apply
+     | S.NO | resource_dir                          |
+     |------|---------------------------------------|
+     | 1    | testdata/pvc/chains-pvc.yaml          |
+     | 2    | testdata/chains/kaniko.yaml           |
+    * Start the kaniko-chains task
+    * Verify image signature
+    * Check attestation exists
+    * Verify attestation
@@ -419,4 +419,93 @@ Steps:
   * Verify pipelinerun
       |S.NO|pipeline_run_name|status    |
       |----|-----------------|----------|
-      |1   |opc-task-run     |successful|
\ No newline at end of file
+      |1   |opc-task-run     |successful|
+
+## git-cli read private repo over SSH from the in-cluster git server pipelinerun: PIPELINES-29-TC22
+Tags: e2e, ecosystem, non-admin, git-cli, git-server
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+  * Deploy git server
+  * Create git repositories
+      |S.NO|repository                       |source|path|branch|visibility|
+      |----|---------------------------------|------|----|------|----------|
+      |1   |openshift-pipelines/test-private |      |    |main  |private   |
+  * Stop exposing git server
+  * Create with git server
+      |S.NO|resource_dir                                               |
+      |----|-----------------------------------------------------------|
+      |1   |testdata/ecosystem/pipelines/git-cli-read-private.yaml     |
+  * Create
+      |S.NO|resource_dir                                               |
+      |----|-----------------------------------------------------------|
+      |1   |testdata/pvc/pvc.yaml                                      |
+  * Link secret "git-server-ssh" to service account "pipeline"
+  * Create
+      |S.NO|resource_dir                                                  |
+      |----|--------------------------------------------------------------|
+      |1   |testdata/ecosystem/pipelineruns/git-cli-read-private.yaml     |
+  * Verify pipelinerun
+      |S.NO|pipeline_run_name       |status    |
+      |----|------------------------|----------|
+      |1   |git-cli-read-private-run|successful|
+
+## git-clone read private repo over SSH from the in-cluster git server taskrun: PIPELINES-29-TC23
+Tags: e2e, ecosystem, non-admin, git-clone, git-server
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+  * Deploy git server
+  * Create git repositories
+      |S.NO|repository                       |source|path|branch|visibility|
+      |----|---------------------------------|------|----|------|----------|
+      |1   |openshift-pipelines/test-private |      |    |main  |private   |
+  * Stop exposing git server
+  * Create with git server
+      | S.NO | resource_dir                                                  |
+      |------|---------------------------------------------------------------|
+      | 1    | testdata/ecosystem/pipelines/git-clone-read-private.yaml      |
+  * Create
+      | S.NO | resource_dir                                                    |
+      |------|-----------------------------------------------------------------|
+      | 1    | testdata/pvc/pvc.yaml                                           |
+  * Link secret "git-server-ssh" to service account "pipeline"
+  * Create
+      | S.NO | resource_dir                                                    |
+      |------|-----------------------------------------------------------------|
+      | 1    | testdata/ecosystem/pipelineruns/git-clone-read-private.yaml     |
+  * Verify pipelinerun
+      | S.NO | pipeline_run_name                   | status     |
+      |------|-------------------------------------|------------|
+      | 1    | git-clone-read-private-pipeline-run | successful |
+
+## buildah pipelinerun pushing to the in-cluster registry: PIPELINES-29-TC24
+Tags: e2e, ecosystem, tasks, non-admin, buildah, registry
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+  * Deploy registry with authentication "true" and TLS "true"
+  * Mirror "image-registry.openshift-image-registry.svc:5000" to registry
+  * Link secret "registry-credentials" to service account "pipeline"
+  * Create with registry
+      |S.NO|resource_dir                                     |
+      |----|-------------------------------------------------|
+      |1   |testdata/ecosystem/pipelines/buildah.yaml        |
+  * Create
+      |S.NO|resource_dir                                     |
+      |----|-------------------------------------------------|
+      |1   |testdata/pvc/pvc.yaml                            |
+      |2   |testdata/ecosystem/pipelineruns/buildah.yaml     |
+  * Verify pipelinerun
+      |S.NO|pipeline_run_name|status    |
+      |----|-----------------|----------|
+      |1   |buildah-run      |successful|
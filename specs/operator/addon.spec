@@ -5,7 +5,7 @@ Pre condition:
   * Validate Operator should be installed
 
 ## Disable/Enable resolverTasks: PIPELINES-15-TC06
-Tags: e2e, integration, resolvertasks, admin, addon, sanity
+Tags: e2e, integration, resolvertasks, admin, addon, sanity, mutating
 Component: Pipelines
 Level: Integration
 Type: Functional
@@ -18,7 +18,7 @@ Steps:
   * Tasks "s2i-java" are "present" in namespace "openshift-pipelines"
 
 ## Disable/Enable resolverTasks with additional Tasks: PIPELINES-15-TC07
-Tags: e2e, integration, resolvertasks, admin, addon
+Tags: e2e, integration, resolvertasks, admin, addon, mutating
 Component: Pipelines
 Level: Integration
 Type: Functional
@@ -40,7 +40,7 @@ Steps:
   * Tasks "hello" are "present" in namespace "openshift-pipelines"
 
 ## Disable/Enable pipeline templates: PIPELINES-15-TC08
-Tags: e2e, integration, resolvertasks, admin, addon, sanity
+Tags: e2e, integration, resolvertasks, admin, addon, sanity, mutating
 Component: Pipelines
 Level: Integration
 Type: Functional
@@ -55,7 +55,7 @@ Steps:
   * Assert pipelines are "present" in "openshift" namespace
 
 ## Enable pipeline templates when clustertask is disabled: PIPELINES-15-TC05
-Tags: e2e, integration, negative, admin, addon
+Tags: e2e, integration, negative, admin, addon, mutating
 Component: Pipelines
 Level: Integration
 Type: Functional
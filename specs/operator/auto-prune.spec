@@ -1,7 +1,7 @@
 PIPELINES-12
 
 # Verify auto-prune E2E
-Tags: auto-prune
+Tags: auto-prune, mutating
 
 Pre condition:
   * Validate Operator should be installed
@@ -6,7 +6,7 @@ Pre condition:
   * Validate Operator should be installed
 
 ## Test HPA for tekton-pipelines-webhook deployment: PIPELINES-13-TC01
-Tags: hpa, admin, to-do
+Tags: hpa, admin, mutating
 Component: Operator
 Level: Integration
 Type: Functional
@@ -16,6 +16,9 @@ CustomerScenario: yes
 This scenario tests HPA for tekton-pipelines-webhook deployment
 
 Steps:
-  * Run "kubectl -n openshift-pipelines scale --replicas=3 deployment/tekton-pipelines-webhook"
-  * Sleep for "30" seconds
-  * Assert if "3" pods related to "tekton-pipelines-webhook" are present and running in "openshift-pipelinse" namespace
\ No newline at end of file
+  * Assert HPA exists for "tekton-pipelines-webhook" deployment in "openshift-pipelines" namespace
+  * Update HPA "tekton-pipelines-webhook" in TektonConfig "pipeline" options with min replicas "3" and max replicas "5"
+  * Assert HPA of "tekton-pipelines-webhook" deployment in "openshift-pipelines" namespace matches TektonConfig options
+  * Run "kubectl -n openshift-pipelines scale --replicas=1 deployment/tekton-pipelines-webhook"
+  * Assert HPA of "tekton-pipelines-webhook" deployment in "openshift-pipelines" namespace converges to "3" replicas
+  * Assert if "3" pods related to "tekton-pipelines-webhook" are present and running in "openshift-pipelines" namespace
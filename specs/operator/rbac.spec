@@ -5,7 +5,7 @@ Pre condition:
   * Validate Operator should be installed
 
 ## Disable RBAC resource creation: PIPELINES-11-TC01
-Tags: e2e, rbac-disable, admin, sanity
+Tags: e2e, rbac-disable, admin, sanity, mutating
 Component: Operator
 Level: Integration
 Type: Functional
@@ -22,7 +22,7 @@ Steps:
   * Verify RBAC resources are auto created successfully
 
 ## Independent CA Bundle ConfigMap creation control: PIPELINES-11-TC02
-Tags: e2e, cabundle-control, admin, sanity
+Tags: e2e, cabundle-control, admin, sanity, mutating
 Component: Operator
 Level: Integration
 Type: Functional
@@ -1,7 +1,7 @@
 PIPELINES-36
 
 # Verify Tekton Pruner Functionality
-Tags: pruner, tekton-pruner
+Tags: pruner, tekton-pruner, mutating
 
 Pre condition:
   * Validate Operator should be installed
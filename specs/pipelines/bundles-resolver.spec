@@ -36,4 +36,29 @@ Steps:
     * Verify pipelinerun
       |S.NO|pipeline_run_name                  |status      |
       |----|-----------------------------------|------------|
-      |1   |bundles-resolver-pipelinerun-param |successful  |
\ No newline at end of file
+      |1   |bundles-resolver-pipelinerun-param |successful  |
+
+## Test the functionality of bundles resolver with the in-cluster registry: PIPELINES-25-TC03
+Tags: e2e, registry
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+    * Deploy registry with authentication "true" and TLS "false"
+    * Publish Tekton bundle "quay.io/openshift-pipeline/resolver-test-bundles:1.0" to registry
+      |S.NO|resource_dir                                                  |
+      |----|--------------------------------------------------------------|
+      |1   |testdata/resolvers/tasks/bundles-resolver-task.yaml           |
+      |2   |testdata/resolvers/pipelines/bundles-resolver-pipeline.yaml   |
+    * Create with registry
+      |S.NO|resource_dir                                                               |
+      |----|---------------------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/bundles-resolver-pipelinerun.yaml          |
+      |2   |testdata/resolvers/pipelineruns/bundles-resolver-pipelinerun-param.yaml    |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |bundles-resolver-pipelinerun       |successful  |
+      |2   |bundles-resolver-pipelinerun-param |successful  |
@@ -7,7 +7,7 @@ Precondition:
   * Validate Operator should be installed
 
 ## Run Pipeline with a non-existent ServiceAccount: PIPELINES-02-TC01
-Tags: e2e, pipeline, negative, non-admin, sanity
+Tags: e2e, pipeline, negative, non-admin, sanity, portable
 Component: Pipelines
 Pos/Neg: Negative
 Level: Integration
@@ -28,7 +28,7 @@ Steps:
        |1   |output-pipeline-run-vb|Failure|
 
 ## Run Task with a non-existent ServiceAccount: PIPELINES-02-TC02
-Tags: e2e, tasks, negative, non-admin
+Tags: e2e, tasks, negative, non-admin, portable
 Component: Pipelines
 Pos/Neg: Negative
 Level: Integration
@@ -41,3 +41,55 @@ Steps:
       |1   |git-resolver-pipelinerun-private            |successful  |
       |2   |git-resolver-pipelinerun-private-token-auth |successful  |
       |3   |git-resolver-pipelinerun-private-url        |successful  |
+
+## Test the functionality of git resolvers with the in-cluster git server: PIPELINES-24-TC03
+Tags: e2e, git-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+    * Deploy git server
+    * Create git repositories
+      |S.NO|repository                         |source                       |path|branch|visibility|
+      |----|-----------------------------------|-----------------------------|----|------|----------|
+      |1   |openshift-pipelines/release-tests  |testdata/resolvers/pipelines |    |master|public    |
+      |2   |openshift-pipelines/release-tests  |testdata/resolvers/tasks     |    |master|public    |
+    * Stop exposing git server
+    * Create with git server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/git-resolver-pipelinerun.yaml    |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |git-resolver-pipelinerun           |successful  |
+
+## Test the functionality of git resolvers with token authentication against the in-cluster git server: PIPELINES-24-TC04
+Tags: e2e, git-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+    * Deploy git server
+    * Create git repositories
+      |S.NO|repository                         |source                                                  |path                   |branch|visibility|
+      |----|-----------------------------------|--------------------------------------------------------|-----------------------|------|----------|
+      |1   |openshift-pipelines/release-tests  |testdata/resolvers/tasks                                |                       |master|public    |
+      |2   |openshift-pipelines/test-private   |testdata/resolvers/pipelines/git-resolver-pipeline.yaml |resolver-pipeline.yaml |main  |private   |
+    * Stop exposing git server
+    * Create with git server
+      |S.NO|resource_dir                                                                     |
+      |----|---------------------------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/git-resolver-pipelinerun-private.yaml            |
+      |2   |testdata/resolvers/pipelineruns/git-resolver-pipelinerun-private-token-auth.yaml |
+      |3   |testdata/resolvers/pipelineruns/git-resolver-pipelinerun-private-url.yaml        |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                           |status      |
+      |----|--------------------------------------------|------------|
+      |1   |git-resolver-pipelinerun-private            |successful  |
+      |2   |git-resolver-pipelinerun-private-token-auth |successful  |
+      |3   |git-resolver-pipelinerun-private-url        |successful  |
@@ -19,4 +19,166 @@ Steps:
     * Verify pipelinerun
       |S.NO|pipeline_run_name                  |status      |
       |----|-----------------------------------|------------|
-      |1   |http-resolver-pipelinerun          |successful  |
\ No newline at end of file
+      |1   |http-resolver-pipelinerun          |successful  |
+
+## Test the http resolver against the in-cluster http server: PIPELINES-31-TC02
+Tags: e2e, http-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+    * Deploy http server serving
+      |S.NO|source                                                  |path                                 |
+      |----|--------------------------------------------------------|-------------------------------------|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|                                     |
+      |2   |testdata/resolvers/tasks/resolver-task.yaml             |tasks/task-tkn/0.2.2/task-tkn.yaml   |
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |http-resolver-pipelinerun          |successful  |
+
+## Test the http resolver with basic auth from a secret: PIPELINES-31-TC03
+Tags: e2e, http-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: High
+
+Steps:
+    * Deploy http server serving
+      |S.NO|source                                                  |path                                 |
+      |----|--------------------------------------------------------|-------------------------------------|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|                                     |
+      |2   |testdata/resolvers/tasks/resolver-task.yaml             |tasks/task-tkn/0.2.2/task-tkn.yaml   |
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect|auth|
+      |----|--------------------------------------------------------|------|-----|--------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|      |     |        |true|
+    * Stop exposing http server
+    * Create with http server
+      |S.NO|resource_dir                                                        |
+      |----|--------------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun-auth.yaml |
+      |2   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml      |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |http-resolver-pipelinerun-auth     |successful  |
+    * Verify pipelinerun "http-resolver-pipelinerun" failed with reason "CouldntGetPipeline" and message "is not found"
+
+## Test the http resolver reporting error responses: PIPELINES-31-TC04
+Tags: e2e, http-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: Medium
+
+Steps:
+    * Deploy http server serving
+      |S.NO|source                                                  |path                                 |
+      |----|--------------------------------------------------------|-------------------------------------|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|                                     |
+      |2   |testdata/resolvers/tasks/resolver-task.yaml             |tasks/task-tkn/0.2.2/task-tkn.yaml   |
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect|auth|
+      |----|--------------------------------------------------------|------|-----|--------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|404   |     |        |    |
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun "http-resolver-pipelinerun" failed with reason "CouldntGetPipeline" and message "is not found"
+    * Delete "pipelinerun" named "http-resolver-pipelinerun"
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect|auth|
+      |----|--------------------------------------------------------|------|-----|--------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|500   |     |        |    |
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun "http-resolver-pipelinerun" failed with reason "CouldntGetPipeline" and message "is not found"
+    * Delete "pipelinerun" named "http-resolver-pipelinerun"
+    * Reset http server routes
+    * Stop exposing http server
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |http-resolver-pipelinerun          |successful  |
+
+## Test the http resolver following redirects: PIPELINES-31-TC05
+Tags: e2e, http-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: Medium
+
+Steps:
+    * Deploy http server serving
+      |S.NO|source                                                  |path                                 |
+      |----|--------------------------------------------------------|-------------------------------------|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|                                     |
+      |2   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|moved/http-resolver-pipeline.yaml    |
+      |3   |testdata/resolvers/tasks/resolver-task.yaml             |tasks/task-tkn/0.2.2/task-tkn.yaml   |
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect                         |auth|
+      |----|--------------------------------------------------------|------|-----|---------------------------------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|      |     |moved/http-resolver-pipeline.yaml|    |
+    * Stop exposing http server
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |http-resolver-pipelinerun          |successful  |
+
+## Test the http resolver timing out on slow responses: PIPELINES-31-TC06
+Tags: e2e, http-server
+Component: Resolvers
+Level: Integration
+Type: Functional
+Importance: Medium
+
+The http resolver gives up after its fetch-timeout, 1m by default.
+
+Steps:
+    * Deploy http server serving
+      |S.NO|source                                                  |path                                 |
+      |----|--------------------------------------------------------|-------------------------------------|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|                                     |
+      |2   |testdata/resolvers/tasks/resolver-task.yaml             |tasks/task-tkn/0.2.2/task-tkn.yaml   |
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect|auth|
+      |----|--------------------------------------------------------|------|-----|--------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|      |5s   |        |    |
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun
+      |S.NO|pipeline_run_name                  |status      |
+      |----|-----------------------------------|------------|
+      |1   |http-resolver-pipelinerun          |successful  |
+    * Delete "pipelinerun" named "http-resolver-pipelinerun"
+    * Configure http server
+      |S.NO|path                                                    |status|delay|redirect|auth|
+      |----|--------------------------------------------------------|------|-----|--------|----|
+      |1   |testdata/resolvers/pipelines/http-resolver-pipeline.yaml|      |90s  |        |    |
+    * Stop exposing http server
+    * Create with http server
+      |S.NO|resource_dir                                                     |
+      |----|-----------------------------------------------------------------|
+      |1   |testdata/resolvers/pipelineruns/http-resolver-pipelinerun.yaml   |
+    * Verify pipelinerun "http-resolver-pipelinerun" failed with reason "CouldntGetPipeline" and message ""
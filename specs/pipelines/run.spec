@@ -114,3 +114,188 @@ Steps:
       |S.NO|pipeline_run_name|status    |
       |----|-----------------|----------|
       |1   |result-test-run  |successful|
+
+## Send CloudEvents of PipelineRuns and TaskRuns to the default sink: PIPELINES-03-TC09
+Tags: e2e, integration, pipelines, events, admin, mutating
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: Medium
+
+Registers an event sink as the default-cloud-events-sink of the pipelines and verifies the lifecycle events of a
+PipelineRun and of its TaskRun.
+
+Steps:
+  * Deploy event sink
+  * Register event sink for pipelines
+  * Create
+      |S.NO|resource_dir                                                                |
+      |----|----------------------------------------------------------------------------|
+      |1   |testdata/v1beta1/pipelinerun/pipelinerun-with-pipelinespec-and-taskspec.yaml|
+  * Verify pipelinerun
+      |S.NO|pipeline_run_name                        |status    |
+      |----|-----------------------------------------|----------|
+      |1   |pipelinerun-with-pipelinespec-taskspec-vb|successful|
+  * Verify event sink received events for "pipelinerun-with-pipelinespec-taskspec-vb" in order
+      |S.NO|type                                      |
+      |----|------------------------------------------|
+      |1   |dev.tekton.event.pipelinerun.started.v1   |
+      |2   |dev.tekton.event.pipelinerun.running.v1   |
+      |3   |dev.tekton.event.pipelinerun.successful.v1|
+  * Verify event sink received events for "pipelinerun-with-pipelinespec-taskspec-vb-echo-message" in order
+      |S.NO|type                                  |
+      |----|--------------------------------------|
+      |1   |dev.tekton.event.taskrun.started.v1   |
+      |2   |dev.tekton.event.taskrun.successful.v1|
+  * Verify event sink received "1" events of type "dev.tekton.event.pipelinerun.successful.v1" for "pipelinerun-with-pipelinespec-taskspec-vb"
+  * Verify event sink received event of type "dev.tekton.event.pipelinerun.successful.v1" for "pipelinerun-with-pipelinespec-taskspec-vb" with payload
+      |S.NO|path                                   |value                                    |
+      |----|---------------------------------------|-----------------------------------------|
+      |1   |pipelineRun.metadata.name              |pipelinerun-with-pipelinespec-taskspec-vb|
+      |2   |pipelineRun.status.conditions.0.reason |Succeeded                                |
+
+## Verify pipeline and task level results: PIPELINES-03-TC10
+Tags: e2e, integration, pipelines, non-admin, results
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: High
+
+Verifies the values of the results of a pipeline summing its params with two tasks, and that the result of the first
+task is passed as a param of the second one.
+
+Steps:
+  * Create
+      |S.NO|resource_dir                                         |
+      |----|-----------------------------------------------------|
+      |1   |testdata/v1beta1/pipelinerun/pipelinerun-results.yaml|
+  * Verify pipelinerun
+      |S.NO|pipeline_run_name     |status    |
+      |----|----------------------|----------|
+      |1   |pipeline-level-results|successful|
+  * Verify results of pipelinerun "pipeline-level-results"
+      |S.NO|name       |operator|value  |
+      |----|-----------|--------|-------|
+      |1   |sum        |equals  |22     |
+      |2   |partial-sum|equals  |12     |
+      |3   |all-sum    |matches |^22-12$|
+  * Verify results of task "first-add" in pipelinerun "pipeline-level-results"
+      |S.NO|name|operator|value|
+      |----|----|--------|-----|
+      |1   |sum |equals  |12   |
+  * Verify params of task "second-add" in pipelinerun "pipeline-level-results"
+      |S.NO|name  |operator|value|
+      |----|------|--------|-----|
+      |1   |first |equals  |12   |
+      |2   |second|equals  |10   |
+  * Verify tasks of pipelinerun "pipeline-level-results"
+      |S.NO|pipeline_task|status    |reason   |
+      |----|-------------|----------|---------|
+      |1   |first-add    |successful|Succeeded|
+      |2   |second-add   |successful|Succeeded|
+
+## Verify when expressions, array and object results and finally tasks: PIPELINES-03-TC11
+Tags: e2e, integration, pipelines, non-admin, results
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: High
+
+Runs a pipeline whose tasks pass array and object results, are skipped by a when expression or once a task failed, and
+whose finally task runs after the failure.
+
+Steps:
+  * Create
+      |S.NO|resource_dir                                                       |
+      |----|-------------------------------------------------------------------|
+      |1   |testdata/v1beta1/pipelinerun/pipelinerun-with-when-and-finally.yaml|
+  * Verify pipelinerun
+      |S.NO|pipeline_run_name                   |status|
+      |----|------------------------------------|------|
+      |1   |pipelinerun-with-when-and-finally-vb|failed|
+  * Verify results of task "produce" in pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|name         |operator|value                                                                          |
+      |----|-------------|--------|-------------------------------------------------------------------------------|
+      |1   |status       |equals  |ok                                                                             |
+      |2   |platforms    |equals  |["linux/amd64","linux/arm64"]                                                  |
+      |3   |platforms[0] |equals  |linux/amd64                                                                    |
+      |4   |image        |equals  |{"digest":"sha256:0123456789abcdef","url":"quay.io/openshift-pipeline/example"}|
+      |5   |image[digest]|matches |^sha256:[0-9a-f]+$                                                             |
+  * Verify params of task "consume" in pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|name    |operator|value                             |
+      |----|--------|--------|----------------------------------|
+      |1   |platform|equals  |linux/arm64                       |
+      |2   |url     |equals  |quay.io/openshift-pipeline/example|
+  * Verify tasks of pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|pipeline_task|status    |reason   |
+      |----|-------------|----------|---------|
+      |1   |produce      |successful|Succeeded|
+      |2   |consume      |successful|Succeeded|
+      |3   |fail         |failed    |Failed   |
+  * Verify skipped tasks of pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|pipeline_task|reason                             |
+      |----|-------------|-----------------------------------|
+      |1   |on-failure   |When Expressions evaluated to false|
+      |2   |after-fail   |PipelineRun was stopping           |
+  * Verify finally tasks of pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|pipeline_task|status    |reason   |
+      |----|-------------|----------|---------|
+      |1   |report       |successful|Succeeded|
+  * Verify params of task "report" in pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|name       |operator|value |
+      |----|-----------|--------|------|
+      |1   |fail-status|equals  |Failed|
+  * Verify step logs of pipelinerun "pipelinerun-with-when-and-finally-vb"
+      |S.NO|task   |step|operator   |pattern                                             |
+      |----|-------|----|-----------|----------------------------------------------------|
+      |1   |consume|echo|matches    |^quay.io/openshift-pipeline/example for linux/arm64$|
+      |2   |consume|echo|not matches|linux/amd64                                         |
+      |3   |report |echo|matches    |^fail: Failed$                                      |
+  * Verify logs of step "produce" of task "produce" in pipelinerun "pipelinerun-with-when-and-finally-vb" have lines in order
+      |S.NO|pattern    |
+      |----|-----------|
+      |1   |^ok$       |
+      |2   |linux/arm64|
+      |3   |"digest"   |
+
+## Verify task run params, results and step logs: PIPELINES-03-TC12
+Tags: e2e, integration, pipelines, non-admin, results
+Component: Pipelines
+Level: Integration
+Type: Functional
+Importance: Medium
+
+Runs a task run summing a string and an array param without a pipeline, and verifies its params, result and the logs of
+its step.
+
+Steps:
+  * Create
+      |S.NO|resource_dir                                 |
+      |----|---------------------------------------------|
+      |1   |testdata/v1beta1/taskrun/taskrun-results.yaml|
+  * Verify taskrun
+      |S.NO|task_run_name|status    |
+      |----|-------------|----------|
+      |1   |add-taskrun  |successful|
+  * Verify params of taskrun "add-taskrun"
+      |S.NO|name       |operator|value      |
+      |----|-----------|--------|-----------|
+      |1   |first      |equals  |2          |
+      |2   |operands   |equals  |["10","10"]|
+      |3   |operands[1]|equals  |10         |
+  * Verify results of taskrun "add-taskrun"
+      |S.NO|name|operator|value|
+      |----|----|--------|-----|
+      |1   |sum |equals  |22   |
+  * Verify step logs of taskrun "add-taskrun"
+      |S.NO|step|operator   |pattern    |
+      |----|----|-----------|-----------|
+      |1   |add |matches    |^first: 2$ |
+      |2   |add |not matches|adding 2$  |
+  * Verify logs of step "add" of taskrun "add-taskrun" have lines in order
+      |S.NO|pattern    |
+      |----|-----------|
+      |1   |^first: 2$ |
+      |2   |^adding 10$|
+      |3   |^adding 10$|
+      |4   |^22$       |
@@ -473,3 +473,207 @@ Steps:
     |----|-------------------|----------|
     |1   |simple-pipeline-run|successful|
   * Cleanup Triggers
+
+## Create Eventlistener with github interceptor And verify its CloudEvents: PIPELINES-05-TC18
+Tags: e2e, events, triggers, admin
+Component: Triggers
+Level: Integration
+Type: Functional
+Importance: Medium
+
+This scenario registers an event sink as the cloudEventURI of an eventlistner with github interceptor, and verifies the
+CloudEvents sent while it processes a github event: their type, source, order and the headers of the event in their payload.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                                        |
+    |----|--------------------------------------------------------------------|
+    |1   |testdata/triggers/sample-pipeline.yaml                              |
+    |2   |testdata/triggers/triggerbindings/triggerbinding.yaml               |
+    |3   |testdata/triggers/triggertemplate/triggertemplate.yaml              |
+    |4   |testdata/triggers/eventlisteners/eventlistener-embeded-binding.yaml |
+  * Verify that "eventlistener" "listener-embed-binding" exists
+  * Deploy event sink
+  * Register event sink for eventlistener "listener-embed-binding"
+  * Expose Event listener "listener-embed-binding"
+  * Mock post event to "github" interceptor with event-type "push", payload "testdata/push.json", with TLS "false"
+  * Assert eventlistener response
+  * Verify event sink received events for "listener-embed-binding" in order
+    |S.NO|type                                   |
+    |----|---------------------------------------|
+    |1   |dev.tekton.event.triggers.started.v1   |
+    |2   |dev.tekton.event.triggers.done.v1      |
+  * Verify event sink received event of type "dev.tekton.event.triggers.started.v1" from source "/listener-embed-binding"
+  * Verify event sink received event of type "dev.tekton.event.triggers.started.v1" for "listener-embed-binding" with payload
+    |S.NO|path            |value|
+    |----|----------------|-----|
+    |1   |X-Github-Event.0|push |
+  * Verify pipelinerun
+    |S.NO|pipeline_run_name  |status    |
+    |----|-------------------|----------|
+    |1   |simple-pipeline-run|successful|
+  * Cleanup Triggers
+
+## Post concurrent events to an Eventlistener within latency and error thresholds: PIPELINES-05-TC19
+Tags: e2e, triggers, load, non-admin
+Component: Triggers
+Level: Integration
+Type: Performance
+Importance: Medium
+
+This scenario posts signed github events concurrently to an eventlistner, and checks the latency percentiles of its
+responses, the share of failed requests and that every accepted event created its PipelineRun.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                                           |
+    |----|-----------------------------------------------------------------------|
+    |1   |testdata/triggers/sample-pipeline.yaml                                 |
+    |2   |testdata/triggers/triggerbindings/triggerbinding.yaml                  |
+    |3   |testdata/triggers/triggertemplate/triggertemplate-generate-name.yaml   |
+    |4   |testdata/triggers/eventlisteners/eventlistener-embeded-binding.yaml    |
+  * Verify that "eventlistener" "listener-embed-binding" exists
+  * Expose Event listener "listener-embed-binding"
+  * Post "20" events to "github" interceptor with event-type "push", payload "testdata/push.json", with TLS "false" at "5" per second with concurrency "4"
+  * Verify eventlistener load
+    |S.NO|statistic   |threshold|
+    |----|------------|---------|
+    |1   |p95         |5s       |
+    |2   |p99         |10s      |
+    |3   |error_rate  |0%       |
+    |4   |pipelineruns|20       |
+  * Cleanup Triggers
+
+## Create Eventlistener with gitlab interceptor for a generated push event: PIPELINES-05-TC20
+Tags: e2e, triggers, non-admin
+Component: Triggers
+Level: Integration
+Type: Functional
+Importance: Medium
+
+This scenario posts a gitlab push event generated for a branch of its own, instead of a payload fixture, to an
+eventlistener with gitlab interceptor.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                      |
+    |----|--------------------------------------------------|
+    |1   |testdata/triggers/gitlab/gitlab-push-listener.yaml|
+  * Verify that "eventlistener" "gitlab-listener" exists
+  * Create & Link secret "gitlab-secret" to service account "pipeline"
+  * Expose Event listener "gitlab-listener"
+  * Generate "gitlab" "push" event payload
+    |S.NO|field   |value                              |
+    |----|--------|-----------------------------------|
+    |1   |repo_url|https://gitlab.com/dibyom/triggers |
+    |2   |branch  |release-next                       |
+  * Mock post generated payload to "gitlab" interceptor with event-type "Push Hook", with TLS "false"
+  * Assert eventlistener response
+  * Verify pipelinerun
+    |S.NO|pipeline_run_name |status    |
+    |----|------------------|----------|
+    |1   |gitlab-run        |successful|
+  * Cleanup Triggers
+
+## Reject forged and invalid events with github and CEL interceptors: PIPELINES-05-TC21
+Tags: e2e, triggers, security, non-admin
+Component: Triggers
+Level: Integration
+Type: Security
+Importance: High
+
+This scenario posts events with a wrong or missing signature, a disallowed event type, a tampered body, an
+oversized or malformed body and a branch filtered out by CEL to an eventlistener with github and CEL interceptors. The
+eventlistener accepts them but must create nothing for them, and still trigger a valid event afterwards. A replayed
+delivery is not rejected by any interceptor, so it must create its PipelineRun again.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                      |
+    |----|--------------------------------------------------|
+    |1   |testdata/triggers/github/github-push-listener.yaml|
+  * Verify that "eventlistener" "github-listener" exists
+  * Create & Link secret "github-secret" to service account "pipeline"
+  * Expose Event listener "github-listener"
+  * Post negative events to "github" interceptor with event-type "push", payload "testdata/triggers/github-ctb/push.json", with TLS "false"
+    |S.NO|case             |value               |status|
+    |----|-----------------|--------------------|------|
+    |1   |wrong_hmac       |                    |202   |
+    |2   |missing_signature|                    |202   |
+    |3   |disallowed_event |issues              |202   |
+    |4   |tampered_body    |                    |202   |
+    |5   |oversized_body   |26Mi                |202   |
+    |6   |malformed_json   |                    |202   |
+    |7   |cel_mismatch     |ref=refs/heads/other|202   |
+    |8   |replay           |                    |202   |
+  * Verify eventlistener rejected negative events
+  * Mock post event to "github" interceptor with event-type "push", payload "testdata/triggers/github-ctb/push.json", with TLS "false"
+  * Assert eventlistener response
+  * Verify pipelinerun
+    |S.NO|pipeline_run_name|status    |
+    |----|-----------------|----------|
+    |1   |github-run       |successful|
+  * Cleanup Triggers
+
+## Reject forged and invalid events with gitlab interceptor: PIPELINES-05-TC22
+Tags: e2e, triggers, security, non-admin
+Component: Triggers
+Level: Integration
+Type: Security
+Importance: High
+
+This scenario posts events with a wrong or missing token, a disallowed event type, an oversized or malformed body to an
+eventlistener with gitlab interceptor, and checks it creates nothing for them
+but for a replayed delivery, which no interceptor rejects.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                      |
+    |----|--------------------------------------------------|
+    |1   |testdata/triggers/gitlab/gitlab-push-listener.yaml|
+  * Verify that "eventlistener" "gitlab-listener" exists
+  * Create & Link secret "gitlab-secret" to service account "pipeline"
+  * Expose Event listener "gitlab-listener"
+  * Post negative events to "gitlab" interceptor with event-type "Push Hook", payload "testdata/triggers/gitlab/gitlab-push-event.json", with TLS "false"
+    |S.NO|case             |value     |status|
+    |----|-----------------|----------|------|
+    |1   |wrong_hmac       |          |202   |
+    |2   |missing_signature|          |202   |
+    |3   |disallowed_event |Issue Hook|202   |
+    |4   |oversized_body   |26Mi      |202   |
+    |5   |malformed_json   |          |202   |
+    |6   |replay           |          |202   |
+  * Verify eventlistener rejected negative events
+  * Cleanup Triggers
+
+## Reject forged and invalid events with bitbucket interceptor: PIPELINES-05-TC23
+Tags: e2e, triggers, security, non-admin
+Component: Triggers
+Level: Integration
+Type: Security
+Importance: High
+
+This scenario posts events with a wrong or missing signature, a disallowed event type, a tampered body, an
+oversized or malformed body to an eventlistener with bitbucket interceptor, and checks it creates nothing for them
+but for a replayed delivery, which no interceptor rejects.
+
+Steps:
+  * Create
+    |S.NO|resource_dir                                                        |
+    |----|--------------------------------------------------------------------|
+    |1   |testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml|
+  * Verify that "eventlistener" "bitbucket-listener" exists
+  * Create & Link secret "bitbucket-secret" to service account "pipeline"
+  * Expose Event listener "bitbucket-listener"
+  * Post negative events to "bitbucket" interceptor with event-type "refs_changed", payload "testdata/triggers/bitbucket/refs-change-event.json", with TLS "false"
+    |S.NO|case             |value    |status|
+    |----|-----------------|---------|------|
+    |1   |wrong_hmac       |         |202   |
+    |2   |missing_signature|         |202   |
+    |3   |disallowed_event |pr:opened|202   |
+    |4   |tampered_body    |         |202   |
+    |5   |oversized_body   |26Mi     |202   |
+    |6   |malformed_json   |         |202   |
+    |7   |replay           |         |202   |
+  * Verify eventlistener rejected negative events
+  * Cleanup Triggers
@@ -0,0 +1,160 @@
+PIPELINES-38
+# Verify trigger rendering without a cluster
+
+Tags: offline, triggers
+
+The EventListeners of the testdata are rendered in process, like their sink would, to check how their bindings,
+templates and interceptors map the payloads to the params of the resources they create.
+
+## Render PipelineRun params from embedded and referenced bindings: PIPELINES-38-TC01
+Tags: triggers, sanity
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: High
+
+Steps:
+  * Render eventlistener "listener-embed-binding" for "github" event "push" with payload "testdata/push.json" from
+    |S.NO|resource_dir                                                        |
+    |----|--------------------------------------------------------------------|
+    |1   |testdata/triggers/eventlisteners/eventlistener-embeded-binding.yaml |
+    |2   |testdata/triggers/triggerbindings/triggerbinding.yaml               |
+    |3   |testdata/triggers/triggertemplate/triggertemplate.yaml              |
+  * Verify rendered "PipelineRun" "simple-pipeline-run" params
+    |S.NO|name       |value                                                            |
+    |----|-----------|-----------------------------------------------------------------|
+    |1   |message    |Hello from the Triggers EventListener(listener-embed-binding)!   |
+    |2   |contenttype|application/json                                                 |
+
+## Render PipelineRun params from CEL overlays of a Trigger: PIPELINES-38-TC02
+Tags: triggers, sanity
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: High
+
+Steps:
+  * Render eventlistener "listener-triggerref" for "github" event "pull_request" with payload "testdata/triggers/triggersCRD/pull-request.json" from
+    |S.NO|resource_dir                                               |
+    |----|-----------------------------------------------------------|
+    |1   |testdata/triggers/triggersCRD/eventlistener-triggerref.yaml|
+    |2   |testdata/triggers/triggersCRD/trigger.yaml                 |
+    |3   |testdata/triggers/triggersCRD/triggerbindings.yaml         |
+    |4   |testdata/triggers/triggersCRD/triggertemplate.yaml         |
+  * Verify rendered "PipelineRun" "parallel-pipelinerun" params
+    |S.NO|name   |value  |
+    |----|-------|-------|
+    |1   |message|28911bb|
+
+## Stop events filtered out by CEL: PIPELINES-38-TC03
+Tags: triggers, negative
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: Medium
+
+Steps:
+  * Render eventlistener "listener-triggerref" for "github" event "push" with payload "testdata/triggers/triggersCRD/pull-request.json" from
+    |S.NO|resource_dir                                               |
+    |----|-----------------------------------------------------------|
+    |1   |testdata/triggers/triggersCRD/eventlistener-triggerref.yaml|
+    |2   |testdata/triggers/triggersCRD/trigger.yaml                 |
+    |3   |testdata/triggers/triggersCRD/triggerbindings.yaml         |
+    |4   |testdata/triggers/triggersCRD/triggertemplate.yaml         |
+  * Verify rendered trigger "trigger" is stopped by its interceptors
+
+## Render TaskRun params from a signed Bitbucket event: PIPELINES-38-TC04
+Tags: triggers
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: Medium
+
+Steps:
+  * Render eventlistener "bitbucket-listener" for "bitbucket" event "refs_changed" with payload "testdata/triggers/bitbucket/refs-change-event.json" from
+    |S.NO|resource_dir                                                        |
+    |----|--------------------------------------------------------------------|
+    |1   |testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml|
+    |2   |testdata/triggers/bitbucket/secret.yaml                             |
+  * Verify rendered "TaskRun" "bitbucket-run" params
+    |S.NO|name    |value                                          |
+    |----|--------|-----------------------------------------------|
+    |1   |url     |http://localhost:7990/scm/~test/helloworld.git |
+    |2   |revision|master                                         |
+
+## Render PipelineRun params from a generated GitHub pull request: PIPELINES-38-TC05
+Tags: triggers
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: Medium
+
+Steps:
+  * Generate "github" "pull_request" event payload
+    |S.NO|field   |value                                   |
+    |----|--------|----------------------------------------|
+    |1   |branch  |feature-1                               |
+    |2   |revision|0123456789abcdef0123456789abcdef01234567|
+  * Render eventlistener "listener-triggerref" for "github" event "pull_request" with generated payload from
+    |S.NO|resource_dir                                               |
+    |----|-----------------------------------------------------------|
+    |1   |testdata/triggers/triggersCRD/eventlistener-triggerref.yaml|
+    |2   |testdata/triggers/triggersCRD/trigger.yaml                 |
+    |3   |testdata/triggers/triggersCRD/triggerbindings.yaml         |
+    |4   |testdata/triggers/triggersCRD/triggertemplate.yaml         |
+  * Verify rendered "PipelineRun" "parallel-pipelinerun" params
+    |S.NO|name   |value  |
+    |----|-------|-------|
+    |1   |message|0123456|
+
+## Render TaskRun params from a generated Bitbucket push: PIPELINES-38-TC06
+Tags: triggers
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: Medium
+
+Steps:
+  * Generate "bitbucket" "push" event payload
+    |S.NO|field   |value                                    |
+    |----|--------|-----------------------------------------|
+    |1   |repo_url|http://localhost:7990/scm/~test/helloworld|
+    |2   |branch  |release-1.0                              |
+  * Render eventlistener "bitbucket-listener" for "bitbucket" event "refs_changed" with generated payload from
+    |S.NO|resource_dir                                                        |
+    |----|--------------------------------------------------------------------|
+    |1   |testdata/triggers/bitbucket/bitbucket-eventlistener-interceptor.yaml|
+    |2   |testdata/triggers/bitbucket/secret.yaml                             |
+  * Verify rendered "TaskRun" "bitbucket-run" params
+    |S.NO|name    |value                                          |
+    |----|--------|-----------------------------------------------|
+    |1   |url     |http://localhost:7990/scm/~test/helloworld.git |
+    |2   |revision|release-1.0                                    |
+
+## Render PipelineRun params from a payload template: PIPELINES-38-TC07
+Tags: triggers
+Component: Triggers
+Level: Unit
+Type: Functional
+Importance: Low
+
+Steps:
+  * Render payload template "testdata/triggers/payloads/github-pull-request.json.tmpl"
+    |S.NO|name    |value                                                    |
+    |----|--------|---------------------------------------------------------|
+    |1   |action  |opened                                                   |
+    |2   |number  |42                                                       |
+    |3   |title   |Generated pull request                                   |
+    |4   |branch  |feature-1                                                |
+    |5   |repo_url|https://github.com/openshift-pipelines/release-tests.git |
+  * Render eventlistener "listener-triggerref" for "github" event "pull_request" with generated payload from
+    |S.NO|resource_dir                                               |
+    |----|-----------------------------------------------------------|
+    |1   |testdata/triggers/triggersCRD/eventlistener-triggerref.yaml|
+    |2   |testdata/triggers/triggersCRD/trigger.yaml                 |
+    |3   |testdata/triggers/triggersCRD/triggerbindings.yaml         |
+    |4   |testdata/triggers/triggersCRD/triggertemplate.yaml         |
+  * Verify rendered "PipelineRun" "parallel-pipelinerun" params
+    |S.NO|name   |value  |
+    |----|-------|-------|
+    |1   |message|a29b5e0|
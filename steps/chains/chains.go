@@ -1,8 +1,6 @@
 package chains
 
 import (
-	"os"
-
 	"github.com/getgauge-contrib/gauge-go/gauge"
 	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/oc"
@@ -30,16 +28,15 @@ var _ = gauge.Step("Verify attestation", func() {
 })
 
 var _ = gauge.Step("Verify that image registry variable is exported", func() {
-	if os.Getenv("CHAINS_REPOSITORY") == "" {
+	if operator.ChainsRepository() == "" {
 		testsuit.T.Errorf("'CHAINS_REPOSITORY' environment variable is not exported")
 	}
 })
 
 var _ = gauge.Step("Create secret with image registry credentials for SA", func() {
-	if os.Getenv("CHAINS_DOCKER_CONFIG_JSON") == "" {
+	if dockerConfig := operator.ChainsDockerConfig(); dockerConfig == "" {
 		testsuit.T.Errorf("'CHAINS_DOCKER_CONFIG_JSON' robot credentials environment variable is not exported")
 	} else {
-		dockerConfig := os.Getenv("CHAINS_DOCKER_CONFIG_JSON")
 		oc.CreateChainsImageRegistrySecret(dockerConfig)
 	}
 })
@@ -14,6 +14,7 @@ import (
 	"github.com/openshift-pipelines/release-tests/pkg/oc"
 	"github.com/openshift-pipelines/release-tests/pkg/openshift"
 	"github.com/openshift-pipelines/release-tests/pkg/operator"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 )
 
@@ -176,11 +177,17 @@ var _ = gauge.Step("Verify versioned ecosystem step actions", func() {
 
 var _ = gauge.Step("Create project <projectName>", func(projectName string) {
 	log.Printf("Check if project %v already exists", projectName)
-	if oc.CheckProjectExists(projectName) {
+	exists, err := platform.Current().NamespaceExists(store.Clients(), projectName)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	if exists {
 		log.Printf("Switch to project %v", projectName)
 	} else {
 		log.Printf("Creating project %v", projectName)
-		oc.CreateNewProject(projectName)
+		if err := platform.Current().CreateNamespace(store.Clients(), projectName); err != nil {
+			testsuit.T.Fail(err)
+		}
 	}
 	store.Clients().NewClientSet(projectName)
 	gauge.GetScenarioStore()["namespace"] = projectName
@@ -189,7 +196,7 @@ var _ = gauge.Step("Create project <projectName>", func(projectName string) {
 var _ = gauge.Step("Switch to autogenerated namespace", func() {
 	gauge_store := gauge.GetScenarioStore()
 	autogenerated_ns := gauge_store["autogenerated"].(string)
-	if oc.CheckProjectExists(autogenerated_ns) {
+	if exists, _ := platform.Current().NamespaceExists(store.Clients(), autogenerated_ns); exists {
 		log.Printf("Switch to project %v", autogenerated_ns)
 	}
 	store.Clients().NewClientSet(autogenerated_ns)
@@ -198,7 +205,7 @@ var _ = gauge.Step("Switch to autogenerated namespace", func() {
 
 var _ = gauge.Step("Delete project <projectName>", func(projectName string) {
 	log.Printf("Deleting project %v", projectName)
-	oc.DeleteProjectIgnoreErors(projectName)
+	platform.Current().DeleteNamespace(store.Clients(), projectName)
 })
 
 var _ = gauge.Step("Link secret <secret> to service account <sa>", func(secret, sa string) {
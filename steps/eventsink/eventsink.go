@@ -0,0 +1,68 @@
+package eventsink
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/getgauge-contrib/gauge-go/gauge"
+	m "github.com/getgauge-contrib/gauge-go/models"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/eventsink"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+)
+
+func sink() *eventsink.Sink {
+	s, ok := gauge.GetScenarioStore()["eventsink"].(*eventsink.Sink)
+	if !ok {
+		testsuit.T.Fail(fmt.Errorf("no event sink was deployed in the scenario"))
+	}
+	return s
+}
+
+var _ = gauge.Step("Deploy event sink", func() {
+	s, err := eventsink.Deploy(store.Clients(), store.Namespace())
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["eventsink"] = s
+})
+
+var _ = gauge.Step("Register event sink for eventlistener <elname>", func(elname string) {
+	if err := sink().RegisterEventListener(store.Clients(), store.Namespace(), elname); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
+
+var _ = gauge.Step("Register event sink for pipelines", func() {
+	if err := sink().RegisterPipelines(store.Clients()); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
+
+var _ = gauge.Step("Verify event sink received <count> events of type <type> for <subject>", func(count, eventType, subject string) {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid number of events %q: %v", count, err))
+	}
+	eventsink.AssertEvents(store.Clients(), sink(), eventsink.Filter{Type: eventType, Subject: subject}, n)
+})
+
+var _ = gauge.Step("Verify event sink received event of type <type> from source <source>", func(eventType, source string) {
+	eventsink.AssertEvents(store.Clients(), sink(), eventsink.Filter{Type: eventType, Source: source}, 1)
+})
+
+var _ = gauge.Step("Verify event sink received events for <subject> in order <table>", func(subject string, table *m.Table) {
+	var types []string
+	for _, row := range table.Rows {
+		types = append(types, row.Cells[1])
+	}
+	eventsink.AssertEventOrder(store.Clients(), sink(), eventsink.Filter{Subject: subject}, types)
+})
+
+var _ = gauge.Step("Verify event sink received event of type <type> for <subject> with payload <table>", func(eventType, subject string, table *m.Table) {
+	fields := map[string]string{}
+	for _, row := range table.Rows {
+		fields[row.Cells[1]] = row.Cells[2]
+	}
+	eventsink.AssertEvents(store.Clients(), sink(), eventsink.Filter{Type: eventType, Subject: subject, Fields: fields}, 1)
+})
@@ -0,0 +1,81 @@
+package gitserver
+
+import (
+	"fmt"
+
+	"github.com/getgauge-contrib/gauge-go/gauge"
+	m "github.com/getgauge-contrib/gauge-go/models"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/gitserver"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+)
+
+func server() *gitserver.Server {
+	s, ok := gauge.GetScenarioStore()["gitserver"].(*gitserver.Server)
+	if !ok {
+		testsuit.T.Fail(fmt.Errorf("no git server was deployed in the scenario"))
+	}
+	return s
+}
+
+var _ = gauge.Step("Deploy git server", func() {
+	s, err := gitserver.Deploy(store.Clients(), store.Namespace())
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["gitserver"] = s
+})
+
+var _ = gauge.Step("Create git repositories <table>", func(table *m.Table) {
+	type repository struct {
+		branch  string
+		private bool
+		sources []gitserver.Source
+	}
+	var names []string
+	repos := map[string]*repository{}
+	for _, row := range table.Rows {
+		name := row.Cells[1]
+		repo, ok := repos[name]
+		if !ok {
+			repo = &repository{branch: row.Cells[4]}
+			repos[name] = repo
+			names = append(names, name)
+		}
+		switch row.Cells[5] {
+		case "private":
+			repo.private = true
+		case "public":
+		default:
+			testsuit.T.Fail(fmt.Errorf("invalid visibility %q of repository %s, expected public or private", row.Cells[5], name))
+		}
+		repo.sources = append(repo.sources, gitserver.Source{Path: row.Cells[2], Target: row.Cells[3]})
+	}
+	for _, name := range names {
+		repo := repos[name]
+		files, err := gitserver.ReadSources(repo.sources)
+		if err != nil {
+			testsuit.T.Fail(err)
+		}
+		if err := server().CreateRepository(store.Clients(), name, repo.branch, repo.private, files); err != nil {
+			testsuit.T.Fail(err)
+		}
+	}
+})
+
+var _ = gauge.Step("Create with git server <table>", func(table *m.Table) {
+	for _, row := range table.Rows {
+		path, err := server().RewriteFile(row.Cells[1], store.TempDir())
+		if err != nil {
+			testsuit.T.Fail(err)
+		}
+		oc.CreateRemote(path, store.Namespace())
+	}
+})
+
+var _ = gauge.Step("Stop exposing git server", func() {
+	if err := server().Close(store.Clients()); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
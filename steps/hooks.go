@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"slices"
-	"strconv"
 	"strings"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
@@ -13,17 +13,63 @@ import (
 	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/config"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
+	"github.com/openshift-pipelines/release-tests/pkg/lease"
+	"github.com/openshift-pipelines/release-tests/pkg/mustgather"
 	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	"github.com/openshift-pipelines/release-tests/pkg/operator"
+	"github.com/openshift-pipelines/release-tests/pkg/platform"
+	"github.com/openshift-pipelines/release-tests/pkg/runreport"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	operatorapi "github.com/tektoncd/operator/pkg/apis/operator/v1alpha1"
 	"github.com/tektoncd/operator/test/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// mutatingTag marks the scenarios mutating cluster-scoped resources such as TektonConfig
+	mutatingTag = "mutating"
+	// offlineTag marks the scenarios which do not use the cluster, e.g. rendering
+	// triggers, they get no clients nor namespace
+	offlineTag = "offline"
+)
+
+// Runs Before the suite
+var _ = gauge.BeforeSuite(func(exInfo *gauge_messages.ExecutionInfo) {
+	runreport.RegisterTraceExporter()
+}, []string{}, testsuit.AND)
+
+// Runs After the suite
+var _ = gauge.AfterSuite(func(exInfo *gauge_messages.ExecutionInfo) {
+	if err := runreport.Write(config.Flags.ReportDir); err != nil {
+		log.Printf("Warning: could not write the run report: %v", err)
+		return
+	}
+	log.Printf("Run report written to %s", config.Flags.ReportDir)
+}, []string{}, testsuit.AND)
+
 // Runs Before every Secenario
 var _ = gauge.BeforeScenario(func(exInfo *gauge_messages.ExecutionInfo) {
+	runreport.BeginScenario(exInfo.CurrentSpec.Name, exInfo.CurrentSpec.FileName, exInfo.CurrentScenario.Name, exInfo.CurrentScenario.Tags)
+	if hasTag(exInfo, offlineTag) {
+		tmpDir, err := os.MkdirTemp("", "offline-")
+		if err != nil {
+			testsuit.T.Fail(fmt.Errorf("could not create the temporary directory of the scenario: %v", err))
+		}
+		gauge.GetScenarioStore()["scenario.name"] = exInfo.CurrentScenario.Name
+		gauge.GetScenarioStore()["scenario.tmpdir"] = tmpDir
+		return
+	}
+	if isMutating(exInfo) {
+		gauge.GetScenarioStore()["scenario.lease"] = lease.LockCluster()
+	} else {
+		gauge.GetScenarioStore()["scenario.lease"] = lease.LockClusterShared()
+	}
 	cs, namespace, cleanup := k8s.NewClientSet()
 	crNames := utils.GetResourceNames()
+	tmpDir, err := os.MkdirTemp("", namespace+"-")
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("could not create the temporary directory of the scenario: %v", err))
+	}
 
 	store := gauge.GetScenarioStore()
 	store["crnames"] = crNames
@@ -32,11 +78,23 @@ var _ = gauge.BeforeScenario(func(exInfo *gauge_messages.ExecutionInfo) {
 	store["namespace"] = namespace
 	store["scenario.cleanup"] = cleanup
 	store["scenario.name"] = exInfo.CurrentScenario.Name
-	store["targetNamespace"] = config.TargetNamespace
+	store["scenario.tmpdir"] = tmpDir
+	store["scenario.artifactsdir"] = mustgather.ScenarioDir(config.Flags.ArtifactsDir, exInfo.CurrentScenario.Name, namespace)
+	store["targetNamespace"] = config.TargetNamespace()
+
+	if isMutating(exInfo) {
+		snapshot, err := operator.SnapshotTektonConfig(cs, operatorapi.ConfigResourceName)
+		if err != nil {
+			testsuit.T.Fail(fmt.Errorf("could not snapshot TektonConfig of the mutating scenario: %v", err))
+		}
+		store["scenario.tektonconfig"] = snapshot
+	}
 
 	// Skip pipelines SA check if scenario has @install tag
 	if slices.Contains(exInfo.CurrentScenario.Tags, "install") {
 		log.Printf("Skipping service account check as the scenario has @install tag")
+	} else if !platform.Current().Supports(platform.PipelineServiceAccount) {
+		log.Printf("Skipping service account check as the operator does not create it on %s", platform.Current().Name())
 	} else {
 		sa := k8s.WaitForServiceAccount(cs, namespace, "pipeline")
 		if sa == nil {
@@ -47,6 +105,19 @@ var _ = gauge.BeforeScenario(func(exInfo *gauge_messages.ExecutionInfo) {
 
 // Runs After every Secenario
 var _ = gauge.AfterScenario(func(exInfo *gauge_messages.ExecutionInfo) {
+	defer runreport.EndScenario(exInfo.CurrentScenario.IsFailed)
+	if release, ok := gauge.GetScenarioStore()["scenario.lease"].(func()); ok {
+		defer release()
+	}
+	if tmpDir, ok := gauge.GetScenarioStore()["scenario.tmpdir"].(string); ok {
+		defer os.RemoveAll(tmpDir)
+	}
+
+	if exInfo.CurrentScenario.IsFailed {
+		collectArtifacts()
+	}
+	restoreTektonConfig()
+
 	switch c := gauge.GetScenarioStore()["scenario.cleanup"].(type) {
 	case func():
 		if exInfo.CurrentScenario.IsFailed {
@@ -54,33 +125,79 @@ var _ = gauge.AfterScenario(func(exInfo *gauge_messages.ExecutionInfo) {
 		} else {
 			c()
 		}
+	case nil:
+		// offline scenarios have no namespace
 	default:
 		testsuit.T.Errorf("Error: return type is not of type func()")
 	}
 }, []string{}, testsuit.AND)
 
-// Store default pruner config
-var _ = gauge.BeforeSpec(func(exInfo *gauge_messages.ExecutionInfo) {
-	cs, _, _ := k8s.NewClientSet()
+// isMutating tells whether the scenario or its spec is tagged as mutating
+// cluster-scoped resources, such scenarios hold the cluster lease exclusively
+// while they run, the other ones shared
+func isMutating(exInfo *gauge_messages.ExecutionInfo) bool {
+	return hasTag(exInfo, mutatingTag)
+}
 
-	tc, err := cs.TektonConfig().Get(context.TODO(), operatorapi.ConfigResourceName, metav1.GetOptions{})
-	if err != nil {
-		testsuit.T.Errorf("Error: could not get TektonConfig")
+// hasTag tells whether the scenario or its spec is tagged with tag
+func hasTag(exInfo *gauge_messages.ExecutionInfo, tag string) bool {
+	return slices.Contains(exInfo.CurrentScenario.Tags, tag) || slices.Contains(exInfo.CurrentSpec.Tags, tag)
+}
+
+// restoreTektonConfig reverts the changes made to TektonConfig by a mutating
+// scenario, so they do not leak into the following ones
+func restoreTektonConfig() {
+	snapshot, ok := gauge.GetScenarioStore()["scenario.tektonconfig"].(*operator.TektonConfigSnapshot)
+	if !ok {
+		return
 	}
+	cs := store.Clients()
+	if err := operator.RestoreTektonConfig(cs, snapshot); err != nil {
+		testsuit.T.Errorf("Error: %v", err)
+		return
+	}
+	if _, err := operator.WaitForTektonConfigState(cs.TektonConfig(), snapshot.Name, operator.IsTektonConfigReady); err != nil {
+		log.Printf("Warning: restored TektonConfig is not ready: %v", err)
+	}
+}
 
-	store := gauge.GetSpecStore()
-	if tc.Spec.Pruner.Keep != nil {
-		store["keep"] = strconv.FormatUint(uint64(*(tc.Spec.Pruner.Keep)), 10)
-	} else {
-		store["keep"] = "null"
+// collectArtifacts dumps the state of the scenario namespace and of the
+// installation, so the failure can be investigated after the cluster is gone
+func collectArtifacts() {
+	cs := store.Clients()
+	if cs == nil {
+		log.Printf("Warning: skipping artifacts collection as the scenario has no clients")
+		return
 	}
-	if tc.Spec.Pruner.KeepSince != nil {
-		store["keepSince"] = strconv.FormatUint(uint64(*(tc.Spec.Pruner.KeepSince)), 10)
-	} else {
-		store["keepSince"] = "null"
+	dir := store.ArtifactsDir()
+	if err := mustgather.Collect(cs, store.Namespace(), dir); err != nil {
+		log.Printf("Warning: artifacts collection was incomplete: %v", err)
+	}
+	runreport.AddDiagnostic("artifacts", dir)
+	log.Printf("Artifacts of the failed scenario collected in %s", dir)
+}
+
+// Runs Before every Step
+var _ = gauge.BeforeStep(func(exInfo *gauge_messages.ExecutionInfo) {
+	runreport.BeginStep(exInfo.CurrentStep.Step.ActualStepText)
+}, []string{}, testsuit.AND)
+
+// Runs After every Step
+var _ = gauge.AfterStep(func(exInfo *gauge_messages.ExecutionInfo) {
+	runreport.EndStep(exInfo.CurrentStep.IsFailed, exInfo.CurrentStep.ErrorMessage)
+}, []string{}, testsuit.AND)
+
+// Resolve the timeouts and poll intervals of the spec
+var _ = gauge.BeforeSpec(func(exInfo *gauge_messages.ExecutionInfo) {
+	if err := config.ApplyTimeoutOverrides(exInfo.CurrentSpec.FileName, exInfo.CurrentSpec.Tags); err != nil {
+		testsuit.T.Fail(fmt.Errorf("could not apply timeout overrides: %v", err))
 	}
-	store["resources"] = tc.Spec.Pruner.Resources
-	store["schedule"] = tc.Spec.Pruner.Schedule
+}, []string{}, testsuit.AND)
+
+// Keep the runs of other namespaces from being pruned by the auto-prune scenarios,
+// TektonConfig is restored after each of them as they are tagged mutating
+var _ = gauge.BeforeSpec(func(exInfo *gauge_messages.ExecutionInfo) {
+	cs, _, _ := k8s.NewClientSet()
 
 	// Annotate other namespace with value operator.tekton.dev/prune.skip=true
 	namespaces, err := cs.KubeClient.Kube.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
@@ -95,16 +212,3 @@ var _ = gauge.BeforeSpec(func(exInfo *gauge_messages.ExecutionInfo) {
 	}
 
 }, []string{"auto-prune"}, testsuit.AND)
-
-// Revert changes made by pruner tests
-var _ = gauge.AfterSpec(func(exInfo *gauge_messages.ExecutionInfo) {
-	keep := gauge.GetSpecStore()["keep"]
-	keepSince := gauge.GetSpecStore()["keepSince"]
-	resources := gauge.GetSpecStore()["resources"].([]string)
-	schedule := gauge.GetSpecStore()["schedule"]
-
-	resourcesList := strings.Join(resources, "\",\"")
-	patch_data := fmt.Sprintf("{\"spec\":{\"pruner\":{\"keep\":%s,\"keep-since\":%s,\"schedule\":\"%s\",\"resources\":[\"%s\"]}}}", keep, keepSince, schedule, resourcesList)
-
-	oc.UpdateTektonConfig(patch_data)
-}, []string{"auto-prune"}, testsuit.AND)
@@ -0,0 +1,85 @@
+package httpserver
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/getgauge-contrib/gauge-go/gauge"
+	m "github.com/getgauge-contrib/gauge-go/models"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/httpserver"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+)
+
+func server() *httpserver.Server {
+	s, ok := gauge.GetScenarioStore()["httpServer"].(*httpserver.Server)
+	if !ok {
+		testsuit.T.Fail(fmt.Errorf("no http server was deployed in the scenario"))
+	}
+	return s
+}
+
+var _ = gauge.Step("Deploy http server serving <table>", func(table *m.Table) {
+	var files []httpserver.File
+	for _, row := range table.Rows {
+		files = append(files, httpserver.File{Path: row.Cells[1], Target: row.Cells[2]})
+	}
+	s, err := httpserver.Deploy(store.Clients(), store.Namespace(), files)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["httpServer"] = s
+})
+
+var _ = gauge.Step("Configure http server <table>", func(table *m.Table) {
+	s := server()
+	routes := map[string]httpserver.Route{}
+	for _, row := range table.Rows {
+		var route httpserver.Route
+		if auth := row.Cells[5]; auth != "" {
+			required, err := strconv.ParseBool(auth)
+			if err != nil {
+				testsuit.T.Fail(fmt.Errorf("invalid auth %q of %s: %v", auth, row.Cells[1], err))
+			}
+			if required {
+				route = s.AuthRoute()
+			}
+		}
+		if status := row.Cells[2]; status != "" {
+			code, err := strconv.Atoi(status)
+			if err != nil {
+				testsuit.T.Fail(fmt.Errorf("invalid status %q of %s: %v", status, row.Cells[1], err))
+			}
+			route.Status = code
+		}
+		route.Delay = row.Cells[3]
+		route.Redirect = row.Cells[4]
+		routes[row.Cells[1]] = route
+	}
+	if err := s.SetRoutes(store.Clients(), routes); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
+
+var _ = gauge.Step("Reset http server routes", func() {
+	if err := server().ResetRoutes(store.Clients()); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
+
+var _ = gauge.Step("Create with http server <table>", func(table *m.Table) {
+	for _, row := range table.Rows {
+		path, err := server().RewriteFile(row.Cells[1], store.TempDir())
+		if err != nil {
+			testsuit.T.Fail(err)
+		}
+		oc.CreateRemote(path, store.Namespace())
+	}
+})
+
+var _ = gauge.Step("Stop exposing http server", func() {
+	if err := server().Close(store.Clients()); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
@@ -1,9 +1,12 @@
 package k8s
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 )
@@ -58,3 +61,19 @@ var _ = gauge.Step("Assert if cronjob with prefix <cronJobName> is <status> in t
 		k8s.AssertCronjobNotPresent(store.Clients(), cronJobName, namespace)
 	}
 })
+
+var _ = gauge.Step("Scale deployment <deployment> to <replicas> replicas in target namespace", func(deployment, replicas string) {
+	n, err := strconv.ParseInt(replicas, 10, 32)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid number of replicas %q: %v", replicas, err))
+	}
+	k8s.ScaleDeployment(store.Clients(), deployment, store.TargetNamespace(), int32(n))
+})
+
+var _ = gauge.Step("Assert if <replicas> pods related to <deployment> are present and running in <namespace> namespace", func(replicas, deployment, namespace string) {
+	n, err := strconv.Atoi(replicas)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid number of pods %q: %v", replicas, err))
+	}
+	k8s.AssertPodsOfDeploymentRunning(store.Clients(), deployment, namespace, n)
+})
@@ -3,6 +3,7 @@ package olm
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
@@ -61,3 +62,31 @@ var _ = gauge.Step("Verify the total number of roles in <namespace> namespace ma
 		testsuit.T.Errorf("Mismatch in number of roles in namespace %s. Expected: %d (from table), Actual: %d (from oc get role)\nFull output of 'oc get role -n %s':\n%s", namespace, expectedCount, actualCount, namespace, fullOutput)
 	}
 })
+
+var _ = gauge.Step("Assert HPA exists for <deployment> deployment in <namespace> namespace", func(deployment, namespace string) {
+	operator.AssertHPAExists(store.Clients(), deployment, namespace)
+})
+
+var _ = gauge.Step("Update HPA <hpa> in TektonConfig <component> options with min replicas <min> and max replicas <max>", func(hpa, component, min, max string) {
+	minReplicas, err := strconv.ParseInt(min, 10, 32)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid min replicas %q: %v", min, err))
+	}
+	maxReplicas, err := strconv.ParseInt(max, 10, 32)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid max replicas %q: %v", max, err))
+	}
+	operator.UpdateHPAOptions(component, hpa, int32(minReplicas), int32(maxReplicas))
+})
+
+var _ = gauge.Step("Assert HPA of <deployment> deployment in <namespace> namespace matches TektonConfig options", func(deployment, namespace string) {
+	operator.AssertHPAMatchesTektonConfig(store.Clients(), deployment, namespace)
+})
+
+var _ = gauge.Step("Assert HPA of <deployment> deployment in <namespace> namespace converges to <replicas> replicas", func(deployment, namespace, replicas string) {
+	n, err := strconv.ParseInt(replicas, 10, 32)
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid number of replicas %q: %v", replicas, err))
+	}
+	operator.AssertHPAConvergesTo(store.Clients(), deployment, namespace, int32(n))
+})
@@ -29,6 +29,97 @@ var _ = gauge.Step("Verify pipelinerun <table>", func(table *m.Table) {
 	}
 })
 
+var _ = gauge.Step("Verify pipelinerun <prname> failed with reason <reason> and message <message>", func(prname, reason, message string) {
+	pipelines.ValidatePipelineRunFailure(store.Clients(), prname, reason, message, store.Namespace())
+})
+
+// expectations reads a table with the columns S.NO|name|operator|value
+func expectations(table *m.Table) []pipelines.Expectation {
+	var expected []pipelines.Expectation
+	for _, row := range table.Rows {
+		expected = append(expected, pipelines.Expectation{Name: row.Cells[1], Operator: row.Cells[2], Value: row.Cells[3]})
+	}
+	return expected
+}
+
+// childStatuses reads a table with the columns S.NO|pipeline_task|status|reason
+func childStatuses(table *m.Table) []pipelines.ChildStatus {
+	var expected []pipelines.ChildStatus
+	for _, row := range table.Rows {
+		expected = append(expected, pipelines.ChildStatus{PipelineTask: row.Cells[1], Status: row.Cells[2], Reason: row.Cells[3]})
+	}
+	return expected
+}
+
+var _ = gauge.Step("Verify results of pipelinerun <prname> <table>", func(prname string, table *m.Table) {
+	pipelines.AssertPipelineRunResults(store.Clients(), prname, store.Namespace(), expectations(table))
+})
+
+var _ = gauge.Step("Verify results of taskrun <trname> <table>", func(trname string, table *m.Table) {
+	pipelines.AssertTaskRunResults(store.Clients(), trname, store.Namespace(), expectations(table))
+})
+
+var _ = gauge.Step("Verify params of taskrun <trname> <table>", func(trname string, table *m.Table) {
+	pipelines.AssertTaskRunParams(store.Clients(), trname, store.Namespace(), expectations(table))
+})
+
+var _ = gauge.Step("Verify results of task <task> in pipelinerun <prname> <table>", func(task, prname string, table *m.Table) {
+	pipelines.AssertPipelineTaskResults(store.Clients(), prname, task, store.Namespace(), expectations(table))
+})
+
+var _ = gauge.Step("Verify params of task <task> in pipelinerun <prname> <table>", func(task, prname string, table *m.Table) {
+	pipelines.AssertPipelineTaskParams(store.Clients(), prname, task, store.Namespace(), expectations(table))
+})
+
+var _ = gauge.Step("Verify tasks of pipelinerun <prname> <table>", func(prname string, table *m.Table) {
+	pipelines.AssertChildStatuses(store.Clients(), prname, store.Namespace(), childStatuses(table))
+})
+
+var _ = gauge.Step("Verify skipped tasks of pipelinerun <prname> <table>", func(prname string, table *m.Table) {
+	var expected []pipelines.SkippedTask
+	for _, row := range table.Rows {
+		expected = append(expected, pipelines.SkippedTask{PipelineTask: row.Cells[1], Reason: row.Cells[2]})
+	}
+	pipelines.AssertSkippedTasks(store.Clients(), prname, store.Namespace(), expected)
+})
+
+var _ = gauge.Step("Verify finally tasks of pipelinerun <prname> <table>", func(prname string, table *m.Table) {
+	pipelines.AssertFinalTasks(store.Clients(), prname, store.Namespace(), childStatuses(table))
+})
+
+var _ = gauge.Step("Verify step logs of pipelinerun <prname> <table>", func(prname string, table *m.Table) {
+	var expected []pipelines.LogExpectation
+	for _, row := range table.Rows {
+		expected = append(expected, pipelines.LogExpectation{PipelineTask: row.Cells[1], Step: row.Cells[2], Operator: row.Cells[3], Pattern: row.Cells[4]})
+	}
+	pipelines.AssertPipelineRunStepLogs(store.Clients(), prname, store.Namespace(), expected)
+})
+
+var _ = gauge.Step("Verify step logs of taskrun <trname> <table>", func(trname string, table *m.Table) {
+	var expected []pipelines.LogExpectation
+	for _, row := range table.Rows {
+		expected = append(expected, pipelines.LogExpectation{Step: row.Cells[1], Operator: row.Cells[2], Pattern: row.Cells[3]})
+	}
+	pipelines.AssertTaskRunStepLogs(store.Clients(), trname, store.Namespace(), expected)
+})
+
+// patterns reads a table with the columns S.NO|pattern
+func patterns(table *m.Table) []string {
+	var expected []string
+	for _, row := range table.Rows {
+		expected = append(expected, row.Cells[1])
+	}
+	return expected
+}
+
+var _ = gauge.Step("Verify logs of step <step> of task <task> in pipelinerun <prname> have lines in order <table>", func(step, task, prname string, table *m.Table) {
+	pipelines.AssertPipelineRunStepLogLines(store.Clients(), prname, task, step, store.Namespace(), patterns(table))
+})
+
+var _ = gauge.Step("Verify logs of step <step> of taskrun <trname> have lines in order <table>", func(step, trname string, table *m.Table) {
+	pipelines.AssertTaskRunStepLogLines(store.Clients(), trname, step, store.Namespace(), patterns(table))
+})
+
 var _ = gauge.Step("Watch for pipelinerun resources", func() {
 	pipelines.WatchForPipelineRun(store.Clients(), store.Namespace())
 })
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/getgauge-contrib/gauge-go/gauge"
+	m "github.com/getgauge-contrib/gauge-go/models"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/oc"
+	"github.com/openshift-pipelines/release-tests/pkg/operator"
+	"github.com/openshift-pipelines/release-tests/pkg/registry"
+	"github.com/openshift-pipelines/release-tests/pkg/store"
+)
+
+func current() *registry.Registry {
+	r, ok := gauge.GetScenarioStore()["registry"].(*registry.Registry)
+	if !ok {
+		testsuit.T.Fail(fmt.Errorf("no registry was deployed in the scenario"))
+	}
+	return r
+}
+
+func deploy(opts registry.Options) {
+	r, err := registry.Deploy(store.Clients(), store.Namespace(), opts)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["registry"] = r
+}
+
+var _ = gauge.Step("Deploy registry", func() {
+	deploy(registry.Options{})
+})
+
+var _ = gauge.Step("Deploy registry with authentication <auth> and TLS <tls>", func(auth, tls string) {
+	var opts registry.Options
+	var err error
+	if opts.Auth, err = strconv.ParseBool(auth); err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid authentication %q: %v", auth, err))
+	}
+	if opts.TLS, err = strconv.ParseBool(tls); err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid TLS %q: %v", tls, err))
+	}
+	deploy(opts)
+})
+
+var _ = gauge.Step("Mirror <prefix> to registry", func(prefix string) {
+	current().Mirror(prefix)
+})
+
+var _ = gauge.Step("Publish Tekton bundle <reference> to registry <table>", func(reference string, table *m.Table) {
+	var files []string
+	for _, row := range table.Rows {
+		files = append(files, row.Cells[1])
+	}
+	if _, err := current().PublishBundle(store.Clients(), reference, files); err != nil {
+		testsuit.T.Fail(err)
+	}
+})
+
+var _ = gauge.Step("Create with registry <table>", func(table *m.Table) {
+	for _, row := range table.Rows {
+		path, err := current().RewriteFile(row.Cells[1], store.TempDir())
+		if err != nil {
+			testsuit.T.Fail(err)
+		}
+		oc.CreateRemote(path, store.Namespace())
+	}
+})
+
+var _ = gauge.Step("Use registry repository <repository> for chains", func(repository string) {
+	r := current()
+	dockerConfig, err := r.DockerConfigJSON()
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	exposed, err := r.Exposed(store.Clients())
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	chains := operator.ChainsRegistry{
+		Repository:        r.Host + "/" + repository,
+		ExposedRepository: exposed + "/" + repository,
+		DockerConfig:      string(dockerConfig),
+		Insecure:          r.CA == nil,
+	}
+	if r.CA != nil {
+		chains.CAFile = filepath.Join(store.TempDir(), "registry-ca.crt")
+		chains.ServerName = r.ServerName()
+		if err := os.WriteFile(chains.CAFile, r.CA.CertPEM(), 0600); err != nil {
+			testsuit.T.Fail(fmt.Errorf("failed to write the CA of the registry: %v", err))
+		}
+	}
+	operator.UseChainsRegistry(chains)
+})
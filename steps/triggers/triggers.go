@@ -1,9 +1,17 @@
 package triggers
 
 import (
+	"fmt"
+	"net/http"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
+	m "github.com/getgauge-contrib/gauge-go/models"
+	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/openshift-pipelines/release-tests/pkg/config"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
 	"github.com/openshift-pipelines/release-tests/pkg/triggers"
 )
@@ -41,8 +49,179 @@ var _ = gauge.Step("Mock post event to <interceptor> interceptor with event-type
 	gauge.GetScenarioStore()["response"] = triggers.MockPostEvent(store.GetScenarioData("route"), interceptor, eventType, payload, isTLS)
 })
 
+var _ = gauge.Step("Post <requests> events to <interceptor> interceptor with event-type <eventType>, payload <payload>, with TLS <tls> at <rate> per second with concurrency <concurrency>", func(requests, interceptor, eventType, payload, tls, rate, concurrency string) {
+	isTLS, _ := strconv.ParseBool(tls)
+	body, err := os.ReadFile(config.Path(payload))
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("could not load test data from file %s: %v", payload, err))
+	}
+	load := triggers.Load{Interceptor: interceptor, EventType: eventType, Payload: body, TLS: isTLS}
+	if load.Requests, err = strconv.Atoi(requests); err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid number of requests %q: %v", requests, err))
+	}
+	if load.Rate, err = strconv.ParseFloat(rate, 64); err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid rate %q: %v", rate, err))
+	}
+	if load.Concurrency, err = strconv.Atoi(concurrency); err != nil {
+		testsuit.T.Fail(fmt.Errorf("invalid concurrency %q: %v", concurrency, err))
+	}
+	result, err := triggers.PostLoad(store.GetScenarioData("route"), load)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.WriteMessage("%s", result)
+	gauge.GetScenarioStore()["load"] = result
+})
+
+var _ = gauge.Step("Verify eventlistener load <table>", func(table *m.Table) {
+	thresholds := triggers.LoadThresholds{Percentiles: map[float64]time.Duration{}}
+	for _, row := range table.Rows {
+		statistic, threshold := row.Cells[1], row.Cells[2]
+		var err error
+		switch {
+		case statistic == "error_rate":
+			var percent float64
+			percent, err = strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+			thresholds.MaxErrorRate = percent / 100
+		case statistic == "pipelineruns":
+			thresholds.PipelineRuns, err = strconv.Atoi(threshold)
+		case strings.HasPrefix(statistic, "p"):
+			var p float64
+			var limit time.Duration
+			if p, err = strconv.ParseFloat(strings.TrimPrefix(statistic, "p"), 64); err == nil {
+				limit, err = time.ParseDuration(threshold)
+				thresholds.Percentiles[p] = limit
+			}
+		default:
+			err = fmt.Errorf("unknown statistic, expected p<percentile>, error_rate or pipelineruns")
+		}
+		if err != nil {
+			testsuit.T.Fail(fmt.Errorf("invalid threshold %q of %s: %v", threshold, statistic, err))
+		}
+	}
+	result, _ := gauge.GetScenarioStore()["load"].(triggers.LoadResult)
+	triggers.AssertLoad(store.Clients(), result, store.GetScenarioData("elname"), store.Namespace(), thresholds)
+})
+
+var _ = gauge.Step("Post negative events to <interceptor> interceptor with event-type <eventType>, payload <payload>, with TLS <tls> <table>", func(interceptor, eventType, payload, tls string, table *m.Table) {
+	isTLS, _ := strconv.ParseBool(tls)
+	body, err := os.ReadFile(config.Path(payload))
+	if err != nil {
+		testsuit.T.Fail(fmt.Errorf("could not load test data from file %s: %v", payload, err))
+	}
+	var events []triggers.NegativeEvent
+	for _, row := range table.Rows {
+		e := triggers.NegativeEvent{Case: row.Cells[1], Value: row.Cells[2], Status: http.StatusAccepted}
+		if status := row.Cells[3]; status != "" {
+			if e.Status, err = strconv.Atoi(status); err != nil {
+				testsuit.T.Fail(fmt.Errorf("invalid status %q of %s event: %v", status, e.Case, err))
+			}
+		}
+		events = append(events, e)
+	}
+	results, err := triggers.PostNegativeEvents(store.GetScenarioData("route"), interceptor, eventType, body, isTLS, events)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["negative"] = results
+})
+
+var _ = gauge.Step("Verify eventlistener rejected negative events", func() {
+	results, _ := gauge.GetScenarioStore()["negative"].([]triggers.NegativeResult)
+	triggers.AssertNegativeEvents(store.Clients(), results, store.GetScenarioData("elname"), store.Namespace())
+})
+
+var _ = gauge.Step("Generate <provider> <event> event payload <table>", func(provider, event string, table *m.Table) {
+	e := triggers.WebhookEvent{Provider: provider, Event: event}
+	fields := map[string]*string{
+		"repo_url": &e.RepoURL, "branch": &e.Branch, "base_branch": &e.BaseBranch, "tag": &e.Tag,
+		"revision": &e.Revision, "message": &e.Message, "author": &e.Author, "title": &e.Title, "action": &e.Action,
+	}
+	for _, row := range table.Rows {
+		name, value := row.Cells[1], row.Cells[2]
+		if name == "number" {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				testsuit.T.Fail(fmt.Errorf("invalid pull request number %q: %v", value, err))
+			}
+			e.Number = number
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			testsuit.T.Fail(fmt.Errorf("unknown field %q of %s %s event", name, provider, event))
+		}
+		*field = value
+	}
+	payload, err := triggers.BuildPayload(e)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["payload"] = payload
+})
+
+var _ = gauge.Step("Render payload template <template> <table>", func(tmpl string, table *m.Table) {
+	vars := map[string]interface{}{}
+	for key, value := range gauge.GetScenarioStore() {
+		if s, ok := value.(string); ok {
+			vars[key] = s
+		}
+	}
+	for _, row := range table.Rows {
+		vars[row.Cells[1]] = row.Cells[2]
+	}
+	payload, err := triggers.RenderPayload(tmpl, vars)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["payload"] = payload
+})
+
+var _ = gauge.Step("Mock post generated payload to <interceptor> interceptor with event-type <eventType>, with TLS <tls>", func(interceptor, eventType, tls string) {
+	isTLS, _ := strconv.ParseBool(tls)
+	gauge.GetScenarioStore()["response"] = triggers.MockPostPayload(store.GetScenarioData("route"), interceptor, eventType, store.GetPayload(), isTLS)
+})
+
 var _ = gauge.Step("Get route for eventlistener <elname>", func(elname string) {
 	routeurl := triggers.GetRoute(elname, store.Namespace())
 	store.PutScenarioData("route", routeurl)
 	store.PutScenarioData("elname", elname)
 })
+
+var _ = gauge.Step("Render eventlistener <elname> for <interceptor> event <eventType> with payload <payload> from <table>", func(elname, interceptor, eventType, payload string, table *m.Table) {
+	var manifests []string
+	for _, row := range table.Rows {
+		manifests = append(manifests, row.Cells[1])
+	}
+	rendered, err := triggers.RenderEventListener(elname, manifests, interceptor, eventType, payload)
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["rendered"] = rendered
+})
+
+var _ = gauge.Step("Render eventlistener <elname> for <interceptor> event <eventType> with generated payload from <table>", func(elname, interceptor, eventType string, table *m.Table) {
+	var manifests []string
+	for _, row := range table.Rows {
+		manifests = append(manifests, row.Cells[1])
+	}
+	rendered, err := triggers.RenderEventListenerPayload(elname, manifests, interceptor, eventType, store.GetPayload())
+	if err != nil {
+		testsuit.T.Fail(err)
+	}
+	gauge.GetScenarioStore()["rendered"] = rendered
+})
+
+var _ = gauge.Step("Verify rendered <kind> <name> params <table>", func(kind, name string, table *m.Table) {
+	want := map[string]string{}
+	for _, row := range table.Rows {
+		want[row.Cells[1]] = row.Cells[2]
+	}
+	rendered, _ := gauge.GetScenarioStore()["rendered"].([]triggers.RenderedTrigger)
+	triggers.AssertRenderedParams(rendered, kind, name, want)
+})
+
+var _ = gauge.Step("Verify rendered trigger <trigger> is stopped by its interceptors", func(trigger string) {
+	rendered, _ := gauge.GetScenarioStore()["rendered"].([]triggers.RenderedTrigger)
+	triggers.AssertTriggerStopped(rendered, trigger)
+})
@@ -9,6 +9,7 @@ import (
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
 	"github.com/getgauge-contrib/gauge-go/testsuit"
+	"github.com/google/shlex"
 	"github.com/openshift-pipelines/release-tests/pkg/cmd"
 	"github.com/openshift-pipelines/release-tests/pkg/k8s"
 	"github.com/openshift-pipelines/release-tests/pkg/store"
@@ -54,3 +55,11 @@ var _ = gauge.Step("Validate that route URL contains <expectedOutput>", func(exp
 var _ = gauge.Step("Wait for <deploymentName> deployment", func(deploymentName string) {
 	k8s.ValidateDeployments(store.Clients(), store.Namespace(), deploymentName)
 })
+
+var _ = gauge.Step("Run <command>", func(command string) {
+	args, err := shlex.Split(command)
+	if err != nil || len(args) == 0 {
+		testsuit.T.Fail(fmt.Errorf("invalid command %q: %v", command, err))
+	}
+	log.Printf("output: %s\n", cmd.MustSucceed(args...).Stdout())
+})